@@ -3,6 +3,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
 	"time"
 
@@ -14,11 +15,14 @@ import (
 const timerDuration = 10 * time.Millisecond
 
 func main() {
+	configPath := flag.String("config", "examples/observe.yaml", "path to the observe config file")
+	flag.Parse()
+
 	ctx := context.Background()
 
 	client, err := observe.Init(ctx,
 		observe.WithLoaders(
-			config.FileLoader{Path: "examples/observe.yaml"},
+			config.FileLoader{Path: *configPath},
 			config.EnvLoader{},
 		),
 	)
@@ -0,0 +1,90 @@
+// Package baggage promotes allowlisted W3C baggage members to telemetry attributes.
+package baggage
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelbaggage "go.opentelemetry.io/otel/baggage"
+)
+
+// Promoter copies allowlisted baggage members found in a context onto attribute sets.
+// A nil Promoter promotes nothing, so callers can use it unconditionally.
+type Promoter struct {
+	keys []string
+}
+
+// NewPromoter builds a Promoter for the supplied keys. It returns nil when no usable key is provided.
+func NewPromoter(keys []string) *Promoter {
+	seen := make(map[string]struct{}, len(keys))
+	normalized := make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		seen[key] = struct{}{}
+		normalized = append(normalized, key)
+	}
+
+	if len(normalized) == 0 {
+		return nil
+	}
+
+	return &Promoter{keys: normalized}
+}
+
+// Keys returns a copy of the allowlisted keys.
+func (p *Promoter) Keys() []string {
+	if p == nil {
+		return nil
+	}
+
+	return append([]string(nil), p.keys...)
+}
+
+// Attributes returns the allowlisted baggage members present in ctx, in allowlist order.
+func (p *Promoter) Attributes(ctx context.Context) []attribute.KeyValue {
+	if p == nil || ctx == nil {
+		return nil
+	}
+
+	bag := otelbaggage.FromContext(ctx)
+	if bag.Len() == 0 {
+		return nil
+	}
+
+	var attrs []attribute.KeyValue
+
+	for _, key := range p.keys {
+		member := bag.Member(key)
+		if member.Key() == "" {
+			continue
+		}
+
+		attrs = append(attrs, attribute.String(key, member.Value()))
+	}
+
+	return attrs
+}
+
+// Append returns attrs extended with the allowlisted baggage members present in ctx.
+// The input slice is never modified in place.
+func (p *Promoter) Append(ctx context.Context, attrs []attribute.KeyValue) []attribute.KeyValue {
+	promoted := p.Attributes(ctx)
+	if len(promoted) == 0 {
+		return attrs
+	}
+
+	out := make([]attribute.KeyValue, 0, len(attrs)+len(promoted))
+	out = append(out, attrs...)
+
+	return append(out, promoted...)
+}
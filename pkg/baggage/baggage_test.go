@@ -0,0 +1,86 @@
+package baggage_test
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelbaggage "go.opentelemetry.io/otel/baggage"
+
+	"github.com/hyp3rd/observe/pkg/baggage"
+)
+
+func TestPromoterAttributesFollowsAllowlist(t *testing.T) {
+	t.Parallel()
+
+	promoter := baggage.NewPromoter([]string{"tenant.id", " region ", "tenant.id", "missing"})
+
+	ctx := withBaggage(t, map[string]string{
+		"tenant.id": "acme",
+		"region":    "eu-west-1",
+		"secret":    "hidden",
+	})
+
+	attrs := promoter.Attributes(ctx)
+	if len(attrs) != 2 {
+		t.Fatalf("expected two promoted attributes, got %v", attrs)
+	}
+
+	if attrs[0] != attribute.String("tenant.id", "acme") || attrs[1] != attribute.String("region", "eu-west-1") {
+		t.Fatalf("unexpected attributes %v", attrs)
+	}
+}
+
+func TestPromoterAppendDoesNotMutateInput(t *testing.T) {
+	t.Parallel()
+
+	promoter := baggage.NewPromoter([]string{"tenant.id"})
+	ctx := withBaggage(t, map[string]string{"tenant.id": "acme"})
+
+	base := make([]attribute.KeyValue, 1, 4)
+	base[0] = attribute.String("route", "/orders")
+
+	out := promoter.Append(ctx, base)
+	if len(out) != 2 || len(base) != 1 {
+		t.Fatalf("unexpected lengths: out=%d base=%d", len(out), len(base))
+	}
+
+	if base[:2][1].Valid() {
+		t.Fatal("expected input backing array to be untouched")
+	}
+}
+
+func TestNilPromoter(t *testing.T) {
+	t.Parallel()
+
+	promoter := baggage.NewPromoter(nil)
+	if promoter != nil {
+		t.Fatal("expected nil promoter for empty allowlist")
+	}
+
+	ctx := withBaggage(t, map[string]string{"tenant.id": "acme"})
+	if attrs := promoter.Attributes(ctx); attrs != nil {
+		t.Fatalf("expected no attributes from nil promoter, got %v", attrs)
+	}
+}
+
+func withBaggage(t *testing.T, values map[string]string) context.Context {
+	t.Helper()
+
+	members := make([]otelbaggage.Member, 0, len(values))
+	for key, value := range values {
+		member, err := otelbaggage.NewMember(key, value)
+		if err != nil {
+			t.Fatalf("new baggage member: %v", err)
+		}
+
+		members = append(members, member)
+	}
+
+	bag, err := otelbaggage.New(members...)
+	if err != nil {
+		t.Fatalf("new baggage: %v", err)
+	}
+
+	return otelbaggage.ContextWithBaggage(context.Background(), bag)
+}
@@ -7,10 +7,15 @@ import (
 
 // Config is the canonical configuration consumed by the observe runtime.
 // It is intentionally verbose to capture all required knobs up front.
+// SDKDisabled is a kill switch: the runtime installs no-op providers process-wide and
+// ignores the exporter settings.
 type Config struct {
+	SDKDisabled     bool                  `yaml:"sdk_disabled"    json:"sdk_disabled"`
 	Service         ServiceConfig         `yaml:"service"         json:"service"`
 	Exporters       ExporterConfig        `yaml:"exporters"       json:"exporters"`
+	Metrics         MetricsConfig         `yaml:"metrics"         json:"metrics"`
 	Sampling        SamplingConfig        `yaml:"sampling"        json:"sampling"`
+	Propagation     PropagationConfig     `yaml:"propagation"     json:"propagation"`
 	Instrumentation InstrumentationConfig `yaml:"instrumentation" json:"instrumentation"`
 	Logging         LoggingConfig         `yaml:"logging"         json:"logging"`
 	Diagnostics     DiagnosticsConfig     `yaml:"diagnostics"     json:"diagnostics"`
@@ -25,11 +30,13 @@ type ServiceConfig struct {
 	Attributes  map[string]string `yaml:"attributes"  json:"attributes"`
 }
 
-// BatchConfig defines batch processor settings.
+// BatchConfig defines batch processor settings. Timeout is the delay between scheduled
+// exports; ExportTimeout bounds a single export (default 30s).
 type BatchConfig struct {
 	Enabled        bool          `yaml:"enabled"          json:"enabled"`
 	MaxExportBatch int           `yaml:"max_export_batch" json:"max_export_batch"`
 	Timeout        time.Duration `yaml:"timeout"          json:"timeout"`
+	ExportTimeout  time.Duration `yaml:"export_timeout"   json:"export_timeout"`
 	MaxQueueSize   int           `yaml:"max_queue_size"   json:"max_queue_size"`
 }
 
@@ -49,17 +56,129 @@ type TLSConfig struct {
 	Insecure bool   `yaml:"insecure"  json:"insecure"`
 }
 
-// SamplingConfig defines tracing sampling strategies.
+// SamplingConfig defines tracing sampling strategies. The rules mode samples each trace with
+// the first of Rules that matches its root span, and at the Argument ratio when none does.
 type SamplingConfig struct {
-	Mode          string              `yaml:"mode"           json:"mode"`
-	Argument      float64             `yaml:"argument"       json:"argument"`
-	TenantLimiter TenantLimiterConfig `yaml:"tenant_limiter" json:"tenant_limiter"`
+	Mode          string               `yaml:"mode"           json:"mode"`
+	Argument      float64              `yaml:"argument"       json:"argument"`
+	Rules         []SamplingRuleConfig `yaml:"rules"          json:"rules"`
+	TenantLimiter TenantLimiterConfig  `yaml:"tenant_limiter" json:"tenant_limiter"`
+	Tail          TailSamplingConfig   `yaml:"tail"           json:"tail"`
 }
 
-// TenantLimiterConfig throttles noisy tenants.
+// SamplingRuleConfig is one rule of the rules sampling mode. Match selects spans; Sampler is
+// always_on, always_off or trace_id_ratio, which samples Ratio of the matching traces.
+type SamplingRuleConfig struct {
+	Match   SamplingRuleMatch `yaml:"match"   json:"match"`
+	Sampler string            `yaml:"sampler" json:"sampler"`
+	Ratio   float64           `yaml:"ratio"   json:"ratio"`
+}
+
+// SamplingRuleMatch selects spans by name glob (* and ?), kind, instrumentation scope name glob
+// and attribute value globs; every field set must match, and an empty match selects every
+// span. Kind is one of internal, server, client, producer or consumer.
+type SamplingRuleMatch struct {
+	Name       string            `yaml:"name"       json:"name"`
+	Kind       string            `yaml:"kind"       json:"kind"`
+	Scope      string            `yaml:"scope"      json:"scope"`
+	Attributes map[string]string `yaml:"attributes" json:"attributes"`
+}
+
+// TenantLimiterConfig throttles noisy tenants. The tenant is read from the Key span attribute
+// or, failing that, the Key baggage member (default "tenant.id"). Each tenant may start Rate
+// sampled traces per second, bursting up to Burst (default Rate, at least 1); Overrides sets
+// the rate for individual tenants, where 0 drops all of a tenant's traces. MaxTenants bounds
+// the tenants tracked at once (default 10000), forgetting the least recently seen.
 type TenantLimiterConfig struct {
-	Enabled bool    `yaml:"enabled" json:"enabled"`
-	Rate    float64 `yaml:"rate"    json:"rate"`
+	Enabled    bool               `yaml:"enabled"     json:"enabled"`
+	Rate       float64            `yaml:"rate"        json:"rate"`
+	Burst      float64            `yaml:"burst"       json:"burst"`
+	Key        string             `yaml:"key"         json:"key"`
+	Overrides  map[string]float64 `yaml:"overrides"   json:"overrides"`
+	MaxTenants int                `yaml:"max_tenants" json:"max_tenants"`
+}
+
+// TailSamplingConfig buffers the spans of each trace in memory until its local root span ends,
+// or DecisionWait (default 10s) after its first span ended, then exports the whole trace if
+// any of Policies keeps it. MaxTraces (default 10000) and MaxSpans (default 100000) cap the
+// buffer; when either is exceeded the oldest trace is decided early. Keep the head sampler at
+// always_on or parentbased_always_on so every trace reaches the tail stage.
+type TailSamplingConfig struct {
+	Enabled      bool               `yaml:"enabled"       json:"enabled"`
+	DecisionWait time.Duration      `yaml:"decision_wait" json:"decision_wait"`
+	MaxTraces    int                `yaml:"max_traces"    json:"max_traces"`
+	MaxSpans     int                `yaml:"max_spans"     json:"max_spans"`
+	Policies     []TailPolicyConfig `yaml:"policies"      json:"policies"`
+}
+
+// TailPolicyConfig is a single tail sampling policy; Type selects it:
+//   - error: any span ended with an error status.
+//   - latency: the trace lasted at least Threshold, from its first start to its last end.
+//   - attribute: any span carries the Key attribute, with one of Values when set.
+//   - probabilistic: Ratio of traces, chosen consistently by trace ID.
+//   - rate_limit: at most Rate traces per second.
+//   - and, or: combine the nested Policies, evaluated in order.
+//
+// Name labels the policy in diagnostics (default "<type>_<index>").
+type TailPolicyConfig struct {
+	Name      string             `yaml:"name"      json:"name"`
+	Type      string             `yaml:"type"      json:"type"`
+	Threshold time.Duration      `yaml:"threshold" json:"threshold"`
+	Key       string             `yaml:"key"       json:"key"`
+	Values    []string           `yaml:"values"    json:"values"`
+	Ratio     float64            `yaml:"ratio"     json:"ratio"`
+	Rate      float64            `yaml:"rate"      json:"rate"`
+	Policies  []TailPolicyConfig `yaml:"policies"  json:"policies"`
+}
+
+// PropagationConfig selects the context propagators installed globally.
+// Supported values: tracecontext, baggage, b3, b3multi, jaeger, none.
+type PropagationConfig struct {
+	Propagators []string      `yaml:"propagators" json:"propagators"`
+	Baggage     BaggageConfig `yaml:"baggage"     json:"baggage"`
+}
+
+// BaggageConfig allowlists W3C baggage members promoted to telemetry attributes.
+// Attributes apply to spans and log records; MetricAttributes is kept separate
+// so metric cardinality can be bounded more tightly.
+type BaggageConfig struct {
+	Attributes       []string `yaml:"attributes"        json:"attributes"`
+	MetricAttributes []string `yaml:"metric_attributes" json:"metric_attributes"`
+}
+
+// MetricsConfig customizes the metric streams produced by the meter provider.
+type MetricsConfig struct {
+	Views []MetricViewConfig `yaml:"views" json:"views"`
+}
+
+// MetricViewConfig compiles into an SDK view. Match selects instruments; the remaining
+// fields rewrite the matching streams. Drop removes the instrument entirely, Buckets or
+// Exponential replace the histogram aggregation, and KeepAttributes or DropAttributes
+// filter attribute keys.
+type MetricViewConfig struct {
+	Match          MetricViewMatch          `yaml:"match"           json:"match"`
+	Name           string                   `yaml:"name"            json:"name"`
+	Description    string                   `yaml:"description"     json:"description"`
+	Buckets        []float64                `yaml:"buckets"         json:"buckets"`
+	Exponential    *ExponentialBucketConfig `yaml:"exponential"     json:"exponential"`
+	KeepAttributes []string                 `yaml:"keep_attributes" json:"keep_attributes"`
+	DropAttributes []string                 `yaml:"drop_attributes" json:"drop_attributes"`
+	Drop           bool                     `yaml:"drop"            json:"drop"`
+}
+
+// MetricViewMatch selects instruments by name glob (* and ?), meter name and kind. Kind is one
+// of counter, up_down_counter, histogram, gauge, observable_counter, observable_up_down_counter
+// or observable_gauge.
+type MetricViewMatch struct {
+	Instrument string `yaml:"instrument" json:"instrument"`
+	Meter      string `yaml:"meter"      json:"meter"`
+	Kind       string `yaml:"kind"       json:"kind"`
+}
+
+// ExponentialBucketConfig selects a base-2 exponential histogram; zero values use SDK defaults.
+type ExponentialBucketConfig struct {
+	MaxSize  int32 `yaml:"max_size"  json:"max_size"`
+	MaxScale int32 `yaml:"max_scale" json:"max_scale"`
 }
 
 // InstrumentationConfig toggles modules.
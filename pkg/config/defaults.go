@@ -11,6 +11,7 @@ const (
 	defaultInterval          = 500 * time.Millisecond
 	defaultMaxInterval       = 5 * time.Second
 	tenantLimiterDefaultRate = 10
+	defaultMetricInterval    = time.Minute
 )
 
 // DefaultConfig returns a Config populated with production-safe defaults.
@@ -44,6 +45,10 @@ func DefaultConfig() Config {
 					Insecure: true,
 				},
 				Compression: "gzip",
+				MetricReader: MetricReaderConfig{
+					Interval:    defaultMetricInterval,
+					Temporality: "cumulative",
+				},
 			},
 		},
 		Sampling: SamplingConfig{
@@ -54,6 +59,9 @@ func DefaultConfig() Config {
 				Rate:    tenantLimiterDefaultRate,
 			},
 		},
+		Propagation: PropagationConfig{
+			Propagators: []string{"tracecontext", "baggage"},
+		},
 		Instrumentation: InstrumentationConfig{
 			HTTP: HTTPInstrumentationConfig{
 				Enabled: true,
@@ -255,7 +255,10 @@ func envKeyToPath(key string) []string {
 func isListKey(path []string) bool {
 	switch strings.Join(path, ".") {
 	case "instrumentation.http.ignored_routes",
-		"instrumentation.grpc.metadata_allowlist":
+		"instrumentation.grpc.metadata_allowlist",
+		"propagation.propagators",
+		"propagation.baggage.attributes",
+		"propagation.baggage.metric_attributes":
 		return true
 	default:
 		return false
@@ -2,8 +2,10 @@ package config_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"testing/fstest"
+	"time"
 
 	"github.com/hyp3rd/observe/pkg/config"
 )
@@ -54,3 +56,219 @@ exporters:
 		t.Fatalf("unexpected ignored routes: %#v", got)
 	}
 }
+
+func TestLoadPerSignalOTLPOverrides(t *testing.T) {
+	t.Parallel()
+
+	fs := fstest.MapFS{
+		"observe.yaml": {
+			Data: []byte(`
+service:
+  name: vendor-service
+exporters:
+  otlp:
+    protocol: grpc
+    endpoint: ""
+    insecure: true
+    headers:
+      x-team: payments
+    traces:
+      protocol: http
+      endpoint: traces.vendor.example:443
+      insecure: false
+      headers:
+        x-api-key: traces-key
+    metrics:
+      endpoint: metrics.vendor.example:4317
+      headers:
+        x-api-key: metrics-key
+`),
+		},
+	}
+
+	cfg, err := config.Load(context.Background(), config.FileLoader{FS: fs})
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	traces := cfg.Exporters.OTLP.ForSignal("traces")
+	if traces.Protocol != "http" || traces.Endpoint != "traces.vendor.example:443" || traces.Insecure {
+		t.Fatalf("unexpected effective trace settings %+v", traces)
+	}
+
+	if traces.Headers["x-api-key"] != "traces-key" || traces.Headers["x-team"] != "payments" {
+		t.Fatalf("expected merged trace headers, got %v", traces.Headers)
+	}
+
+	metrics := cfg.Exporters.OTLP.ForSignal("metrics")
+	if metrics.Protocol != "grpc" || metrics.Endpoint != "metrics.vendor.example:4317" || !metrics.Insecure {
+		t.Fatalf("expected metrics to inherit shared settings, got %+v", metrics)
+	}
+
+	if metrics.Headers["x-api-key"] != "metrics-key" || cfg.Exporters.OTLP.Headers["x-api-key"] != "" {
+		t.Fatalf("expected per-signal headers without mutating the shared block, got %v", metrics.Headers)
+	}
+
+	if logs := cfg.Exporters.OTLP.ForSignal("logs"); logs.Endpoint != "" {
+		t.Fatalf("expected logs to inherit the empty shared endpoint, got %q", logs.Endpoint)
+	}
+
+	err = config.Validate(cfg)
+	if err != nil {
+		t.Fatalf("expected per-signal endpoints to satisfy validation, got %v", err)
+	}
+}
+
+func TestLoadMapsStandardOTelEnvironment(t *testing.T) {
+	t.Setenv("OTEL_SERVICE_NAME", "otel-service")
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "service.name=ignored,deployment.environment=prod,team=core%2Cobs")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "https://collector.example.com:4318")
+	t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "http/protobuf")
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "x-api-key=abc%3D")
+	t.Setenv("OTEL_EXPORTER_OTLP_TIMEOUT", "2500")
+	t.Setenv("OTEL_EXPORTER_OTLP_LOGS_ENDPOINT", "http://logs:4318")
+	t.Setenv("OTEL_TRACES_SAMPLER", "parentbased_traceidratio")
+	t.Setenv("OTEL_TRACES_SAMPLER_ARG", "0.25")
+	t.Setenv("OTEL_BSP_MAX_QUEUE_SIZE", "4096")
+	t.Setenv("OTEL_BSP_SCHEDULE_DELAY", "1000")
+	t.Setenv("OTEL_METRIC_EXPORT_INTERVAL", "15000")
+	t.Setenv("OBSERVE_EXPORTERS__OTLP__COMPRESSION", "none")
+	t.Setenv("OTEL_EXPORTER_OTLP_COMPRESSION", "gzip")
+
+	cfg, err := config.Load(context.Background(), config.OTelEnvLoader{}, config.EnvLoader{})
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if cfg.Service.Name != "otel-service" || cfg.Service.Environment != "prod" ||
+		cfg.Service.Attributes["team"] != "core,obs" {
+		t.Fatalf("unexpected service config: %+v", cfg.Service)
+	}
+
+	otlp := cfg.Exporters.OTLP
+	if otlp.Endpoint != "collector.example.com:4318" || otlp.Insecure || otlp.Protocol != "http" {
+		t.Fatalf("unexpected otlp endpoint settings: %q insecure=%v protocol=%q", otlp.Endpoint, otlp.Insecure, otlp.Protocol)
+	}
+
+	if otlp.Headers["x-api-key"] != "abc=" || otlp.Timeout != 2500*time.Millisecond {
+		t.Fatalf("unexpected otlp headers or timeout: %v %v", otlp.Headers, otlp.Timeout)
+	}
+
+	if otlp.Compression != "none" {
+		t.Fatalf("expected OBSERVE_* to override OTEL_*, got compression %q", otlp.Compression)
+	}
+
+	if otlp.Logs == nil || otlp.Logs.Endpoint != "logs:4318" || otlp.Logs.Insecure == nil || !*otlp.Logs.Insecure {
+		t.Fatalf("unexpected logs override: %+v", otlp.Logs)
+	}
+
+	if cfg.Sampling.Mode != "trace_id_ratio" || cfg.Sampling.Argument != 0.25 {
+		t.Fatalf("unexpected sampling config: %+v", cfg.Sampling)
+	}
+
+	if otlp.Batch.MaxQueueSize != 4096 || otlp.Batch.Timeout != time.Second {
+		t.Fatalf("unexpected batch config: %+v", otlp.Batch)
+	}
+
+	if otlp.MetricReader.Interval != 15*time.Second {
+		t.Fatalf("unexpected metric reader interval: %v", otlp.MetricReader.Interval)
+	}
+}
+
+func TestOTelEnvLoaderKeepsEndpointPaths(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "https://gateway.example.com/otlp/")
+	t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "http/protobuf")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "https://gateway.example.com/custom/v1/traces")
+
+	cfg, err := config.Load(context.Background(), config.OTelEnvLoader{})
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	otlp := cfg.Exporters.OTLP
+	if otlp.Endpoint != "gateway.example.com" || otlp.PathPrefix != "/otlp" {
+		t.Fatalf("expected the base path to become the path prefix, got %q %q", otlp.Endpoint, otlp.PathPrefix)
+	}
+
+	if traces := otlp.ForSignal("traces"); traces.URLPath != "/custom/v1/traces" {
+		t.Fatalf("expected the traces endpoint path to be kept as is, got %q", traces.URLPath)
+	}
+
+	if metrics := otlp.ForSignal("metrics"); metrics.URLPath != "" {
+		t.Fatalf("expected metrics to keep deriving their path from the prefix, got %q", metrics.URLPath)
+	}
+}
+
+func TestOTelEnvLoaderRejectsUnknownSampler(t *testing.T) {
+	t.Setenv("OTEL_TRACES_SAMPLER", "jaeger_remote")
+
+	_, err := config.Load(context.Background(), config.OTelEnvLoader{})
+	if err == nil {
+		t.Fatal("expected an unsupported sampler to fail loading")
+	}
+}
+
+func TestOTelEnvLoaderRejectsJSONProtocol(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL", "http/json")
+
+	_, err := config.Load(context.Background(), config.OTelEnvLoader{})
+	if err == nil || !strings.Contains(err.Error(), "unsupported OTEL_EXPORTER_OTLP_TRACES_PROTOCOL") {
+		t.Fatalf("expected http/json to fail loading, got %v", err)
+	}
+}
+
+func TestLoadHonoursExportSwitches(t *testing.T) {
+	t.Setenv("OTEL_LOGS_EXPORTER", "none")
+	t.Setenv("OBSERVE_SDK_DISABLED", "true")
+
+	fs := fstest.MapFS{
+		"observe.yaml": {
+			Data: []byte(`
+exporters:
+  mode: none
+  otlp:
+    endpoint: ""
+`),
+		},
+	}
+
+	cfg, err := config.Load(context.Background(), config.FileLoader{FS: fs}, config.OTelEnvLoader{}, config.EnvLoader{})
+	if err != nil {
+		t.Fatalf("expected mode none to load without an endpoint, got %v", err)
+	}
+
+	if !cfg.SDKDisabled {
+		t.Fatal("expected OBSERVE_SDK_DISABLED to set the kill switch")
+	}
+
+	if cfg.Exporters.Exporting() {
+		t.Fatal("expected mode none to disable every signal")
+	}
+
+	cfg.Exporters.Mode = ""
+	if cfg.Exporters.SignalEnabled("logs") || !cfg.Exporters.SignalEnabled("traces") {
+		t.Fatal("expected OTEL_LOGS_EXPORTER=none to disable only the logs signal")
+	}
+}
+
+func TestLoadRejectsZeroBreakerThreshold(t *testing.T) {
+	t.Parallel()
+
+	fs := fstest.MapFS{
+		"observe.yaml": {
+			Data: []byte(`
+exporters:
+  otlp:
+    endpoint: collector:4317
+    circuit_breaker:
+      enabled: true
+      error_threshold: 0
+`),
+		},
+	}
+
+	_, err := config.Load(context.Background(), config.FileLoader{FS: fs})
+	if err == nil {
+		t.Fatal("expected error_threshold 0 to fail loading instead of falling back to the default")
+	}
+}
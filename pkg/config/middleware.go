@@ -1,9 +1,11 @@
 package config
 
 // HTTPInstrumentationConfig configures HTTP middleware.
+// PublicEndpoint links incoming remote span contexts instead of parenting on them.
 type HTTPInstrumentationConfig struct {
-	Enabled       bool     `yaml:"enabled"        json:"enabled"`
-	IgnoredRoutes []string `yaml:"ignored_routes" json:"ignored_routes"`
+	Enabled        bool     `yaml:"enabled"         json:"enabled"`
+	IgnoredRoutes  []string `yaml:"ignored_routes"  json:"ignored_routes"`
+	PublicEndpoint bool     `yaml:"public_endpoint" json:"public_endpoint"`
 }
 
 // GRPCInstrumentationConfig configures gRPC interceptors.
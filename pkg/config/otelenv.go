@@ -0,0 +1,372 @@
+package config
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hyp3rd/ewrap"
+)
+
+// OTelEnvLoader maps the environment variables defined by the OpenTelemetry specification
+// (OTEL_SDK_DISABLED, OTEL_SERVICE_NAME, OTEL_RESOURCE_ATTRIBUTES, OTEL_EXPORTER_OTLP_*,
+// OTEL_{TRACES,METRICS,LOGS}_EXPORTER=none, OTEL_TRACES_SAMPLER, OTEL_BSP_* and
+// OTEL_METRIC_EXPORT_*) onto Config. It runs before EnvLoader in the default
+// chain, so OBSERVE_* variables still take precedence.
+//
+// Endpoints are URLs: the scheme selects insecure transport ("http") or TLS ("https") and
+// only the host and port are kept, since the exporters append the standard signal paths.
+type OTelEnvLoader struct{}
+
+type otelEnv struct {
+	values map[string]any
+}
+
+// Load implements Loader.
+func (OTelEnvLoader) Load(context.Context) (map[string]any, error) {
+	env := &otelEnv{values: map[string]any{}}
+
+	err := env.loadSwitches()
+	if err != nil {
+		return nil, err
+	}
+
+	env.loadService()
+
+	err = env.loadExporter()
+	if err != nil {
+		return nil, err
+	}
+
+	err = env.loadSampler()
+	if err != nil {
+		return nil, err
+	}
+
+	err = env.loadBatch()
+	if err != nil {
+		return nil, err
+	}
+
+	err = env.loadMetricReader()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(env.values) == 0 {
+		return nil, newLoaderSkipError()
+	}
+
+	return env.values, nil
+}
+
+func (e *otelEnv) set(value any, path ...string) {
+	e.values = setNested(e.values, path, value)
+}
+
+// loadSwitches applies the SDK kill switch and the per-signal exporter selection. Only
+// "none" is honoured for OTEL_*_EXPORTER; exporter types are chosen through the config.
+func (e *otelEnv) loadSwitches() error {
+	if raw := os.Getenv("OTEL_SDK_DISABLED"); raw != "" {
+		disabled, err := strconv.ParseBool(raw)
+		if err != nil {
+			return ewrap.Wrap(err, "parse OTEL_SDK_DISABLED")
+		}
+
+		e.set(disabled, "sdk_disabled")
+	}
+
+	for _, signal := range []string{"traces", "metrics", "logs"} {
+		key := "OTEL_" + strings.ToUpper(signal) + "_EXPORTER"
+		if strings.EqualFold(strings.TrimSpace(os.Getenv(key)), "none") {
+			e.set(false, "exporters", "signals", signal, "enabled")
+		}
+	}
+
+	return nil
+}
+
+// loadService applies OTEL_RESOURCE_ATTRIBUTES, lifting the service.* and deployment
+// environment keys onto their ServiceConfig fields, then OTEL_SERVICE_NAME, which wins.
+func (e *otelEnv) loadService() {
+	attrs := parseKeyValues(os.Getenv("OTEL_RESOURCE_ATTRIBUTES"))
+
+	extra := map[string]any{}
+
+	for key, value := range attrs {
+		switch key {
+		case "service.name":
+			e.set(value, "service", "name")
+		case "service.namespace":
+			e.set(value, "service", "namespace")
+		case "service.version":
+			e.set(value, "service", "version")
+		case "deployment.environment", "deployment.environment.name":
+			e.set(value, "service", "environment")
+		default:
+			extra[key] = value
+		}
+	}
+
+	if len(extra) > 0 {
+		e.set(extra, "service", "attributes")
+	}
+
+	if name := os.Getenv("OTEL_SERVICE_NAME"); name != "" {
+		e.set(name, "service", "name")
+	}
+}
+
+func (e *otelEnv) loadExporter() error {
+	// The base endpoint's path prefixes every signal path; a signal endpoint's path is used as is.
+	err := e.loadOTLP("OTEL_EXPORTER_OTLP_", "path_prefix", "exporters", "otlp")
+	if err != nil {
+		return err
+	}
+
+	for _, signal := range []string{"traces", "metrics", "logs"} {
+		prefix := "OTEL_EXPORTER_OTLP_" + strings.ToUpper(signal) + "_"
+
+		err = e.loadOTLP(prefix, "url_path", "exporters", "otlp", signal)
+		if err != nil {
+			return err
+		}
+	}
+
+	if temporality := os.Getenv("OTEL_EXPORTER_OTLP_METRICS_TEMPORALITY_PREFERENCE"); temporality != "" {
+		e.set(strings.ToLower(temporality), "exporters", "otlp", "metric_reader", "temporality")
+	}
+
+	return nil
+}
+
+func (e *otelEnv) loadOTLP(prefix, pathKey string, path ...string) error {
+	at := func(key string) []string {
+		return append(append([]string(nil), path...), key)
+	}
+
+	if raw := os.Getenv(prefix + "ENDPOINT"); raw != "" {
+		endpoint, urlPath, insecure, ok := parseOTLPEndpoint(raw)
+		if !ok {
+			return ewrap.Newf("parse %sENDPOINT %q", prefix, raw)
+		}
+
+		e.set(endpoint, at("endpoint")...)
+
+		if urlPath != "" {
+			e.set(urlPath, at(pathKey)...)
+		}
+
+		if insecure != nil {
+			e.set(*insecure, at("insecure")...)
+		}
+	}
+
+	if raw := os.Getenv(prefix + "INSECURE"); raw != "" {
+		insecure, err := strconv.ParseBool(raw)
+		if err != nil {
+			return ewrap.Wrapf(err, "parse %sINSECURE", prefix)
+		}
+
+		e.set(insecure, at("insecure")...)
+	}
+
+	if raw := os.Getenv(prefix + "PROTOCOL"); raw != "" {
+		protocol, ok := otlpProtocol(raw)
+		if !ok {
+			return ewrap.Newf("unsupported %sPROTOCOL %q", prefix, raw)
+		}
+
+		e.set(protocol, at("protocol")...)
+	}
+
+	if headers := parseKeyValues(os.Getenv(prefix + "HEADERS")); len(headers) > 0 {
+		values := make(map[string]any, len(headers))
+		for key, value := range headers {
+			values[key] = value
+		}
+
+		e.set(values, at("headers")...)
+	}
+
+	timeout, ok, err := envMillis(prefix + "TIMEOUT")
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		e.set(timeout, at("timeout")...)
+	}
+
+	if compression := os.Getenv(prefix + "COMPRESSION"); compression != "" {
+		e.set(strings.ToLower(compression), at("compression")...)
+	}
+
+	return nil
+}
+
+// loadSampler maps OTEL_TRACES_SAMPLER onto the sampling modes the runtime supports.
+// traceidratio is served by the parent-based trace_id_ratio mode.
+func (e *otelEnv) loadSampler() error {
+	if sampler := strings.ToLower(os.Getenv("OTEL_TRACES_SAMPLER")); sampler != "" {
+		switch sampler {
+		case "always_on", "always_off", "parentbased_always_on", "parentbased_always_off":
+			e.set(sampler, "sampling", "mode")
+		case "traceidratio", "parentbased_traceidratio":
+			e.set("trace_id_ratio", "sampling", "mode")
+		default:
+			return ewrap.Newf("unsupported OTEL_TRACES_SAMPLER %q", sampler)
+		}
+	}
+
+	if raw := os.Getenv("OTEL_TRACES_SAMPLER_ARG"); raw != "" {
+		argument, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return ewrap.Wrap(err, "parse OTEL_TRACES_SAMPLER_ARG")
+		}
+
+		e.set(argument, "sampling", "argument")
+	}
+
+	return nil
+}
+
+func (e *otelEnv) loadBatch() error {
+	for _, setting := range []struct {
+		env    string
+		key    string
+		millis bool
+	}{
+		{env: "OTEL_BSP_SCHEDULE_DELAY", key: "timeout", millis: true},
+		{env: "OTEL_BSP_EXPORT_TIMEOUT", key: "export_timeout", millis: true},
+		{env: "OTEL_BSP_MAX_QUEUE_SIZE", key: "max_queue_size"},
+		{env: "OTEL_BSP_MAX_EXPORT_BATCH_SIZE", key: "max_export_batch"},
+	} {
+		if setting.millis {
+			value, ok, err := envMillis(setting.env)
+			if err != nil {
+				return err
+			}
+
+			if ok {
+				e.set(value, "exporters", "otlp", "batch", setting.key)
+			}
+
+			continue
+		}
+
+		raw := os.Getenv(setting.env)
+		if raw == "" {
+			continue
+		}
+
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return ewrap.Wrapf(err, "parse %s", setting.env)
+		}
+
+		e.set(value, "exporters", "otlp", "batch", setting.key)
+	}
+
+	return nil
+}
+
+func (e *otelEnv) loadMetricReader() error {
+	interval, ok, err := envMillis("OTEL_METRIC_EXPORT_INTERVAL")
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		e.set(interval, "exporters", "otlp", "metric_reader", "interval")
+	}
+
+	timeout, ok, err := envMillis("OTEL_METRIC_EXPORT_TIMEOUT")
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		e.set(timeout, "exporters", "otlp", "metric_reader", "timeout")
+	}
+
+	return nil
+}
+
+// envMillis reads a duration given in milliseconds, as every OTEL_* timeout and interval is.
+func envMillis(key string) (time.Duration, bool, error) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return 0, false, nil
+	}
+
+	millis, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+	if err != nil {
+		return 0, false, ewrap.Wrapf(err, "parse %s", key)
+	}
+
+	return time.Duration(millis) * time.Millisecond, true, nil
+}
+
+// parseOTLPEndpoint splits an endpoint URL into host:port and its path, which is empty when the
+// URL has none beyond "/". insecure is nil when the value has no scheme.
+func parseOTLPEndpoint(raw string) (string, string, *bool, bool) {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		return raw, "", nil, true
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" {
+		return "", "", nil, false
+	}
+
+	insecure := parsed.Scheme == "http"
+
+	return parsed.Host, strings.TrimSuffix(parsed.Path, "/"), &insecure, true
+}
+
+// otlpProtocol maps an OTEL_EXPORTER_OTLP_PROTOCOL value onto the OTLP config. http/json is not
+// accepted: the HTTP exporters only send protobuf.
+func otlpProtocol(raw string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "grpc":
+		return "grpc", true
+	case "http/protobuf", "http":
+		return "http", true
+	default:
+		return "", false
+	}
+}
+
+// parseKeyValues parses the W3C-baggage-like "key1=value1,key2=value2" lists used by
+// OTEL_RESOURCE_ATTRIBUTES and OTEL_EXPORTER_OTLP_HEADERS. Values are percent-decoded and
+// malformed members are skipped.
+func parseKeyValues(raw string) map[string]string {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+
+	out := map[string]string{}
+
+	for member := range strings.SplitSeq(raw, ",") {
+		key, value, ok := strings.Cut(member, "=")
+		key = strings.TrimSpace(key)
+
+		if !ok || key == "" {
+			continue
+		}
+
+		decoded, err := url.PathUnescape(strings.TrimSpace(value))
+		if err != nil {
+			continue
+		}
+
+		out[key] = decoded
+	}
+
+	return out
+}
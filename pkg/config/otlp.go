@@ -1,21 +1,338 @@
 package config
 
-import "time"
+import (
+	"maps"
+	"strings"
+	"time"
+)
 
 // ExporterConfig enumerates supported telemetry exporters.
+// OTLP is the primary exporter; Backends fan telemetry out to additional named exporters.
+// Clearing the OTLP endpoint disables the primary exporter when at least one backend is configured.
+// Mode "none" keeps the providers recording but builds no exporter at all; Signals switches
+// exporting off for individual signals.
 type ExporterConfig struct {
-	OTLP *OTLPConfig `yaml:"otlp" json:"otlp"`
+	Mode       string            `yaml:"mode"       json:"mode"`
+	Signals    SignalsConfig     `yaml:"signals"    json:"signals"`
+	OTLP       *OTLPConfig       `yaml:"otlp"       json:"otlp"`
+	Backends   []ExporterSpec    `yaml:"backends"   json:"backends"`
+	Prometheus *PrometheusConfig `yaml:"prometheus" json:"prometheus"`
+}
+
+// ExportModeNone disables every exporter while the providers keep recording and sampling.
+const ExportModeNone = "none"
+
+// SignalsConfig toggles exporting per signal.
+type SignalsConfig struct {
+	Traces  SignalConfig `yaml:"traces"  json:"traces"`
+	Metrics SignalConfig `yaml:"metrics" json:"metrics"`
+	Logs    SignalConfig `yaml:"logs"    json:"logs"`
+}
+
+// SignalConfig toggles a single signal; a nil Enabled leaves it enabled.
+type SignalConfig struct {
+	Enabled *bool `yaml:"enabled" json:"enabled"`
+}
+
+func (s SignalConfig) enabled() bool {
+	return s.Enabled == nil || *s.Enabled
+}
+
+// SignalEnabled reports whether signal ("traces", "metrics" or "logs") may be exported.
+// Every signal is disabled in mode "none".
+func (c ExporterConfig) SignalEnabled(signal string) bool {
+	if strings.EqualFold(strings.TrimSpace(c.Mode), ExportModeNone) {
+		return false
+	}
+
+	switch signal {
+	case "traces":
+		return c.Signals.Traces.enabled()
+	case "metrics":
+		return c.Signals.Metrics.enabled()
+	case "logs":
+		return c.Signals.Logs.enabled()
+	default:
+		return false
+	}
+}
+
+// Exporting reports whether at least one signal may be exported.
+func (c ExporterConfig) Exporting() bool {
+	return c.SignalEnabled("traces") || c.SignalEnabled("metrics") || c.SignalEnabled("logs")
+}
+
+// PrometheusConfig exposes metrics for scraping alongside any push exporters.
+// The handler is mounted on the diagnostics server unless ListenAddr starts a dedicated listener.
+type PrometheusConfig struct {
+	Enabled          bool   `yaml:"enabled"           json:"enabled"`
+	Path             string `yaml:"path"              json:"path"`
+	ListenAddr       string `yaml:"listen_addr"       json:"listen_addr"`
+	NativeHistograms bool   `yaml:"native_histograms" json:"native_histograms"`
+	WithoutUnits     bool   `yaml:"without_units"     json:"without_units"`
+}
+
+// ExporterSpec declares a named exporter resolved through the exporter registry.
+// Signals defaults to every signal the exporter type supports. Typed settings blocks
+// (such as OTLP) serve built-in types; Settings carries free-form options for custom types.
+type ExporterSpec struct {
+	Name           string                `yaml:"name"            json:"name"`
+	Type           string                `yaml:"type"            json:"type"`
+	Signals        []string              `yaml:"signals"         json:"signals"`
+	Batch          *BatchConfig          `yaml:"batch"           json:"batch"`
+	MetricReader   *MetricReaderConfig   `yaml:"metric_reader"   json:"metric_reader"`
+	OTLP           *OTLPConfig           `yaml:"otlp"            json:"otlp"`
+	Console        *ConsoleConfig        `yaml:"console"         json:"console"`
+	Zipkin         *ZipkinConfig         `yaml:"zipkin"          json:"zipkin"`
+	File           *FileConfig           `yaml:"file"            json:"file"`
+	Queue          *QueueConfig          `yaml:"queue"           json:"queue"`
+	CircuitBreaker *CircuitBreakerConfig `yaml:"circuit_breaker" json:"circuit_breaker"`
+	Settings       map[string]any        `yaml:"settings"        json:"settings"`
+}
+
+// MetricReaderSettings resolves the periodic reader settings for spec: its own block when set,
+// otherwise the one from its OTLP settings.
+func (s ExporterSpec) MetricReaderSettings() MetricReaderConfig {
+	if s.MetricReader != nil {
+		return *s.MetricReader
+	}
+
+	if s.OTLP != nil {
+		return s.OTLP.MetricReader
+	}
+
+	return MetricReaderConfig{}
+}
+
+// MetricReaderConfig configures the periodic reader driving a metric exporter. Interval and
+// Timeout default to one minute and 30 seconds. Temporality is "cumulative" (default),
+// "delta" or "lowmemory", following the OTEL_EXPORTER_OTLP_METRICS_TEMPORALITY_PREFERENCE values.
+type MetricReaderConfig struct {
+	Interval    time.Duration `yaml:"interval"    json:"interval"`
+	Timeout     time.Duration `yaml:"timeout"     json:"timeout"`
+	Temporality string        `yaml:"temporality" json:"temporality"`
+}
+
+// ConsoleConfig configures the human-readable console exporter.
+// Output is "stdout" (default) or "stderr"; Attributes limits printed span attributes to the listed keys.
+type ConsoleConfig struct {
+	Output     string   `yaml:"output"     json:"output"`
+	Attributes []string `yaml:"attributes" json:"attributes"`
+}
+
+// ZipkinConfig configures the Zipkin v2 JSON trace exporter.
+// URL is the full collector endpoint, e.g. http://zipkin:9411/api/v2/spans.
+type ZipkinConfig struct {
+	URL         string            `yaml:"url"         json:"url"`
+	Headers     map[string]string `yaml:"headers"     json:"headers"`
+	Timeout     time.Duration     `yaml:"timeout"     json:"timeout"`
+	Retry       RetryConfig       `yaml:"retry"       json:"retry"`
+	TLS         TLSConfig         `yaml:"tls"         json:"tls"`
+	Compression string            `yaml:"compression" json:"compression"`
+}
+
+// FileConfig configures the OTLP-JSON file exporter. The active file rotates once it exceeds
+// MaxSizeMB or MaxAge (zero disables either limit); Compress gzips rotated files and
+// MaxBackups caps how many are kept (zero keeps all).
+type FileConfig struct {
+	Path       string        `yaml:"path"        json:"path"`
+	MaxSizeMB  int           `yaml:"max_size_mb" json:"max_size_mb"`
+	MaxAge     time.Duration `yaml:"max_age"     json:"max_age"`
+	MaxBackups int           `yaml:"max_backups" json:"max_backups"`
+	Compress   bool          `yaml:"compress"    json:"compress"`
+}
+
+// QueueConfig enables a write-ahead queue on local disk between the batch processor and
+// the exporter. Failed batches are persisted under Dir and replayed every ReplayInterval
+// and on restart. Once MaxSizeMB is reached, Eviction decides whether the oldest queued
+// batch ("drop_oldest", default) or the incoming one ("drop_newest") is discarded.
+type QueueConfig struct {
+	Enabled        bool          `yaml:"enabled"         json:"enabled"`
+	Dir            string        `yaml:"dir"             json:"dir"`
+	MaxSizeMB      int           `yaml:"max_size_mb"     json:"max_size_mb"`
+	Eviction       string        `yaml:"eviction"        json:"eviction"`
+	ReplayInterval time.Duration `yaml:"replay_interval" json:"replay_interval"`
+}
+
+// CircuitBreakerConfig stops calling a failing exporter. The circuit opens once the share of
+// failed exports among the last Window batches reaches ErrorThreshold (after at least
+// MinRequests batches); while open, batches are dropped without being exported. After
+// Cooldown it lets ProbeBatches batches through and closes again once they all succeed.
+// ProbeBatches counts whole export batches, whose size the batch settings determine; the
+// breaker never splits a batch. ErrorThreshold must be within (0,1] when set.
+// Defaults: ErrorThreshold 0.5, Window 20, MinRequests 5, Cooldown 30s, ProbeBatches 1.
+type CircuitBreakerConfig struct {
+	Enabled        bool          `yaml:"enabled"         json:"enabled"`
+	ErrorThreshold *float64      `yaml:"error_threshold" json:"error_threshold"`
+	Window         int           `yaml:"window"          json:"window"`
+	MinRequests    int           `yaml:"min_requests"    json:"min_requests"`
+	Cooldown       time.Duration `yaml:"cooldown"        json:"cooldown"`
+	ProbeBatches   int           `yaml:"probe_batches"   json:"probe_batches"`
+}
+
+// AuthConfig attaches credentials that may rotate to every export, in addition to the static
+// Headers. Mode is "oauth2" (client-credentials grant, tokens cached until shortly before they
+// expire), "token_file" (a bearer token re-read whenever the file changes, such as a Kubernetes
+// projected service account token) or "provider" (a HeaderProvider registered under Provider).
+type AuthConfig struct {
+	Mode      string           `yaml:"mode"       json:"mode"`
+	OAuth2    *OAuth2Config    `yaml:"oauth2"     json:"oauth2"`
+	TokenFile *TokenFileConfig `yaml:"token_file" json:"token_file"`
+	Provider  string           `yaml:"provider"   json:"provider"`
+}
+
+// OAuth2Config configures the OAuth2 client-credentials grant. ClientSecretFile is read on every
+// token request, so the secret can rotate on disk. Tokens are refreshed RefreshBefore their
+// expiry (default one minute), but no later than halfway through their lifetime; Timeout
+// bounds each token request (default 10s).
+type OAuth2Config struct {
+	TokenURL         string            `yaml:"token_url"          json:"token_url"`
+	ClientID         string            `yaml:"client_id"          json:"client_id"`
+	ClientSecret     string            `yaml:"client_secret"      json:"client_secret"`
+	ClientSecretFile string            `yaml:"client_secret_file" json:"client_secret_file"`
+	Scopes           []string          `yaml:"scopes"             json:"scopes"`
+	EndpointParams   map[string]string `yaml:"endpoint_params"    json:"endpoint_params"`
+	RefreshBefore    time.Duration     `yaml:"refresh_before"     json:"refresh_before"`
+	Timeout          time.Duration     `yaml:"timeout"            json:"timeout"`
+	TLS              TLSConfig         `yaml:"tls"                json:"tls"`
+}
+
+// TokenFileConfig reads a token from Path into Header (default "Authorization"), prefixed with
+// Scheme (default "Bearer"; set it to "-" to send the raw token).
+type TokenFileConfig struct {
+	Path   string `yaml:"path"   json:"path"`
+	Header string `yaml:"header" json:"header"`
+	Scheme string `yaml:"scheme" json:"scheme"`
+}
+
+// FailoverConfig tunes failover across OTLPConfig.Endpoints. The exporter moves to the next
+// endpoint after FailureThreshold consecutive failed exports (default 3) and, while away from
+// the primary, sends one batch to it every ProbeInterval (default 30s) to fail back.
+type FailoverConfig struct {
+	FailureThreshold int           `yaml:"failure_threshold" json:"failure_threshold"`
+	ProbeInterval    time.Duration `yaml:"probe_interval"    json:"probe_interval"`
 }
 
 // OTLPConfig defines both gRPC and HTTP export settings.
+// Traces, Metrics and Logs optionally override the shared settings for a single signal.
+// Endpoints lists collectors in priority order and replaces Endpoint when set; the exporter
+// fails over between them as configured by Failover. Over HTTP, each signal posts to
+// PathPrefix followed by /v1/<signal>, or to URLPath when set; gRPC ignores both.
 type OTLPConfig struct {
+	Protocol       string                `yaml:"protocol"        json:"protocol"`
+	Endpoint       string                `yaml:"endpoint"        json:"endpoint"`
+	PathPrefix     string                `yaml:"path_prefix"     json:"path_prefix"`
+	URLPath        string                `yaml:"url_path"        json:"url_path"`
+	Endpoints      []string              `yaml:"endpoints"       json:"endpoints"`
+	Failover       *FailoverConfig       `yaml:"failover"        json:"failover"`
+	Insecure       bool                  `yaml:"insecure"        json:"insecure"`
+	Headers        map[string]string     `yaml:"headers"         json:"headers"`
+	Auth           *AuthConfig           `yaml:"auth"            json:"auth"`
+	Timeout        time.Duration         `yaml:"timeout"         json:"timeout"`
+	Batch          BatchConfig           `yaml:"batch"           json:"batch"`
+	Retry          RetryConfig           `yaml:"retry"           json:"retry"`
+	TLS            TLSConfig             `yaml:"tls"             json:"tls"`
+	Compression    string                `yaml:"compression"     json:"compression"`
+	Queue          *QueueConfig          `yaml:"queue"           json:"queue"`
+	CircuitBreaker *CircuitBreakerConfig `yaml:"circuit_breaker" json:"circuit_breaker"`
+	MetricReader   MetricReaderConfig    `yaml:"metric_reader"   json:"metric_reader"`
+	Traces         *OTLPSignalConfig     `yaml:"traces"          json:"traces"`
+	Metrics        *OTLPSignalConfig     `yaml:"metrics"         json:"metrics"`
+	Logs           *OTLPSignalConfig     `yaml:"logs"            json:"logs"`
+}
+
+// OTLPSignalConfig overrides the shared OTLP settings for one signal. Unset fields inherit
+// from the enclosing OTLPConfig; Headers are merged, with per-signal keys taking precedence.
+type OTLPSignalConfig struct {
 	Protocol    string            `yaml:"protocol"    json:"protocol"`
 	Endpoint    string            `yaml:"endpoint"    json:"endpoint"`
-	Insecure    bool              `yaml:"insecure"    json:"insecure"`
+	URLPath     string            `yaml:"url_path"    json:"url_path"`
+	Endpoints   []string          `yaml:"endpoints"   json:"endpoints"`
+	Insecure    *bool             `yaml:"insecure"    json:"insecure"`
 	Headers     map[string]string `yaml:"headers"     json:"headers"`
 	Timeout     time.Duration     `yaml:"timeout"     json:"timeout"`
-	Batch       BatchConfig       `yaml:"batch"       json:"batch"`
-	Retry       RetryConfig       `yaml:"retry"       json:"retry"`
-	TLS         TLSConfig         `yaml:"tls"         json:"tls"`
 	Compression string            `yaml:"compression" json:"compression"`
+	TLS         *TLSConfig        `yaml:"tls"         json:"tls"`
+}
+
+// ForSignal returns the effective settings for signal ("traces", "metrics" or "logs"):
+// the shared block with that signal's overrides applied and no overrides left. Endpoint
+// always holds the primary endpoint, the first of Endpoints when a list is set.
+func (c OTLPConfig) ForSignal(signal string) OTLPConfig {
+	override := c.override(signal)
+
+	c.Traces, c.Metrics, c.Logs = nil, nil, nil
+	if len(c.Endpoints) > 0 {
+		c.Endpoint = c.Endpoints[0]
+	}
+
+	if override == nil {
+		return c
+	}
+
+	if override.Protocol != "" {
+		c.Protocol = override.Protocol
+	}
+
+	switch {
+	case len(override.Endpoints) > 0:
+		c.Endpoints = override.Endpoints
+		c.Endpoint = override.Endpoints[0]
+	case override.Endpoint != "":
+		c.Endpoint = override.Endpoint
+		c.Endpoints = nil
+	}
+
+	if override.URLPath != "" {
+		c.URLPath = override.URLPath
+	}
+
+	if override.Insecure != nil {
+		c.Insecure = *override.Insecure
+	}
+
+	if override.Timeout > 0 {
+		c.Timeout = override.Timeout
+	}
+
+	if override.Compression != "" {
+		c.Compression = override.Compression
+	}
+
+	if override.TLS != nil {
+		c.TLS = *override.TLS
+	}
+
+	if len(override.Headers) > 0 {
+		headers := make(map[string]string, len(c.Headers)+len(override.Headers))
+		maps.Copy(headers, c.Headers)
+		maps.Copy(headers, override.Headers)
+		c.Headers = headers
+	}
+
+	return c
+}
+
+// HasEndpoint reports whether any signal resolves to a non-empty endpoint.
+func (c OTLPConfig) HasEndpoint() bool {
+	for _, signal := range []string{"traces", "metrics", "logs"} {
+		if c.ForSignal(signal).Endpoint != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c OTLPConfig) override(signal string) *OTLPSignalConfig {
+	switch signal {
+	case "traces":
+		return c.Traces
+	case "metrics":
+		return c.Metrics
+	case "logs":
+		return c.Logs
+	default:
+		return nil
+	}
 }
@@ -1,6 +1,12 @@
 package config
 
-import "github.com/hyp3rd/ewrap"
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hyp3rd/ewrap"
+)
 
 // Validate asserts that the config meets baseline expectations.
 func Validate(cfg Config) error {
@@ -8,21 +14,489 @@ func Validate(cfg Config) error {
 		return invalidConfigError("service.name is required")
 	}
 
-	if cfg.Exporters.OTLP == nil {
-		return invalidConfigError("exporters.otlp section is required")
+	err := validateExporters(cfg.Exporters, !cfg.SDKDisabled && cfg.Exporters.Exporting())
+	if err != nil {
+		return err
 	}
 
-	if cfg.Exporters.OTLP.Endpoint == "" {
-		return invalidConfigError("exporters.otlp.endpoint is required")
+	err = validatePrometheus(cfg.Exporters.Prometheus, cfg.Diagnostics)
+	if err != nil {
+		return err
+	}
+
+	err = validateViews(cfg.Metrics.Views)
+	if err != nil {
+		return err
 	}
 
 	mode := cfg.Sampling.Mode
 	switch mode {
 	case "always_on", "always_off", "parentbased_always_on", "parentbased_always_off", "trace_id_ratio":
+	case "rules":
+		err = validateSamplingRules(cfg.Sampling)
+		if err != nil {
+			return err
+		}
 	default:
 		return invalidConfigError("unsupported sampling.mode %q", mode)
 	}
 
+	err = validateTenantLimiter(cfg.Sampling.TenantLimiter)
+	if err != nil {
+		return err
+	}
+
+	err = validateTailSampling(cfg.Sampling.Tail)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range cfg.Propagation.Propagators {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "tracecontext", "baggage", "b3", "b3multi", "jaeger", "none":
+		default:
+			return invalidConfigError("unsupported propagation.propagators entry %q", name)
+		}
+	}
+
+	return nil
+}
+
+// validateExporters checks the exporter blocks; required is false when nothing will be
+// exported, so a config without any exporter is accepted.
+func validateExporters(cfg ExporterConfig, required bool) error {
+	switch strings.ToLower(strings.TrimSpace(cfg.Mode)) {
+	case "", "export", ExportModeNone:
+	default:
+		return invalidConfigError("unsupported exporters.mode %q", cfg.Mode)
+	}
+
+	prometheus := cfg.Prometheus != nil && cfg.Prometheus.Enabled
+	if required && cfg.OTLP == nil && len(cfg.Backends) == 0 && !prometheus {
+		return invalidConfigError("exporters.otlp, exporters.backends or exporters.prometheus is required")
+	}
+
+	// An empty OTLP endpoint disables the primary exporter when other exporters take over.
+	if required && cfg.OTLP != nil && !cfg.OTLP.HasEndpoint() && len(cfg.Backends) == 0 && !prometheus {
+		return invalidConfigError("exporters.otlp.endpoint is required")
+	}
+
+	if cfg.OTLP != nil {
+		err := validateQueue("exporters.otlp.queue", cfg.OTLP.Queue)
+		if err != nil {
+			return err
+		}
+
+		err = validateCircuitBreaker("exporters.otlp.circuit_breaker", cfg.OTLP.CircuitBreaker)
+		if err != nil {
+			return err
+		}
+
+		err = validateMetricReader("exporters.otlp.metric_reader", &cfg.OTLP.MetricReader)
+		if err != nil {
+			return err
+		}
+
+		err = validateAuth("exporters.otlp.auth", cfg.OTLP.Auth)
+		if err != nil {
+			return err
+		}
+
+		err = validateFailover("exporters.otlp", cfg.OTLP)
+		if err != nil {
+			return err
+		}
+
+		err = validateURLPaths("exporters.otlp", cfg.OTLP)
+		if err != nil {
+			return err
+		}
+	}
+
+	names := map[string]struct{}{}
+	if cfg.OTLP != nil && cfg.OTLP.HasEndpoint() {
+		names["otlp"] = struct{}{}
+	}
+
+	for idx, spec := range cfg.Backends {
+		name := strings.ToLower(strings.TrimSpace(spec.Name))
+		if name == "" {
+			return invalidConfigError("exporters.backends[%d].name is required", idx)
+		}
+
+		if _, dup := names[name]; dup {
+			return invalidConfigError("exporters.backends[%d].name %q is not unique", idx, spec.Name)
+		}
+
+		names[name] = struct{}{}
+
+		err := validateExporterSpec(idx, spec)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateExporterSpec(idx int, spec ExporterSpec) error {
+	if strings.TrimSpace(spec.Type) == "" {
+		return invalidConfigError("exporters.backends[%d].type is required", idx)
+	}
+
+	if spec.Console != nil {
+		switch strings.ToLower(strings.TrimSpace(spec.Console.Output)) {
+		case "", "stdout", "stderr":
+		default:
+			return invalidConfigError("exporters.backends[%d].console.output %q must be stdout or stderr", idx, spec.Console.Output)
+		}
+	}
+
+	if spec.Zipkin != nil && strings.TrimSpace(spec.Zipkin.URL) == "" {
+		return invalidConfigError("exporters.backends[%d].zipkin.url is required", idx)
+	}
+
+	if spec.File != nil && strings.TrimSpace(spec.File.Path) == "" {
+		return invalidConfigError("exporters.backends[%d].file.path is required", idx)
+	}
+
+	for _, signal := range spec.Signals {
+		switch strings.ToLower(strings.TrimSpace(signal)) {
+		case "traces", "metrics", "logs":
+		default:
+			return invalidConfigError("exporters.backends[%d] has unsupported signal %q", idx, signal)
+		}
+	}
+
+	err := validateMetricReader(fmt.Sprintf("exporters.backends[%d].metric_reader", idx), spec.MetricReader)
+	if err != nil {
+		return err
+	}
+
+	err = validateQueue(fmt.Sprintf("exporters.backends[%d].queue", idx), spec.Queue)
+	if err != nil {
+		return err
+	}
+
+	if spec.OTLP != nil {
+		err = validateAuth(fmt.Sprintf("exporters.backends[%d].otlp.auth", idx), spec.OTLP.Auth)
+		if err != nil {
+			return err
+		}
+
+		err = validateFailover(fmt.Sprintf("exporters.backends[%d].otlp", idx), spec.OTLP)
+		if err != nil {
+			return err
+		}
+
+		err = validateURLPaths(fmt.Sprintf("exporters.backends[%d].otlp", idx), spec.OTLP)
+		if err != nil {
+			return err
+		}
+	}
+
+	return validateCircuitBreaker(fmt.Sprintf("exporters.backends[%d].circuit_breaker", idx), spec.CircuitBreaker)
+}
+
+func validateMetricReader(field string, cfg *MetricReaderConfig) error {
+	if cfg == nil {
+		return nil
+	}
+
+	if cfg.Interval < 0 || cfg.Timeout < 0 {
+		return invalidConfigError("%s interval and timeout must not be negative", field)
+	}
+
+	switch strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(strings.TrimSpace(cfg.Temporality))) {
+	case "", "cumulative", "delta", "lowmemory":
+	default:
+		return invalidConfigError("%s.temporality %q must be cumulative, delta or lowmemory", field, cfg.Temporality)
+	}
+
+	return nil
+}
+
+func validateSamplingRules(cfg SamplingConfig) error {
+	if len(cfg.Rules) == 0 {
+		return invalidConfigError("sampling.rules must not be empty in rules mode")
+	}
+
+	if cfg.Argument <= 0 || cfg.Argument > 1 {
+		return invalidConfigError("sampling.argument must be within (0,1], got %f", cfg.Argument)
+	}
+
+	for idx, rule := range cfg.Rules {
+		switch rule.Match.Kind {
+		case "", "internal", "server", "client", "producer", "consumer":
+		default:
+			return invalidConfigError("sampling.rules[%d].match.kind %q is not supported", idx, rule.Match.Kind)
+		}
+
+		if _, ok := rule.Match.Attributes[""]; ok {
+			return invalidConfigError("sampling.rules[%d].match.attributes keys must not be empty", idx)
+		}
+
+		switch rule.Sampler {
+		case "always_on", "always_off":
+		case "trace_id_ratio":
+			if rule.Ratio <= 0 || rule.Ratio > 1 {
+				return invalidConfigError("sampling.rules[%d].ratio must be within (0,1], got %f", idx, rule.Ratio)
+			}
+		default:
+			return invalidConfigError("sampling.rules[%d].sampler %q is not supported", idx, rule.Sampler)
+		}
+	}
+
+	return nil
+}
+
+func validateTenantLimiter(cfg TenantLimiterConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.Rate <= 0 {
+		return invalidConfigError("sampling.tenant_limiter.rate must be positive")
+	}
+
+	if cfg.Burst < 0 || cfg.MaxTenants < 0 {
+		return invalidConfigError("sampling.tenant_limiter limits must not be negative")
+	}
+
+	for tenant, rate := range cfg.Overrides {
+		if rate < 0 {
+			return invalidConfigError("sampling.tenant_limiter.overrides[%q] must not be negative", tenant)
+		}
+	}
+
+	return nil
+}
+
+func validateTailSampling(cfg TailSamplingConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.DecisionWait < 0 || cfg.MaxTraces < 0 || cfg.MaxSpans < 0 {
+		return invalidConfigError("sampling.tail limits must not be negative")
+	}
+
+	if len(cfg.Policies) == 0 {
+		return invalidConfigError("sampling.tail.policies must not be empty")
+	}
+
+	for i, policy := range cfg.Policies {
+		err := validateTailPolicy(fmt.Sprintf("sampling.tail.policies[%d]", i), policy)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateTailPolicy(field string, cfg TailPolicyConfig) error {
+	switch cfg.Type {
+	case "error":
+	case "latency":
+		if cfg.Threshold <= 0 {
+			return invalidConfigError("%s.threshold must be positive", field)
+		}
+	case "attribute":
+		if strings.TrimSpace(cfg.Key) == "" {
+			return invalidConfigError("%s.key is required", field)
+		}
+	case "probabilistic":
+		if cfg.Ratio <= 0 || cfg.Ratio > 1 {
+			return invalidConfigError("%s.ratio must be within (0,1], got %f", field, cfg.Ratio)
+		}
+	case "rate_limit":
+		if cfg.Rate <= 0 {
+			return invalidConfigError("%s.rate must be positive", field)
+		}
+	case "and", "or":
+		if len(cfg.Policies) == 0 {
+			return invalidConfigError("%s.policies must not be empty", field)
+		}
+
+		for i, policy := range cfg.Policies {
+			err := validateTailPolicy(fmt.Sprintf("%s.policies[%d]", field, i), policy)
+			if err != nil {
+				return err
+			}
+		}
+	default:
+		return invalidConfigError("unsupported %s.type %q", field, cfg.Type)
+	}
+
+	return nil
+}
+
+func validateFailover(field string, cfg *OTLPConfig) error {
+	lists := [][]string{cfg.Endpoints}
+	for _, override := range []*OTLPSignalConfig{cfg.Traces, cfg.Metrics, cfg.Logs} {
+		if override != nil {
+			lists = append(lists, override.Endpoints)
+		}
+	}
+
+	for _, endpoints := range lists {
+		if slices.ContainsFunc(endpoints, func(endpoint string) bool { return strings.TrimSpace(endpoint) == "" }) {
+			return invalidConfigError("%s endpoints must not contain empty entries", field)
+		}
+	}
+
+	if cfg.Failover != nil && (cfg.Failover.FailureThreshold < 0 || cfg.Failover.ProbeInterval < 0) {
+		return invalidConfigError("%s.failover settings must not be negative", field)
+	}
+
+	return nil
+}
+
+func validateURLPaths(field string, cfg *OTLPConfig) error {
+	paths := []string{cfg.PathPrefix, cfg.URLPath}
+	for _, override := range []*OTLPSignalConfig{cfg.Traces, cfg.Metrics, cfg.Logs} {
+		if override != nil {
+			paths = append(paths, override.URLPath)
+		}
+	}
+
+	if slices.ContainsFunc(paths, func(path string) bool { return path != "" && !strings.HasPrefix(path, "/") }) {
+		return invalidConfigError("%s url paths must start with /", field)
+	}
+
+	return nil
+}
+
+func validateQueue(field string, cfg *QueueConfig) error {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	if strings.TrimSpace(cfg.Dir) == "" {
+		return invalidConfigError("%s.dir is required", field)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(cfg.Eviction)) {
+	case "", "drop_oldest", "drop_newest":
+	default:
+		return invalidConfigError("%s.eviction %q must be drop_oldest or drop_newest", field, cfg.Eviction)
+	}
+
+	if cfg.MaxSizeMB < 0 || cfg.ReplayInterval < 0 {
+		return invalidConfigError("%s limits must not be negative", field)
+	}
+
+	return nil
+}
+
+func validateCircuitBreaker(field string, cfg *CircuitBreakerConfig) error {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.ErrorThreshold != nil && (*cfg.ErrorThreshold <= 0 || *cfg.ErrorThreshold > 1) {
+		return invalidConfigError("%s.error_threshold must be within (0,1], got %v", field, *cfg.ErrorThreshold)
+	}
+
+	if cfg.Window < 0 || cfg.MinRequests < 0 || cfg.Cooldown < 0 || cfg.ProbeBatches < 0 {
+		return invalidConfigError("%s limits must not be negative", field)
+	}
+
+	if cfg.Window > 0 && cfg.MinRequests > cfg.Window {
+		return invalidConfigError("%s.min_requests must not exceed window", field)
+	}
+
+	return nil
+}
+
+func validateAuth(field string, cfg *AuthConfig) error {
+	if cfg == nil {
+		return nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(cfg.Mode)) {
+	case "", "none":
+		return nil
+	case "oauth2":
+		oauth := cfg.OAuth2
+		if oauth == nil || strings.TrimSpace(oauth.TokenURL) == "" || strings.TrimSpace(oauth.ClientID) == "" {
+			return invalidConfigError("%s.oauth2 requires token_url and client_id", field)
+		}
+
+		if oauth.ClientSecret == "" && oauth.ClientSecretFile == "" {
+			return invalidConfigError("%s.oauth2 requires client_secret or client_secret_file", field)
+		}
+
+		if oauth.RefreshBefore < 0 || oauth.Timeout < 0 {
+			return invalidConfigError("%s.oauth2 durations must not be negative", field)
+		}
+	case "token_file":
+		if cfg.TokenFile == nil || strings.TrimSpace(cfg.TokenFile.Path) == "" {
+			return invalidConfigError("%s.token_file.path is required", field)
+		}
+	case "provider":
+		if strings.TrimSpace(cfg.Provider) == "" {
+			return invalidConfigError("%s.provider is required", field)
+		}
+	default:
+		return invalidConfigError("%s.mode %q must be oauth2, token_file or provider", field, cfg.Mode)
+	}
+
+	return nil
+}
+
+func validatePrometheus(cfg *PrometheusConfig, diag DiagnosticsConfig) error {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.Path != "" && !strings.HasPrefix(cfg.Path, "/") {
+		return invalidConfigError("exporters.prometheus.path %q must start with /", cfg.Path)
+	}
+
+	if cfg.ListenAddr == "" && !diag.Enabled {
+		return invalidConfigError("exporters.prometheus requires listen_addr or an enabled diagnostics server")
+	}
+
+	if cfg.ListenAddr == "" && cfg.Path == "/observe/status" {
+		return invalidConfigError("exporters.prometheus.path conflicts with the diagnostics status endpoint")
+	}
+
+	return nil
+}
+
+func validateViews(views []MetricViewConfig) error {
+	for idx, view := range views {
+		match := view.Match
+		if match.Instrument == "" && match.Meter == "" && match.Kind == "" {
+			return invalidConfigError("metrics.views[%d].match requires instrument, meter or kind", idx)
+		}
+
+		switch match.Kind {
+		case "", "counter", "up_down_counter", "histogram", "gauge",
+			"observable_counter", "observable_up_down_counter", "observable_gauge":
+		default:
+			return invalidConfigError("metrics.views[%d].match.kind %q is not supported", idx, match.Kind)
+		}
+
+		if view.Name != "" && (match.Instrument == "" || strings.ContainsAny(match.Instrument, "*?")) {
+			return invalidConfigError("metrics.views[%d].name requires an exact match.instrument", idx)
+		}
+
+		if len(view.Buckets) > 0 && view.Exponential != nil {
+			return invalidConfigError("metrics.views[%d] sets both buckets and exponential", idx)
+		}
+
+		if !slices.IsSorted(view.Buckets) {
+			return invalidConfigError("metrics.views[%d].buckets must be in ascending order", idx)
+		}
+
+		if len(view.KeepAttributes) > 0 && len(view.DropAttributes) > 0 {
+			return invalidConfigError("metrics.views[%d] sets both keep_attributes and drop_attributes", idx)
+		}
+	}
+
 	return nil
 }
 
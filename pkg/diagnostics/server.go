@@ -19,30 +19,131 @@ import (
 
 // Snapshot captures the current runtime configuration for diagnostics endpoints.
 type Snapshot struct {
-	ServiceName       string          `json:"service_name"`
-	ServiceVersion    string          `json:"service_version"`
-	Environment       string          `json:"environment"`
-	SamplingMode      string          `json:"sampling_mode"`
-	ExporterEndpoint  string          `json:"exporter_endpoint"`
-	StartTime         time.Time       `json:"start_time"`
-	LastReloadTime    time.Time       `json:"last_reload_time"`
-	Instrumentation   map[string]bool `json:"instrumentation"`
-	ConfigReloadCount int64           `json:"config_reload_count"`
-	TraceQueueLimit   int64           `json:"trace_queue_limit"`
-	TraceDroppedSpans int64           `json:"trace_dropped_spans"`
-	TraceExporter     ExporterStatus  `json:"trace_exporter"`
-	MetricExporter    ExporterStatus  `json:"metric_exporter"`
-	Timestamp         time.Time       `json:"timestamp"`
+	ServiceName       string               `json:"service_name"`
+	ServiceVersion    string               `json:"service_version"`
+	Environment       string               `json:"environment"`
+	SamplingMode      string               `json:"sampling_mode"`
+	ExporterEndpoint  string               `json:"exporter_endpoint"`
+	ExportMode        string               `json:"export_mode"`
+	ExportedSignals   map[string]bool      `json:"exported_signals"`
+	StartTime         time.Time            `json:"start_time"`
+	LastReloadTime    time.Time            `json:"last_reload_time"`
+	Instrumentation   map[string]bool      `json:"instrumentation"`
+	ConfigReloadCount int64                `json:"config_reload_count"`
+	TraceQueueLimit   int64                `json:"trace_queue_limit"`
+	TraceDroppedSpans int64                `json:"trace_dropped_spans"`
+	TraceQueueDepth   int64                `json:"trace_queue_depth"`
+	TraceQueueDropped int64                `json:"trace_queue_dropped_spans"`
+	TraceExporter     ExporterStatus       `json:"trace_exporter"`
+	MetricExporter    ExporterStatus       `json:"metric_exporter"`
+	TraceExporters    []ExporterStatus     `json:"trace_exporters,omitempty"`
+	MetricExporters   []ExporterStatus     `json:"metric_exporters,omitempty"`
+	LogExporters      []ExporterStatus     `json:"log_exporters,omitempty"`
+	TenantLimiter     *TenantLimiterStatus `json:"tenant_limiter,omitempty"`
+	TailSampling      *TailSamplingStatus  `json:"tail_sampling,omitempty"`
+	Timestamp         time.Time            `json:"timestamp"`
 }
 
 // ExporterStatus describes exporter health for diagnostics.
 type ExporterStatus struct {
-	Protocol        string    `json:"protocol"`
-	Endpoint        string    `json:"endpoint"`
-	LastError       string    `json:"last_error"`
-	LastErrorTime   time.Time `json:"last_error_time"`
-	LastSuccessTime time.Time `json:"last_success_time"`
-	ErrorCount      int64     `json:"error_count"`
+	Name            string          `json:"name,omitempty"`
+	Type            string          `json:"type,omitempty"`
+	Protocol        string          `json:"protocol"`
+	Endpoint        string          `json:"endpoint"`
+	LastError       string          `json:"last_error"`
+	LastErrorTime   time.Time       `json:"last_error_time"`
+	LastSuccessTime time.Time       `json:"last_success_time"`
+	ErrorCount      int64           `json:"error_count"`
+	AuthErrors      int64           `json:"auth_errors,omitempty"`
+	Dropped         int64           `json:"dropped,omitempty"`
+	Queue           *QueueStatus    `json:"queue,omitempty"`
+	Circuit         *CircuitStatus  `json:"circuit,omitempty"`
+	Batch           *BatchStatus    `json:"batch,omitempty"`
+	TLS             *TLSStatus      `json:"tls,omitempty"`
+	Failover        *FailoverStatus `json:"failover,omitempty"`
+}
+
+// QueueStatus describes an exporter's persistent disk queue. Depth, Replayed and Evicted count batches.
+type QueueStatus struct {
+	Depth    int64 `json:"depth"`
+	Bytes    int64 `json:"bytes"`
+	Replayed int64 `json:"replayed"`
+	Evicted  int64 `json:"evicted"`
+}
+
+// CircuitStatus describes an exporter's circuit breaker. State is "closed", "open" or
+// "half_open"; Rejected counts items not exported while the circuit was open.
+type CircuitStatus struct {
+	State          string    `json:"state"`
+	Transitions    int64     `json:"transitions"`
+	Rejected       int64     `json:"rejected"`
+	LastTransition time.Time `json:"last_transition"`
+}
+
+// BatchStatus describes an exporter's in-memory span batch queue. QueueDropped counts spans
+// discarded because the queue was full, as opposed to Dropped on the exporter, which counts
+// spans lost to failed exports.
+type BatchStatus struct {
+	QueueDepth        int64         `json:"queue_depth"`
+	QueueLimit        int64         `json:"queue_limit"`
+	QueueDropped      int64         `json:"queue_dropped"`
+	ExportedBatches   int64         `json:"exported_batches"`
+	ExportedSpans     int64         `json:"exported_spans"`
+	LastBatchSize     int64         `json:"last_batch_size"`
+	LastExportLatency time.Duration `json:"last_export_latency"`
+	AvgExportLatency  time.Duration `json:"avg_export_latency"`
+}
+
+// TLSStatus describes the certificates an exporter presents and trusts. They are re-read when
+// their files change; Reloads counts rotations picked up and LastError the last failed reload.
+type TLSStatus struct {
+	CertNotAfter time.Time `json:"cert_not_after"`
+	CANotAfter   time.Time `json:"ca_not_after"`
+	Reloads      int64     `json:"reloads"`
+	LastReload   time.Time `json:"last_reload"`
+	LastError    string    `json:"last_error,omitempty"`
+}
+
+// FailoverStatus describes an exporter that fails over across an ordered list of endpoints.
+// Active is also reported as the exporter's Endpoint; History keeps the most recent switches.
+type FailoverStatus struct {
+	Endpoints []string        `json:"endpoints"`
+	Active    string          `json:"active"`
+	Failovers int64           `json:"failovers"`
+	Failbacks int64           `json:"failbacks"`
+	History   []FailoverEvent `json:"history,omitempty"`
+}
+
+// FailoverEvent records a switch between endpoints. Reason is "failover" or "failback".
+type FailoverEvent struct {
+	Time   time.Time `json:"time"`
+	From   string    `json:"from"`
+	To     string    `json:"to"`
+	Reason string    `json:"reason"`
+}
+
+// TenantLimiterStatus describes the per-tenant sampling limiter. TrackedTenants is bounded by
+// the limiter's LRU; ThrottledTraces counts traces dropped because a tenant ran out of budget.
+// ThrottledByTenant breaks the drops down for the tracked tenants, forgetting a tenant's count
+// when the LRU evicts it.
+type TenantLimiterStatus struct {
+	TrackedTenants    int64            `json:"tracked_tenants"`
+	ThrottledTraces   int64            `json:"throttled_traces"`
+	ThrottledByTenant map[string]int64 `json:"throttled_by_tenant,omitempty"`
+}
+
+// TailSamplingStatus describes the tail sampling buffer and the decisions taken so far.
+// EvictedTraces counts traces decided early because the buffer was full; LateSpans counts
+// spans that ended after their trace was decided and followed that decision. Policies counts
+// kept traces by the first top-level policy that matched.
+type TailSamplingStatus struct {
+	BufferedTraces int64            `json:"buffered_traces"`
+	BufferedSpans  int64            `json:"buffered_spans"`
+	SampledTraces  int64            `json:"sampled_traces"`
+	DroppedTraces  int64            `json:"dropped_traces"`
+	EvictedTraces  int64            `json:"evicted_traces"`
+	LateSpans      int64            `json:"late_spans"`
+	Policies       map[string]int64 `json:"policies"`
 }
 
 // SnapshotProvider supplies diagnostic snapshots.
@@ -54,6 +155,7 @@ type SnapshotProvider interface {
 type Server struct {
 	cfg      config.DiagnosticsConfig
 	provider SnapshotProvider
+	handlers map[string]http.Handler
 
 	server *http.Server
 	mu     sync.Mutex
@@ -61,12 +163,34 @@ type Server struct {
 	stop   sync.Once
 }
 
+// Option customizes the diagnostics server.
+type Option func(*Server)
+
+// WithHandler mounts an additional handler on the diagnostics listener, such as a metrics scrape endpoint.
+// Mounted handlers share the status endpoint's auth token.
+func WithHandler(pattern string, handler http.Handler) Option {
+	return func(s *Server) {
+		if pattern == "" || handler == nil {
+			return
+		}
+
+		s.handlers[pattern] = handler
+	}
+}
+
 // NewServer constructs a diagnostics server.
-func NewServer(cfg config.DiagnosticsConfig, provider SnapshotProvider) *Server {
-	return &Server{
+func NewServer(cfg config.DiagnosticsConfig, provider SnapshotProvider, opts ...Option) *Server {
+	s := &Server{
 		cfg:      cfg,
 		provider: provider,
+		handlers: map[string]http.Handler{},
+	}
+
+	for _, opt := range opts {
+		opt(s)
 	}
+
+	return s
 }
 
 // Start begins serving the diagnostics endpoint until the supplied context is canceled or Shutdown is called.
@@ -78,12 +202,9 @@ func (s *Server) Start(ctx context.Context) error {
 	var startErr error
 
 	s.start.Do(func() {
-		mux := http.NewServeMux()
-		mux.HandleFunc("/observe/status", s.HandleStatus)
-
 		s.server = &http.Server{
 			Addr:              s.cfg.HTTPAddr,
-			Handler:           mux,
+			Handler:           s.Handler(),
 			ReadHeaderTimeout: constants.DefaultTimeout,
 		}
 
@@ -147,6 +268,34 @@ func (s *Server) Shutdown(ctx context.Context) error {
 	return nil
 }
 
+// Handler returns the mux serving the status endpoint and any mounted handlers.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/observe/status", s.HandleStatus)
+
+	for pattern, handler := range s.handlers {
+		mux.Handle(pattern, s.authorize(handler))
+	}
+
+	return mux
+}
+
+func (s *Server) authorize(next http.Handler) http.Handler {
+	if s.cfg.AuthToken == "" {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !validAuth(r.Header.Get("Authorization"), s.cfg.AuthToken) {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 // HandleStatus serves the /observe/status endpoint with a JSON snapshot of the runtime status.
 func (s *Server) HandleStatus(w http.ResponseWriter, r *http.Request) {
 	if s.cfg.AuthToken != "" {
@@ -132,3 +132,34 @@ func TestHandleStatusAuth(t *testing.T) {
 		t.Fatalf("expected 200 with auth, got %d", rr2.Code)
 	}
 }
+
+func TestWithHandlerMountsBehindAuth(t *testing.T) {
+	t.Parallel()
+
+	metrics := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("up 1\n"))
+	})
+
+	server := diagnostics.NewServer(
+		config.DiagnosticsConfig{Enabled: true, HTTPAddr: "127.0.0.1:0", AuthToken: "secret"},
+		stubSnapshotProvider{},
+		diagnostics.WithHandler("/metrics", metrics),
+	)
+
+	rr := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected unauthorized without token, got %d", rr.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+
+	rr = httptest.NewRecorder()
+	server.Handler().ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK || rr.Body.String() != "up 1\n" {
+		t.Fatalf("expected mounted handler response, got %d %q", rr.Code, rr.Body.String())
+	}
+}
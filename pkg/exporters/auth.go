@@ -0,0 +1,483 @@
+package exporters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyp3rd/ewrap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hyp3rd/observe/pkg/config"
+)
+
+const (
+	authModeOAuth2    = "oauth2"
+	authModeTokenFile = "token_file"
+	authModeProvider  = "provider"
+
+	defaultAuthHeader    = "Authorization"
+	defaultAuthScheme    = "Bearer"
+	rawTokenScheme       = "-"
+	defaultOAuth2Refresh = time.Minute
+	defaultOAuth2Timeout = 10 * time.Second
+	// defaultOAuth2Lifetime applies when the token response omits expires_in.
+	defaultOAuth2Lifetime = time.Hour
+	maxTokenResponseBytes = 1 << 20
+)
+
+// ErrAuthentication wraps every failure to obtain export credentials, so exporter stats can
+// tell authentication problems apart from transport errors.
+var ErrAuthentication = ewrap.New("exporter authentication failed").WithContext(
+	&ewrap.ErrorContext{
+		Severity: ewrap.SeverityError,
+		Type:     ewrap.ErrorTypeExternal,
+	},
+)
+
+// HeaderProvider supplies request headers for each export. OTLP exporters configured with an
+// auth block call it before every request, over both gRPC and HTTP; returning an error fails
+// the export without contacting the collector.
+type HeaderProvider interface {
+	Headers(ctx context.Context) (map[string]string, error)
+}
+
+// HeaderProviderFunc adapts a function to HeaderProvider.
+type HeaderProviderFunc func(ctx context.Context) (map[string]string, error)
+
+// Headers implements HeaderProvider.
+func (f HeaderProviderFunc) Headers(ctx context.Context) (map[string]string, error) {
+	return f(ctx)
+}
+
+var (
+	headerProvidersMu sync.RWMutex
+	headerProviders   = map[string]HeaderProvider{}
+)
+
+// RegisterHeaderProvider makes provider available to exporters configured with
+// auth.mode "provider" and auth.provider set to name. Names are case-insensitive and must be unique.
+func RegisterHeaderProvider(name string, provider HeaderProvider) error {
+	key := normalizeName(name)
+	if key == "" {
+		return ewrap.New("header provider name is required")
+	}
+
+	if provider == nil {
+		return ewrap.Newf("header provider %q is nil", name)
+	}
+
+	headerProvidersMu.Lock()
+	defer headerProvidersMu.Unlock()
+
+	if _, exists := headerProviders[key]; exists {
+		return ewrap.Newf("header provider %q is already registered", name)
+	}
+
+	headerProviders[key] = provider
+
+	return nil
+}
+
+// IsAuthError reports whether err stems from missing or rejected export credentials.
+func IsAuthError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, ErrAuthentication) {
+		return true
+	}
+
+	switch status.Code(err) {
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return true
+	default:
+		return false
+	}
+}
+
+// headerProviderFrom builds the provider for cfg; it returns nil when no auth is configured.
+func headerProviderFrom(cfg *config.AuthConfig) (HeaderProvider, error) {
+	if cfg == nil {
+		return nil, nil //nolint:nilnil // no auth configured.
+	}
+
+	switch strings.ToLower(strings.TrimSpace(cfg.Mode)) {
+	case "", "none":
+		return nil, nil //nolint:nilnil // no auth configured.
+	case authModeOAuth2:
+		if cfg.OAuth2 == nil {
+			return nil, ewrap.New("auth.oauth2 settings are required")
+		}
+
+		return newOAuth2Provider(*cfg.OAuth2)
+	case authModeTokenFile:
+		if cfg.TokenFile == nil {
+			return nil, ewrap.New("auth.token_file settings are required")
+		}
+
+		return newTokenFileProvider(*cfg.TokenFile), nil
+	case authModeProvider:
+		headerProvidersMu.RLock()
+		provider, ok := headerProviders[normalizeName(cfg.Provider)]
+		headerProvidersMu.RUnlock()
+
+		if !ok {
+			return nil, ewrap.Newf("header provider %q is not registered", cfg.Provider)
+		}
+
+		return provider, nil
+	default:
+		return nil, ewrap.Newf("unsupported auth mode %q", cfg.Mode)
+	}
+}
+
+func authHeaders(ctx context.Context, provider HeaderProvider) (map[string]string, error) {
+	headers, err := provider.Headers(ctx)
+	if err != nil {
+		if errors.Is(err, ErrAuthentication) {
+			return nil, err
+		}
+
+		return nil, ewrap.Wrap(errors.Join(ErrAuthentication, err), "resolve export credentials")
+	}
+
+	return headers, nil
+}
+
+// grpcCredentials calls the provider for every RPC. Failures surface as Unauthenticated,
+// which the OTLP exporters do not retry.
+type grpcCredentials struct {
+	provider HeaderProvider
+}
+
+func (c grpcCredentials) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	headers, err := authHeaders(ctx, c.provider)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	// gRPC metadata keys are lowercase.
+	md := make(map[string]string, len(headers))
+	for key, value := range headers {
+		md[strings.ToLower(key)] = value
+	}
+
+	return md, nil
+}
+
+// RequireTransportSecurity lets credentials flow over insecure connections, which the
+// exporter only uses when the configuration asks for it.
+func (grpcCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// authTransport sets the provider's headers on every outgoing HTTP request.
+type authTransport struct {
+	base     http.RoundTripper
+	provider HeaderProvider
+}
+
+func (t authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	headers, err := authHeaders(req.Context(), t.provider)
+	if err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// authHTTPClient builds the client used by HTTP exporters with auth. The OTLP exporters ignore
+// their TLS options once a client is supplied, so the transport carries the TLS settings itself.
+func authHTTPClient(cfg *config.OTLPConfig, provider HeaderProvider) (*http.Client, error) {
+	transport, err := httpTransport(cfg.TLS, cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{
+		Transport: authTransport{base: transport, provider: provider},
+		Timeout:   cfg.Timeout,
+	}, nil
+}
+
+func httpTransport(tlsSettings config.TLSConfig, endpoint string) (*http.Transport, error) {
+	base, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, ewrap.New("default http transport is not an *http.Transport")
+	}
+
+	transport := base.Clone()
+
+	tlsCfg, err := tlsConfigFrom(tlsSettings, endpoint)
+	if err != nil && !ErrTLSNotEnabled.Is(err) {
+		return nil, err
+	}
+
+	if tlsCfg != nil {
+		transport.TLSClientConfig = tlsCfg
+	}
+
+	return transport, nil
+}
+
+// tokenFileProvider serves a token from disk, re-reading the file whenever its size or
+// modification time changes.
+type tokenFileProvider struct {
+	path   string
+	header string
+	scheme string
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	value   string
+}
+
+func newTokenFileProvider(cfg config.TokenFileConfig) *tokenFileProvider {
+	header := cfg.Header
+	if header == "" {
+		header = defaultAuthHeader
+	}
+
+	scheme := cfg.Scheme
+	if scheme == "" {
+		scheme = defaultAuthScheme
+	}
+
+	return &tokenFileProvider{path: cfg.Path, header: header, scheme: scheme}
+}
+
+func (p *tokenFileProvider) Headers(context.Context) (map[string]string, error) {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return nil, ewrap.Wrapf(errors.Join(ErrAuthentication, err), "stat token file %s", p.path)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.value == "" || !info.ModTime().Equal(p.modTime) || info.Size() != p.size {
+		data, err := os.ReadFile(p.path)
+		if err != nil {
+			return nil, ewrap.Wrapf(errors.Join(ErrAuthentication, err), "read token file %s", p.path)
+		}
+
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return nil, ewrap.Wrapf(ErrAuthentication, "token file %s is empty", p.path)
+		}
+
+		p.value = withScheme(p.scheme, token)
+		p.modTime, p.size = info.ModTime(), info.Size()
+	}
+
+	return map[string]string{p.header: p.value}, nil
+}
+
+// oauth2Provider implements the client-credentials grant, caching the access token until
+// refreshBefore ahead of its expiry, or halfway through its lifetime for short-lived tokens.
+// Concurrent exports share a single token request, made without holding mu.
+type oauth2Provider struct {
+	cfg           config.OAuth2Config
+	client        *http.Client
+	refreshBefore time.Duration
+	now           func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	refreshAt time.Time
+	inflight  *oauth2Fetch
+}
+
+// oauth2Fetch is a token request shared by every export waiting on it; done closes once
+// token or err is set.
+type oauth2Fetch struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+type oauth2TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func newOAuth2Provider(cfg config.OAuth2Config) (*oauth2Provider, error) {
+	transport, err := httpTransport(cfg.TLS, cfg.TokenURL)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultOAuth2Timeout
+	}
+
+	refresh := cfg.RefreshBefore
+	if refresh <= 0 {
+		refresh = defaultOAuth2Refresh
+	}
+
+	return &oauth2Provider{
+		cfg:           cfg,
+		client:        &http.Client{Transport: transport, Timeout: timeout},
+		refreshBefore: refresh,
+		now:           time.Now,
+	}, nil
+}
+
+func (p *oauth2Provider) Headers(ctx context.Context) (map[string]string, error) {
+	p.mu.Lock()
+
+	if p.token != "" && p.now().Before(p.refreshAt) {
+		token := p.token
+		p.mu.Unlock()
+
+		return map[string]string{defaultAuthHeader: token}, nil
+	}
+
+	fetch := p.inflight
+	if fetch == nil {
+		fetch = &oauth2Fetch{done: make(chan struct{})}
+		p.inflight = fetch
+		p.mu.Unlock()
+
+		p.fetch(ctx, fetch)
+	} else {
+		p.mu.Unlock()
+
+		select {
+		case <-fetch.done:
+		case <-ctx.Done():
+			return nil, ewrap.Wrap(errors.Join(ErrAuthentication, ctx.Err()), "wait for oauth2 token")
+		}
+	}
+
+	if fetch.err != nil {
+		return nil, fetch.err
+	}
+
+	return map[string]string{defaultAuthHeader: fetch.token}, nil
+}
+
+// fetch requests a token for every export waiting on fetch and caches it on success.
+func (p *oauth2Provider) fetch(ctx context.Context, fetch *oauth2Fetch) {
+	token, lifetime, err := p.requestToken(ctx)
+
+	p.mu.Lock()
+
+	if err == nil {
+		p.token = token
+		p.refreshAt = p.now().Add(lifetime - min(p.refreshBefore, lifetime/2))
+	}
+
+	p.inflight = nil
+	p.mu.Unlock()
+
+	fetch.token, fetch.err = token, err
+	close(fetch.done)
+}
+
+// requestToken runs the client-credentials grant, returning the header value and the token's
+// lifetime.
+func (p *oauth2Provider) requestToken(ctx context.Context) (string, time.Duration, error) {
+	secret := p.cfg.ClientSecret
+	if p.cfg.ClientSecretFile != "" {
+		data, err := os.ReadFile(p.cfg.ClientSecretFile)
+		if err != nil {
+			return "", 0, ewrap.Wrapf(errors.Join(ErrAuthentication, err), "read client secret file %s", p.cfg.ClientSecretFile)
+		}
+
+		secret = strings.TrimSpace(string(data))
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(p.cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	}
+
+	for key, value := range p.cfg.EndpointParams {
+		form.Set(key, value)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, ewrap.Wrap(errors.Join(ErrAuthentication, err), "build oauth2 token request")
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(secret))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", 0, ewrap.Wrap(errors.Join(ErrAuthentication, err), "request oauth2 token")
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxTokenResponseBytes))
+	if err != nil {
+		return "", 0, ewrap.Wrap(errors.Join(ErrAuthentication, err), "read oauth2 token response")
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return "", 0, ewrap.Wrapf(ErrAuthentication, "oauth2 token endpoint returned %s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+
+	var token oauth2TokenResponse
+
+	err = json.Unmarshal(body, &token)
+	if err != nil {
+		return "", 0, ewrap.Wrap(errors.Join(ErrAuthentication, err), "decode oauth2 token response")
+	}
+
+	if token.AccessToken == "" {
+		return "", 0, ewrap.Wrap(ErrAuthentication, "oauth2 token response has no access_token")
+	}
+
+	lifetime := defaultOAuth2Lifetime
+	if token.ExpiresIn > 0 {
+		lifetime = time.Duration(token.ExpiresIn) * time.Second
+	}
+
+	return withScheme(tokenScheme(token.TokenType), token.AccessToken), lifetime, nil
+}
+
+// tokenScheme normalises the token_type returned by the server; most servers send "bearer".
+func tokenScheme(tokenType string) string {
+	if tokenType == "" || strings.EqualFold(tokenType, defaultAuthScheme) {
+		return defaultAuthScheme
+	}
+
+	return tokenType
+}
+
+func withScheme(scheme, token string) string {
+	if scheme == rawTokenScheme {
+		return token
+	}
+
+	return scheme + " " + token
+}
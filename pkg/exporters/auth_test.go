@@ -0,0 +1,317 @@
+package exporters_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hyp3rd/ewrap"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/hyp3rd/observe/pkg/config"
+	"github.com/hyp3rd/observe/pkg/exporters"
+)
+
+type headerRecorder struct {
+	mu     sync.Mutex
+	values []string
+}
+
+func (h *headerRecorder) add(value string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.values = append(h.values, value)
+}
+
+func (h *headerRecorder) all() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]string(nil), h.values...)
+}
+
+func newOTLPHTTPCollector(t *testing.T, header string) (string, *headerRecorder) {
+	t.Helper()
+
+	recorder := &headerRecorder{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recorder.add(r.Header.Get(header))
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	return strings.TrimPrefix(server.URL, "http://"), recorder
+}
+
+func otlpTraceExporter(t *testing.T, otlp *config.OTLPConfig) sdktrace.SpanExporter {
+	t.Helper()
+
+	factory, ok := exporters.Lookup(exporters.TypeOTLP)
+	if !ok {
+		t.Fatal("expected the otlp exporter to be registered")
+	}
+
+	exp, err := factory.Traces(context.Background(), config.ExporterSpec{Name: "otlp", Type: "otlp", OTLP: otlp})
+	if err != nil {
+		t.Fatalf("build otlp trace exporter: %v", err)
+	}
+
+	t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+
+	return exp
+}
+
+func TestOTLPHTTPExporterUsesOAuth2ClientCredentials(t *testing.T) {
+	t.Parallel()
+
+	var tokenRequests atomic.Int32
+
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests.Add(1)
+
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "svc" || pass != "s3cret" || r.FormValue("grant_type") != "client_credentials" ||
+			r.FormValue("scope") != "telemetry.write" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "tok-1",
+			"token_type":   "bearer",
+			"expires_in":   3600,
+		})
+	}))
+	defer tokenServer.Close()
+
+	endpoint, headers := newOTLPHTTPCollector(t, "Authorization")
+
+	exp := otlpTraceExporter(t, &config.OTLPConfig{
+		Protocol: "http",
+		Endpoint: endpoint,
+		Insecure: true,
+		Auth: &config.AuthConfig{
+			Mode: "oauth2",
+			OAuth2: &config.OAuth2Config{
+				TokenURL:     tokenServer.URL,
+				ClientID:     "svc",
+				ClientSecret: "s3cret",
+				Scopes:       []string{"telemetry.write"},
+			},
+		},
+	})
+
+	spans := tracetest.SpanStubs{{Name: "op"}}.Snapshots()
+
+	for range 2 {
+		err := exp.ExportSpans(context.Background(), spans)
+		if err != nil {
+			t.Fatalf("ExportSpans: %v", err)
+		}
+	}
+
+	if got := headers.all(); len(got) != 2 || got[0] != "Bearer tok-1" || got[1] != "Bearer tok-1" {
+		t.Fatalf("expected both exports to carry the access token, got %v", got)
+	}
+
+	if tokenRequests.Load() != 1 {
+		t.Fatalf("expected the token to be cached, got %d token requests", tokenRequests.Load())
+	}
+}
+
+func TestOAuth2ProviderCachesShortLivedTokens(t *testing.T) {
+	t.Parallel()
+
+	var tokenRequests atomic.Int32
+
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		tokenRequests.Add(1)
+
+		w.Header().Set("Content-Type", "application/json")
+		// Shorter than the default refresh_before of one minute.
+		_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "short", "expires_in": 30})
+	}))
+	defer tokenServer.Close()
+
+	endpoint, headers := newOTLPHTTPCollector(t, "Authorization")
+
+	exp := otlpTraceExporter(t, &config.OTLPConfig{
+		Protocol: "http",
+		Endpoint: endpoint,
+		Insecure: true,
+		Auth: &config.AuthConfig{
+			Mode:   "oauth2",
+			OAuth2: &config.OAuth2Config{TokenURL: tokenServer.URL, ClientID: "svc", ClientSecret: "s3cret"},
+		},
+	})
+
+	spans := tracetest.SpanStubs{{Name: "op"}}.Snapshots()
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, 4)
+
+	for range 4 {
+		wg.Go(func() { errs <- exp.ExportSpans(context.Background(), spans) })
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("ExportSpans: %v", err)
+		}
+	}
+
+	if got := headers.all(); len(got) != 4 || got[0] != "Bearer short" {
+		t.Fatalf("expected every export to carry the access token, got %v", got)
+	}
+
+	if tokenRequests.Load() != 1 {
+		t.Fatalf("expected concurrent exports to share one short-lived token, got %d token requests", tokenRequests.Load())
+	}
+}
+
+func TestOTLPHTTPExporterRereadsTokenFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "token")
+
+	err := os.WriteFile(path, []byte("first\n"), 0o600)
+	if err != nil {
+		t.Fatalf("write token: %v", err)
+	}
+
+	endpoint, headers := newOTLPHTTPCollector(t, "Authorization")
+
+	exp := otlpTraceExporter(t, &config.OTLPConfig{
+		Protocol: "http",
+		Endpoint: endpoint,
+		Insecure: true,
+		Auth:     &config.AuthConfig{Mode: "token_file", TokenFile: &config.TokenFileConfig{Path: path}},
+	})
+
+	spans := tracetest.SpanStubs{{Name: "op"}}.Snapshots()
+
+	err = exp.ExportSpans(context.Background(), spans)
+	if err != nil {
+		t.Fatalf("ExportSpans: %v", err)
+	}
+
+	err = os.WriteFile(path, []byte("rotated-token\n"), 0o600)
+	if err != nil {
+		t.Fatalf("rotate token: %v", err)
+	}
+
+	err = exp.ExportSpans(context.Background(), spans)
+	if err != nil {
+		t.Fatalf("ExportSpans: %v", err)
+	}
+
+	if got := headers.all(); len(got) != 2 || got[0] != "Bearer first" || got[1] != "Bearer rotated-token" {
+		t.Fatalf("expected the rotated token on the second export, got %v", got)
+	}
+
+	err = os.Remove(path)
+	if err != nil {
+		t.Fatalf("remove token: %v", err)
+	}
+
+	err = exp.ExportSpans(context.Background(), spans)
+	if !exporters.IsAuthError(err) {
+		t.Fatalf("expected an authentication error once the token is gone, got %v", err)
+	}
+
+	if len(headers.all()) != 2 {
+		t.Fatal("expected the collector not to be contacted without credentials")
+	}
+}
+
+type metadataTraceService struct {
+	coltracepb.UnimplementedTraceServiceServer
+
+	tenants *headerRecorder
+}
+
+func (s metadataTraceService) Export(
+	ctx context.Context,
+	_ *coltracepb.ExportTraceServiceRequest,
+) (*coltracepb.ExportTraceServiceResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.tenants.add(strings.Join(md.Get("x-tenant"), ","))
+
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+func TestOTLPGRPCExporterCallsHeaderProvider(t *testing.T) {
+	t.Parallel()
+
+	listener, err := (&net.ListenConfig{}).Listen(context.Background(), "tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	tenants := &headerRecorder{}
+	server := grpc.NewServer()
+	coltracepb.RegisterTraceServiceServer(server, metadataTraceService{tenants: tenants})
+
+	go func() { _ = server.Serve(listener) }()
+
+	defer server.Stop()
+
+	var calls atomic.Int32
+
+	err = exporters.RegisterHeaderProvider("grpc-auth-test", exporters.HeaderProviderFunc(
+		func(context.Context) (map[string]string, error) {
+			if calls.Add(1) > 2 {
+				return nil, ewrap.New("vault unavailable")
+			}
+
+			return map[string]string{"X-Tenant": "acme"}, nil
+		},
+	))
+	if err != nil {
+		t.Fatalf("RegisterHeaderProvider: %v", err)
+	}
+
+	exp := otlpTraceExporter(t, &config.OTLPConfig{
+		Protocol: "grpc",
+		Endpoint: listener.Addr().String(),
+		Insecure: true,
+		Auth:     &config.AuthConfig{Mode: "provider", Provider: "grpc-auth-test"},
+	})
+
+	spans := tracetest.SpanStubs{{Name: "op"}}.Snapshots()
+
+	for range 2 {
+		err = exp.ExportSpans(context.Background(), spans)
+		if err != nil {
+			t.Fatalf("ExportSpans: %v", err)
+		}
+	}
+
+	if got := tenants.all(); len(got) != 2 || got[0] != "acme" || got[1] != "acme" {
+		t.Fatalf("expected the provider headers on every export, got %v", got)
+	}
+
+	err = exp.ExportSpans(context.Background(), spans)
+	if !exporters.IsAuthError(err) {
+		t.Fatalf("expected a provider failure to surface as an authentication error, got %v", err)
+	}
+}
@@ -0,0 +1,386 @@
+package exporters
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hyp3rd/ewrap"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hyp3rd/observe/pkg/config"
+)
+
+// TypeConsole is the registry name of the human-readable console exporter.
+const TypeConsole = "console"
+
+const consoleIndent = "  "
+
+func consoleFactory() Factory {
+	return Factory{
+		Traces: func(_ context.Context, spec config.ExporterSpec) (sdktrace.SpanExporter, error) {
+			cfg := consoleConfig(spec)
+
+			w, err := consoleWriter(cfg.Output)
+			if err != nil {
+				return nil, ewrap.Wrapf(err, "exporter %q", spec.Name)
+			}
+
+			return NewConsoleTraceExporter(w, cfg), nil
+		},
+		Metrics: func(_ context.Context, spec config.ExporterSpec) (sdkmetric.Exporter, error) {
+			cfg := consoleConfig(spec)
+
+			w, err := consoleWriter(cfg.Output)
+			if err != nil {
+				return nil, ewrap.Wrapf(err, "exporter %q", spec.Name)
+			}
+
+			return NewConsoleMetricExporter(w), nil
+		},
+		Describe: func(spec config.ExporterSpec) Target {
+			output := strings.ToLower(consoleConfig(spec).Output)
+			if output == "" {
+				output = "stdout"
+			}
+
+			return Target{Protocol: TypeConsole, Endpoint: output}
+		},
+	}
+}
+
+func consoleConfig(spec config.ExporterSpec) config.ConsoleConfig {
+	if spec.Console == nil {
+		return config.ConsoleConfig{}
+	}
+
+	return *spec.Console
+}
+
+func consoleWriter(output string) (io.Writer, error) {
+	switch strings.ToLower(strings.TrimSpace(output)) {
+	case "", "stdout":
+		return os.Stdout, nil
+	case "stderr":
+		return os.Stderr, nil
+	default:
+		return nil, ewrap.Newf("unsupported console output %q", output)
+	}
+}
+
+// ConsoleTraceExporter prints finished spans as an indented tree per trace.
+// Spans whose parent is not part of the same export batch are printed as roots.
+type ConsoleTraceExporter struct {
+	mu         sync.Mutex
+	w          io.Writer
+	attributes map[attribute.Key]struct{}
+	stopped    bool
+}
+
+// NewConsoleTraceExporter returns a span exporter writing to w.
+func NewConsoleTraceExporter(w io.Writer, cfg config.ConsoleConfig) *ConsoleTraceExporter {
+	exp := &ConsoleTraceExporter{w: w}
+
+	if len(cfg.Attributes) > 0 {
+		exp.attributes = make(map[attribute.Key]struct{}, len(cfg.Attributes))
+		for _, key := range cfg.Attributes {
+			exp.attributes[attribute.Key(key)] = struct{}{}
+		}
+	}
+
+	return exp
+}
+
+// ExportSpans implements sdktrace.SpanExporter.
+func (e *ConsoleTraceExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+
+	for _, group := range groupByTrace(spans) {
+		fmt.Fprintf(&b, "trace %s\n", group.traceID)
+
+		for _, root := range group.roots {
+			e.writeSpan(&b, group, root, 1)
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.stopped {
+		return nil
+	}
+
+	_, err := io.WriteString(e.w, b.String())
+	if err != nil {
+		return ewrap.Wrap(err, "write console spans")
+	}
+
+	return nil
+}
+
+// Shutdown implements sdktrace.SpanExporter.
+func (e *ConsoleTraceExporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	e.stopped = true
+	e.mu.Unlock()
+
+	return nil
+}
+
+func (e *ConsoleTraceExporter) writeSpan(b *strings.Builder, group *traceGroup, span sdktrace.ReadOnlySpan, depth int) {
+	indent := strings.Repeat(consoleIndent, depth)
+	status := span.Status().Code.String()
+
+	if desc := span.Status().Description; desc != "" {
+		status += ": " + desc
+	}
+
+	fmt.Fprintf(b, "%s- %s [%s] %s %s\n",
+		indent, span.Name(), span.SpanKind(), formatDuration(span.EndTime().Sub(span.StartTime())), status)
+
+	if attrs := e.formatAttributes(span.Attributes()); attrs != "" {
+		fmt.Fprintf(b, "%s%s%s\n", indent, consoleIndent, attrs)
+	}
+
+	for _, event := range span.Events() {
+		fmt.Fprintf(b, "%s%s* %s +%s", indent, consoleIndent, event.Name, formatDuration(event.Time.Sub(span.StartTime())))
+
+		if attrs := e.formatAttributes(event.Attributes); attrs != "" {
+			b.WriteString(" " + attrs)
+		}
+
+		b.WriteString("\n")
+	}
+
+	for _, child := range group.children[span.SpanContext().SpanID()] {
+		e.writeSpan(b, group, child, depth+1)
+	}
+}
+
+func (e *ConsoleTraceExporter) formatAttributes(attrs []attribute.KeyValue) string {
+	parts := make([]string, 0, len(attrs))
+
+	for _, kv := range attrs {
+		if e.attributes != nil {
+			if _, ok := e.attributes[kv.Key]; !ok {
+				continue
+			}
+		}
+
+		parts = append(parts, string(kv.Key)+"="+kv.Value.Emit())
+	}
+
+	slices.Sort(parts)
+
+	return strings.Join(parts, " ")
+}
+
+type traceGroup struct {
+	traceID  trace.TraceID
+	roots    []sdktrace.ReadOnlySpan
+	children map[trace.SpanID][]sdktrace.ReadOnlySpan
+}
+
+// groupByTrace arranges spans into per-trace trees, preserving first-seen trace order
+// and ordering siblings by start time.
+func groupByTrace(spans []sdktrace.ReadOnlySpan) []*traceGroup {
+	present := make(map[trace.SpanID]struct{}, len(spans))
+	for _, span := range spans {
+		present[span.SpanContext().SpanID()] = struct{}{}
+	}
+
+	var groups []*traceGroup
+
+	byTrace := map[trace.TraceID]*traceGroup{}
+
+	for _, span := range spans {
+		traceID := span.SpanContext().TraceID()
+
+		group, ok := byTrace[traceID]
+		if !ok {
+			group = &traceGroup{traceID: traceID, children: map[trace.SpanID][]sdktrace.ReadOnlySpan{}}
+			byTrace[traceID] = group
+			groups = append(groups, group)
+		}
+
+		parent := span.Parent()
+		if _, local := present[parent.SpanID()]; parent.IsValid() && local {
+			group.children[parent.SpanID()] = append(group.children[parent.SpanID()], span)
+		} else {
+			group.roots = append(group.roots, span)
+		}
+	}
+
+	byStart := func(a, b sdktrace.ReadOnlySpan) int {
+		return a.StartTime().Compare(b.StartTime())
+	}
+
+	for _, group := range groups {
+		slices.SortStableFunc(group.roots, byStart)
+
+		for _, children := range group.children {
+			slices.SortStableFunc(children, byStart)
+		}
+	}
+
+	return groups
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Microsecond).String()
+}
+
+// ConsoleMetricExporter prints each collection as a compact table.
+type ConsoleMetricExporter struct {
+	mu      sync.Mutex
+	w       io.Writer
+	stopped bool
+}
+
+// NewConsoleMetricExporter returns a metric exporter writing to w.
+func NewConsoleMetricExporter(w io.Writer) *ConsoleMetricExporter {
+	return &ConsoleMetricExporter{w: w}
+}
+
+// Temporality implements sdkmetric.Exporter.
+func (*ConsoleMetricExporter) Temporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	return sdkmetric.DefaultTemporalitySelector(kind)
+}
+
+// Aggregation implements sdkmetric.Exporter.
+func (*ConsoleMetricExporter) Aggregation(kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	return sdkmetric.DefaultAggregationSelector(kind)
+}
+
+// Export implements sdkmetric.Exporter.
+func (e *ConsoleMetricExporter) Export(_ context.Context, rm *metricdata.ResourceMetrics) error {
+	if rm == nil || len(rm.ScopeMetrics) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "metrics %s\n", time.Now().UTC().Format(time.RFC3339))
+
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, consoleIndent+"NAME\tKIND\tATTRIBUTES\tVALUE")
+
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			for _, row := range metricRows(m.Data) {
+				fmt.Fprintf(tw, "%s%s\t%s\t%s\t%s\n", consoleIndent, m.Name, row.kind, row.attributes, row.value)
+			}
+		}
+	}
+
+	err := tw.Flush()
+	if err != nil {
+		return ewrap.Wrap(err, "format console metrics")
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.stopped {
+		return nil
+	}
+
+	_, err = io.WriteString(e.w, b.String())
+	if err != nil {
+		return ewrap.Wrap(err, "write console metrics")
+	}
+
+	return nil
+}
+
+// ForceFlush implements sdkmetric.Exporter.
+func (*ConsoleMetricExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+// Shutdown implements sdkmetric.Exporter.
+func (e *ConsoleMetricExporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	e.stopped = true
+	e.mu.Unlock()
+
+	return nil
+}
+
+type metricRow struct {
+	kind       string
+	attributes string
+	value      string
+}
+
+func metricRows(data metricdata.Aggregation) []metricRow {
+	switch agg := data.(type) {
+	case metricdata.Sum[int64]:
+		return numberRows("sum", agg.DataPoints)
+	case metricdata.Sum[float64]:
+		return numberRows("sum", agg.DataPoints)
+	case metricdata.Gauge[int64]:
+		return numberRows("gauge", agg.DataPoints)
+	case metricdata.Gauge[float64]:
+		return numberRows("gauge", agg.DataPoints)
+	case metricdata.Histogram[int64]:
+		return histogramRows(agg.DataPoints)
+	case metricdata.Histogram[float64]:
+		return histogramRows(agg.DataPoints)
+	default:
+		return []metricRow{{kind: fmt.Sprintf("%T", data), value: "-"}}
+	}
+}
+
+func numberRows[N int64 | float64](kind string, points []metricdata.DataPoint[N]) []metricRow {
+	rows := make([]metricRow, 0, len(points))
+	for _, dp := range points {
+		rows = append(rows, metricRow{
+			kind:       kind,
+			attributes: formatAttributeSet(dp.Attributes),
+			value:      fmt.Sprint(dp.Value),
+		})
+	}
+
+	return rows
+}
+
+func histogramRows[N int64 | float64](points []metricdata.HistogramDataPoint[N]) []metricRow {
+	rows := make([]metricRow, 0, len(points))
+	for _, dp := range points {
+		rows = append(rows, metricRow{
+			kind:       "histogram",
+			attributes: formatAttributeSet(dp.Attributes),
+			value:      fmt.Sprintf("count=%d sum=%v", dp.Count, dp.Sum),
+		})
+	}
+
+	return rows
+}
+
+func formatAttributeSet(set attribute.Set) string {
+	if set.Len() == 0 {
+		return "-"
+	}
+
+	parts := make([]string, 0, set.Len())
+	for _, kv := range set.ToSlice() {
+		parts = append(parts, string(kv.Key)+"="+kv.Value.Emit())
+	}
+
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,123 @@
+package exporters_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hyp3rd/observe/pkg/config"
+	"github.com/hyp3rd/observe/pkg/exporters"
+)
+
+func TestConsoleTraceExporterPrintsSpanTree(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	exp := exporters.NewConsoleTraceExporter(&buf, config.ConsoleConfig{Attributes: []string{"http.route"}})
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(&collectingExporter{next: exp}))
+	tracer := tp.Tracer("test")
+
+	ctx, parent := tracer.Start(context.Background(), "GET /orders")
+	parent.SetAttributes(attribute.String("http.route", "/orders"), attribute.String("secret", "hidden"))
+
+	_, child := tracer.Start(ctx, "SELECT orders")
+	child.AddEvent("retry", trace.WithAttributes(attribute.String("attempt", "2")))
+	child.SetStatus(codes.Error, "timeout")
+	child.End()
+	parent.End()
+
+	err := tp.Shutdown(context.Background())
+	if err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	out := buf.String()
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+
+	if !strings.HasPrefix(lines[0], "trace ") {
+		t.Fatalf("expected trace header, got %q", lines[0])
+	}
+
+	if !strings.HasPrefix(lines[1], "  - GET /orders [internal]") {
+		t.Fatalf("expected root span at depth 1, got %q", lines[1])
+	}
+
+	if !strings.Contains(out, "    http.route=/orders\n") || strings.Contains(out, "secret") {
+		t.Fatalf("expected only allowlisted attributes, got:\n%s", out)
+	}
+
+	if !strings.Contains(out, "    - SELECT orders [internal]") || !strings.Contains(out, "Error: timeout") {
+		t.Fatalf("expected nested child span with status, got:\n%s", out)
+	}
+
+	if !strings.Contains(out, "      * retry +") {
+		t.Fatalf("expected child event, got:\n%s", out)
+	}
+}
+
+func TestConsoleMetricExporterPrintsTable(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	counter, err := mp.Meter("test").Int64Counter("orders.created")
+	if err != nil {
+		t.Fatalf("counter: %v", err)
+	}
+
+	counter.Add(context.Background(), 3)
+
+	var rm metricdata.ResourceMetrics
+
+	err = reader.Collect(context.Background(), &rm)
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+
+	err = exporters.NewConsoleMetricExporter(&buf).Export(context.Background(), &rm)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "NAME") || !strings.Contains(out, "orders.created") {
+		t.Fatalf("expected metrics table, got:\n%s", out)
+	}
+
+	if !strings.Contains(out, "sum") || !strings.Contains(out, " 3\n") {
+		t.Fatalf("expected counter value in table, got:\n%s", out)
+	}
+}
+
+// collectingExporter buffers spans until shutdown so the console exporter sees a whole trace at once.
+type collectingExporter struct {
+	next  sdktrace.SpanExporter
+	spans []sdktrace.ReadOnlySpan
+}
+
+func (c *collectingExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	c.spans = append(c.spans, spans...)
+
+	return nil
+}
+
+func (c *collectingExporter) Shutdown(ctx context.Context) error {
+	err := c.next.ExportSpans(ctx, c.spans)
+	if err != nil {
+		return err
+	}
+
+	return c.next.Shutdown(ctx)
+}
@@ -0,0 +1,169 @@
+// Package exporters provides the registry of telemetry exporter factories used by the runtime,
+// together with the built-in OTLP, console, Zipkin and file exporters.
+package exporters
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hyp3rd/ewrap"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/hyp3rd/observe/pkg/config"
+)
+
+// Signal identifies a telemetry signal an exporter can ship.
+type Signal string
+
+const (
+	// SignalTraces identifies span export.
+	SignalTraces Signal = "traces"
+	// SignalMetrics identifies metric export.
+	SignalMetrics Signal = "metrics"
+	// SignalLogs identifies log record export.
+	SignalLogs Signal = "logs"
+)
+
+// Target describes where an exporter ships data, for diagnostics.
+type Target struct {
+	Protocol string
+	Endpoint string
+}
+
+// Factory builds exporters for one exporter type. A nil builder marks a signal the type does not support.
+type Factory struct {
+	Traces   func(ctx context.Context, spec config.ExporterSpec) (sdktrace.SpanExporter, error)
+	Metrics  func(ctx context.Context, spec config.ExporterSpec) (sdkmetric.Exporter, error)
+	Logs     func(ctx context.Context, spec config.ExporterSpec) (sdklog.Exporter, error)
+	Describe func(spec config.ExporterSpec) Target
+}
+
+// Supports reports whether the factory can build an exporter for signal.
+func (f Factory) Supports(signal Signal) bool {
+	switch signal {
+	case SignalTraces:
+		return f.Traces != nil
+	case SignalMetrics:
+		return f.Metrics != nil
+	case SignalLogs:
+		return f.Logs != nil
+	default:
+		return false
+	}
+}
+
+// Signals lists the signals the factory can build exporters for.
+func (f Factory) Signals() []Signal {
+	var signals []Signal
+
+	for _, signal := range []Signal{SignalTraces, SignalMetrics, SignalLogs} {
+		if f.Supports(signal) {
+			signals = append(signals, signal)
+		}
+	}
+
+	return signals
+}
+
+// Target describes spec for diagnostics, falling back to the exporter type as protocol.
+func (f Factory) Target(spec config.ExporterSpec) Target {
+	if f.Describe != nil {
+		return f.Describe(spec)
+	}
+
+	return Target{Protocol: strings.ToLower(spec.Type)}
+}
+
+// Registry maps exporter type names to factories. It is safe for concurrent use.
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]Factory
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{factories: map[string]Factory{}}
+}
+
+// Register adds a factory under name. Names are case-insensitive and must be unique.
+func (r *Registry) Register(name string, factory Factory) error {
+	key := normalizeName(name)
+	if key == "" {
+		return ewrap.New("exporter name is required")
+	}
+
+	if len(factory.Signals()) == 0 {
+		return ewrap.Newf("exporter %q must support at least one signal", name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.factories[key]; exists {
+		return ewrap.Newf("exporter %q is already registered", name)
+	}
+
+	r.factories[key] = factory
+
+	return nil
+}
+
+// Lookup returns the factory registered under name.
+func (r *Registry) Lookup(name string) (Factory, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	factory, ok := r.factories[normalizeName(name)]
+
+	return factory, ok
+}
+
+// Names lists the registered exporter types in sorted order.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+var defaultRegistry = newDefaultRegistry()
+
+func newDefaultRegistry() *Registry {
+	registry := NewRegistry()
+	registry.factories[TypeOTLP] = otlpFactory()
+	registry.factories[TypeConsole] = consoleFactory()
+	registry.factories[TypeZipkin] = zipkinFactory()
+	registry.factories[TypeFile] = fileFactory()
+
+	return registry
+}
+
+// Default returns the process-wide registry consulted by the runtime.
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// Register adds a factory to the default registry.
+func Register(name string, factory Factory) error {
+	return defaultRegistry.Register(name, factory)
+}
+
+// Lookup returns a factory from the default registry.
+func Lookup(name string) (Factory, bool) {
+	return defaultRegistry.Lookup(name)
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
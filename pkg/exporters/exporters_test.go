@@ -0,0 +1,155 @@
+package exporters_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/hyp3rd/observe/pkg/config"
+	"github.com/hyp3rd/observe/pkg/exporters"
+)
+
+func TestRegistryRegisterAndLookup(t *testing.T) {
+	t.Parallel()
+
+	registry := exporters.NewRegistry()
+	factory := exporters.Factory{
+		Traces: func(context.Context, config.ExporterSpec) (sdktrace.SpanExporter, error) {
+			return tracetest.NewInMemoryExporter(), nil
+		},
+	}
+
+	err := registry.Register("Memory", factory)
+	if err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+
+	got, ok := registry.Lookup("memory")
+	if !ok {
+		t.Fatal("expected lookup to be case-insensitive")
+	}
+
+	if !got.Supports(exporters.SignalTraces) || got.Supports(exporters.SignalMetrics) {
+		t.Fatalf("unexpected signals %v", got.Signals())
+	}
+
+	if err := registry.Register("memory", factory); err == nil {
+		t.Fatal("expected duplicate registration to fail")
+	}
+
+	if err := registry.Register("empty", exporters.Factory{}); err == nil {
+		t.Fatal("expected factory without signals to be rejected")
+	}
+
+	if names := registry.Names(); !slices.Equal(names, []string{"memory"}) {
+		t.Fatalf("unexpected names %v", names)
+	}
+}
+
+func TestDefaultRegistryProvidesOTLP(t *testing.T) {
+	t.Parallel()
+
+	factory, ok := exporters.Lookup(exporters.TypeOTLP)
+	if !ok {
+		t.Fatal("expected otlp factory in default registry")
+	}
+
+	target := factory.Target(config.ExporterSpec{
+		Type: exporters.TypeOTLP,
+		OTLP: &config.OTLPConfig{Endpoint: "collector:4318", Protocol: "HTTP"},
+	})
+	if target.Protocol != "http" || target.Endpoint != "collector:4318" {
+		t.Fatalf("unexpected target %+v", target)
+	}
+
+	_, err := factory.Traces(context.Background(), config.ExporterSpec{Name: "bare", Type: exporters.TypeOTLP})
+	if err == nil {
+		t.Fatal("expected otlp factory to require otlp settings")
+	}
+}
+
+func TestOTLPHTTPExporterHonoursURLPaths(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		otlp config.OTLPConfig
+		want string
+	}{
+		{name: "default", want: "/v1/traces"},
+		{name: "path prefix", otlp: config.OTLPConfig{PathPrefix: "/otlp/"}, want: "/otlp/v1/traces"},
+		{
+			name: "signal url path",
+			otlp: config.OTLPConfig{PathPrefix: "/otlp", Traces: &config.OTLPSignalConfig{URLPath: "/custom/v1/traces"}},
+			want: "/custom/v1/traces",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			paths := make(chan string, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				paths <- r.URL.Path
+
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			otlp := tc.otlp
+			otlp.Protocol, otlp.Endpoint, otlp.Insecure = "http", strings.TrimPrefix(server.URL, "http://"), true
+
+			err := otlpTraceExporter(t, &otlp).ExportSpans(context.Background(), tracetest.SpanStubs{{Name: "op"}}.Snapshots())
+			if err != nil {
+				t.Fatalf("ExportSpans: %v", err)
+			}
+
+			if got := <-paths; got != tc.want {
+				t.Fatalf("expected spans posted to %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestTemporalitySelectorPreferences(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		preference string
+		counter    metricdata.Temporality
+		observable metricdata.Temporality
+		upDown     metricdata.Temporality
+	}{
+		{"", metricdata.CumulativeTemporality, metricdata.CumulativeTemporality, metricdata.CumulativeTemporality},
+		{"delta", metricdata.DeltaTemporality, metricdata.DeltaTemporality, metricdata.CumulativeTemporality},
+		{"low-memory", metricdata.DeltaTemporality, metricdata.CumulativeTemporality, metricdata.CumulativeTemporality},
+	} {
+		selector, err := exporters.TemporalitySelector(tc.preference)
+		if err != nil {
+			t.Fatalf("TemporalitySelector(%q) returned error: %v", tc.preference, err)
+		}
+
+		got := []metricdata.Temporality{
+			selector(sdkmetric.InstrumentKindCounter),
+			selector(sdkmetric.InstrumentKindObservableCounter),
+			selector(sdkmetric.InstrumentKindUpDownCounter),
+		}
+		if got[0] != tc.counter || got[1] != tc.observable || got[2] != tc.upDown {
+			t.Fatalf("%q: unexpected temporalities %v", tc.preference, got)
+		}
+	}
+
+	_, err := exporters.TemporalitySelector("monthly")
+	if err == nil {
+		t.Fatal("expected an unknown preference to be rejected")
+	}
+}
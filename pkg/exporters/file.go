@@ -0,0 +1,191 @@
+package exporters
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/hyp3rd/ewrap"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/hyp3rd/observe/pkg/config"
+)
+
+// TypeFile is the registry name of the OTLP-JSON file exporter.
+const TypeFile = "file"
+
+func fileFactory() Factory {
+	return Factory{
+		Traces: func(_ context.Context, spec config.ExporterSpec) (sdktrace.SpanExporter, error) {
+			if spec.File == nil {
+				return nil, ewrap.Newf("exporter %q: file settings are required", spec.Name)
+			}
+
+			return NewFileTraceExporter(*spec.File)
+		},
+		Metrics: func(_ context.Context, spec config.ExporterSpec) (sdkmetric.Exporter, error) {
+			if spec.File == nil {
+				return nil, ewrap.Newf("exporter %q: file settings are required", spec.Name)
+			}
+
+			return NewFileMetricExporter(*spec.File)
+		},
+		Logs: func(_ context.Context, spec config.ExporterSpec) (sdklog.Exporter, error) {
+			if spec.File == nil {
+				return nil, ewrap.Newf("exporter %q: file settings are required", spec.Name)
+			}
+
+			return NewFileLogExporter(*spec.File)
+		},
+		Describe: func(spec config.ExporterSpec) Target {
+			if spec.File == nil {
+				return Target{Protocol: TypeFile}
+			}
+
+			return Target{Protocol: TypeFile, Endpoint: spec.File.Path}
+		},
+	}
+}
+
+// fileSink is the part of the file exporters shared across signals: one JSON line per export.
+type fileSink struct {
+	file *rotatingFile
+	once sync.Once
+	err  error
+}
+
+func newFileSink(cfg config.FileConfig) (*fileSink, error) {
+	file, err := acquireFile(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileSink{file: file}, nil
+}
+
+func (s *fileSink) writeJSON(payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return ewrap.Wrap(err, "encode otlp json")
+	}
+
+	return s.file.WriteLine(data)
+}
+
+func (s *fileSink) close() error {
+	s.once.Do(func() {
+		s.err = releaseFile(s.file)
+	})
+
+	return s.err
+}
+
+// FileTraceExporter appends spans to a file as OTLP-JSON lines, one ExportTraceServiceRequest per batch.
+type FileTraceExporter struct {
+	sink *fileSink
+}
+
+// NewFileTraceExporter opens (or shares) the rotating file at cfg.Path.
+func NewFileTraceExporter(cfg config.FileConfig) (*FileTraceExporter, error) {
+	sink, err := newFileSink(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileTraceExporter{sink: sink}, nil
+}
+
+// ExportSpans implements sdktrace.SpanExporter.
+func (e *FileTraceExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	return e.sink.writeJSON(toOTLPTraces(spans))
+}
+
+// Shutdown implements sdktrace.SpanExporter.
+func (e *FileTraceExporter) Shutdown(context.Context) error {
+	return e.sink.close()
+}
+
+// FileMetricExporter appends metrics to a file as OTLP-JSON lines, one ExportMetricsServiceRequest per collection.
+type FileMetricExporter struct {
+	sink *fileSink
+}
+
+// NewFileMetricExporter opens (or shares) the rotating file at cfg.Path.
+func NewFileMetricExporter(cfg config.FileConfig) (*FileMetricExporter, error) {
+	sink, err := newFileSink(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileMetricExporter{sink: sink}, nil
+}
+
+// Temporality implements sdkmetric.Exporter.
+func (*FileMetricExporter) Temporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	return sdkmetric.DefaultTemporalitySelector(kind)
+}
+
+// Aggregation implements sdkmetric.Exporter.
+func (*FileMetricExporter) Aggregation(kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	return sdkmetric.DefaultAggregationSelector(kind)
+}
+
+// Export implements sdkmetric.Exporter.
+func (e *FileMetricExporter) Export(_ context.Context, rm *metricdata.ResourceMetrics) error {
+	if rm == nil || len(rm.ScopeMetrics) == 0 {
+		return nil
+	}
+
+	return e.sink.writeJSON(toOTLPMetrics(rm))
+}
+
+// ForceFlush implements sdkmetric.Exporter.
+func (*FileMetricExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+// Shutdown implements sdkmetric.Exporter.
+func (e *FileMetricExporter) Shutdown(context.Context) error {
+	return e.sink.close()
+}
+
+// FileLogExporter appends log records to a file as OTLP-JSON lines, one ExportLogsServiceRequest per batch.
+type FileLogExporter struct {
+	sink *fileSink
+}
+
+// NewFileLogExporter opens (or shares) the rotating file at cfg.Path.
+func NewFileLogExporter(cfg config.FileConfig) (*FileLogExporter, error) {
+	sink, err := newFileSink(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileLogExporter{sink: sink}, nil
+}
+
+// Export implements sdklog.Exporter.
+func (e *FileLogExporter) Export(_ context.Context, records []sdklog.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	return e.sink.writeJSON(toOTLPLogs(records))
+}
+
+// ForceFlush implements sdklog.Exporter.
+func (*FileLogExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+// Shutdown implements sdklog.Exporter.
+func (e *FileLogExporter) Shutdown(context.Context) error {
+	return e.sink.close()
+}
@@ -0,0 +1,295 @@
+package exporters_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/hyp3rd/observe/pkg/config"
+	"github.com/hyp3rd/observe/pkg/exporters"
+)
+
+func TestFileExportersWriteOTLPJSONLines(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.FileConfig{Path: filepath.Join(t.TempDir(), "telemetry.jsonl")}
+
+	traces, err := exporters.NewFileTraceExporter(cfg)
+	if err != nil {
+		t.Fatalf("NewFileTraceExporter returned error: %v", err)
+	}
+
+	metrics, err := exporters.NewFileMetricExporter(cfg)
+	if err != nil {
+		t.Fatalf("NewFileMetricExporter returned error: %v", err)
+	}
+
+	err = traces.ExportSpans(context.Background(), recordSpans(t))
+	if err != nil {
+		t.Fatalf("ExportSpans returned error: %v", err)
+	}
+
+	err = metrics.Export(context.Background(), collectCounter(t))
+	if err != nil {
+		t.Fatalf("Export returned error: %v", err)
+	}
+
+	closeAll(t, traces.Shutdown, metrics.Shutdown)
+
+	lines := readLines(t, cfg.Path)
+	if len(lines) != 2 {
+		t.Fatalf("expected a trace line and a metric line, got %d", len(lines))
+	}
+
+	var traceLine struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []struct {
+					TraceID           string `json:"traceId"`
+					ParentSpanID      string `json:"parentSpanId"`
+					Kind              int    `json:"kind"`
+					StartTimeUnixNano string `json:"startTimeUnixNano"`
+					Status            struct {
+						Code int `json:"code"`
+					} `json:"status"`
+				} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+
+	err = json.Unmarshal([]byte(lines[0]), &traceLine)
+	if err != nil {
+		t.Fatalf("decode trace line: %v", err)
+	}
+
+	spans := traceLine.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 || len(spans[0].TraceID) != 32 || spans[0].StartTimeUnixNano == "" {
+		t.Fatalf("unexpected OTLP-JSON spans %+v", spans)
+	}
+
+	if spans[0].Kind != 3 || spans[0].Status.Code != 2 || spans[0].ParentSpanID == "" {
+		t.Fatalf("expected client span with OTLP error status, got %+v", spans[0])
+	}
+
+	if !strings.Contains(lines[1], `"resourceMetrics"`) || !strings.Contains(lines[1], `"asInt":"3"`) {
+		t.Fatalf("unexpected metric line %s", lines[1])
+	}
+}
+
+func TestFileLogExporterWritesOTLPJSONLines(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.FileConfig{Path: filepath.Join(t.TempDir(), "logs.jsonl")}
+
+	exp, err := exporters.NewFileLogExporter(cfg)
+	if err != nil {
+		t.Fatalf("NewFileLogExporter returned error: %v", err)
+	}
+
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exp)))
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "op")
+
+	var record otellog.Record
+	record.SetSeverity(otellog.SeverityError)
+	record.SetSeverityText("ERROR")
+	record.SetBody(otellog.StringValue("payment failed"))
+	record.AddAttributes(otellog.Map("order", otellog.String("id", "o-1")))
+	provider.Logger("test").Emit(ctx, record)
+	span.End()
+
+	closeAll(t, provider.Shutdown)
+
+	lines := readLines(t, cfg.Path)
+	if len(lines) != 1 {
+		t.Fatalf("expected one log line, got %d", len(lines))
+	}
+
+	var logLine struct {
+		ResourceLogs []struct {
+			ScopeLogs []struct {
+				LogRecords []struct {
+					SeverityNumber int    `json:"severityNumber"`
+					SeverityText   string `json:"severityText"`
+					TraceID        string `json:"traceId"`
+					SpanID         string `json:"spanId"`
+					Body           struct {
+						StringValue string `json:"stringValue"`
+					} `json:"body"`
+				} `json:"logRecords"`
+			} `json:"scopeLogs"`
+		} `json:"resourceLogs"`
+	}
+
+	err = json.Unmarshal([]byte(lines[0]), &logLine)
+	if err != nil {
+		t.Fatalf("decode log line: %v", err)
+	}
+
+	got := logLine.ResourceLogs[0].ScopeLogs[0].LogRecords[0]
+	if got.SeverityNumber != 17 || got.SeverityText != "ERROR" || got.Body.StringValue != "payment failed" {
+		t.Fatalf("unexpected OTLP-JSON log record %+v", got)
+	}
+
+	if got.TraceID != span.SpanContext().TraceID().String() || got.SpanID != span.SpanContext().SpanID().String() {
+		t.Fatalf("expected span correlation, got %+v", got)
+	}
+
+	if !strings.Contains(lines[0], `"kvlistValue":{"values":[{"key":"id","value":{"stringValue":"o-1"}}]}`) {
+		t.Fatalf("expected map attributes as kvlistValue, got %s", lines[0])
+	}
+}
+
+func TestFileExporterRotatesCompressesAndPrunes(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := config.FileConfig{
+		Path:       filepath.Join(dir, "spans.jsonl"),
+		MaxSizeMB:  1,
+		MaxBackups: 2,
+		Compress:   true,
+	}
+
+	exp, err := exporters.NewFileTraceExporter(cfg)
+	if err != nil {
+		t.Fatalf("NewFileTraceExporter returned error: %v", err)
+	}
+
+	payload := strings.Repeat("x", 600*1024)
+	for range 5 {
+		err = exp.ExportSpans(context.Background(), largeSpan(payload))
+		if err != nil {
+			t.Fatalf("ExportSpans returned error: %v", err)
+		}
+	}
+
+	closeAll(t, exp.Shutdown)
+
+	rotated, err := filepath.Glob(filepath.Join(dir, "spans-*.jsonl.gz"))
+	if err != nil {
+		t.Fatalf("glob: %v", err)
+	}
+
+	if len(rotated) != 2 {
+		t.Fatalf("expected retention to keep 2 gzipped backups, got %v", rotated)
+	}
+
+	if lines := readLines(t, cfg.Path); len(lines) != 1 {
+		t.Fatalf("expected active file to hold the latest batch, got %d lines", len(lines))
+	}
+}
+
+func TestFileExporterRotatesByAge(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := config.FileConfig{Path: filepath.Join(dir, "spans.jsonl"), MaxAge: time.Millisecond}
+
+	exp, err := exporters.NewFileTraceExporter(cfg)
+	if err != nil {
+		t.Fatalf("NewFileTraceExporter returned error: %v", err)
+	}
+
+	for range 2 {
+		err = exp.ExportSpans(context.Background(), recordSpans(t))
+		if err != nil {
+			t.Fatalf("ExportSpans returned error: %v", err)
+		}
+
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	closeAll(t, exp.Shutdown)
+
+	rotated, _ := filepath.Glob(filepath.Join(dir, "spans-*.jsonl"))
+	if len(rotated) != 1 {
+		t.Fatalf("expected one age-rotated file, got %v", rotated)
+	}
+}
+
+func largeSpan(payload string) []sdktrace.ReadOnlySpan {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(recorder),
+		sdktrace.WithSpanLimits(sdktrace.SpanLimits{AttributeValueLengthLimit: -1, AttributeCountLimit: 1}),
+	)
+
+	_, span := tp.Tracer("test").Start(context.Background(), "large")
+	span.SetAttributes(attribute.String("payload", payload))
+	span.End()
+
+	return recorder.Ended()
+}
+
+func collectCounter(t *testing.T) *metricdata.ResourceMetrics {
+	t.Helper()
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	counter, err := mp.Meter("test").Int64Counter("orders.created")
+	if err != nil {
+		t.Fatalf("counter: %v", err)
+	}
+
+	counter.Add(context.Background(), 3)
+
+	var rm metricdata.ResourceMetrics
+
+	err = reader.Collect(context.Background(), &rm)
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+
+	return &rm
+}
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open %s: %v", path, err)
+	}
+	defer file.Close()
+
+	var lines []string
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 1024), 4*1024*1024)
+
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scan %s: %v", path, err)
+	}
+
+	return lines
+}
+
+func closeAll(t *testing.T, closers ...func(context.Context) error) {
+	t.Helper()
+
+	for _, closeFn := range closers {
+		err := closeFn(context.Background())
+		if err != nil {
+			t.Fatalf("shutdown: %v", err)
+		}
+	}
+}
@@ -0,0 +1,496 @@
+package exporters
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hyp3rd/ewrap"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/hyp3rd/observe/pkg/config"
+)
+
+// TypeOTLP is the registry name of the built-in OTLP exporter.
+const TypeOTLP = "otlp"
+
+func otlpFactory() Factory {
+	return Factory{
+		Traces: func(ctx context.Context, spec config.ExporterSpec) (sdktrace.SpanExporter, error) {
+			if spec.OTLP == nil {
+				return nil, ewrap.Newf("exporter %q: otlp settings are required", spec.Name)
+			}
+
+			return newOTLPTraceExporter(ctx, spec.OTLP)
+		},
+		Metrics: func(ctx context.Context, spec config.ExporterSpec) (sdkmetric.Exporter, error) {
+			if spec.OTLP == nil {
+				return nil, ewrap.Newf("exporter %q: otlp settings are required", spec.Name)
+			}
+
+			return newOTLPMetricExporter(ctx, spec.OTLP, spec.MetricReaderSettings().Temporality)
+		},
+		Logs: func(ctx context.Context, spec config.ExporterSpec) (sdklog.Exporter, error) {
+			if spec.OTLP == nil {
+				return nil, ewrap.Newf("exporter %q: otlp settings are required", spec.Name)
+			}
+
+			return newOTLPLogExporter(ctx, spec.OTLP)
+		},
+		Describe: func(spec config.ExporterSpec) Target {
+			if spec.OTLP == nil {
+				return Target{Protocol: "grpc"}
+			}
+
+			protocol := spec.OTLP.Protocol
+			if protocol == "" {
+				protocol = "grpc"
+			}
+
+			return Target{
+				Protocol: strings.ToLower(protocol),
+				Endpoint: spec.OTLP.Endpoint,
+			}
+		},
+	}
+}
+
+// newOTLPTraceExporter builds the trace exporter from the shared settings merged with cfg.Traces.
+func newOTLPTraceExporter(ctx context.Context, shared *config.OTLPConfig) (sdktrace.SpanExporter, error) {
+	resolved := shared.ForSignal(string(SignalTraces))
+	cfg := &resolved
+
+	switch strings.ToLower(cfg.Protocol) {
+	case "http", "https":
+		opts, err := otlpHTTPOptions(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		exp, err := otlptracehttp.New(ctx, opts...)
+		if err != nil {
+			return nil, ewrap.Wrap(err, "create otlp http trace exporter")
+		}
+
+		return exp, nil
+	default:
+		opts, err := otlpGRPCOptions(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		exp, err := otlptracegrpc.New(ctx, opts...)
+		if err != nil {
+			return nil, ewrap.Wrap(err, "create otlp grpc trace exporter")
+		}
+
+		return exp, nil
+	}
+}
+
+// newOTLPMetricExporter builds the metric exporter from the shared settings merged with cfg.Metrics,
+// reporting metrics with the given temporality preference.
+func newOTLPMetricExporter(ctx context.Context, shared *config.OTLPConfig, temporality string) (sdkmetric.Exporter, error) {
+	resolved := shared.ForSignal(string(SignalMetrics))
+	cfg := &resolved
+
+	selector, err := TemporalitySelector(temporality)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(cfg.Protocol) {
+	case "http", "https":
+		opts, err := otlpMetricHTTPOptions(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, otlpmetrichttp.WithTemporalitySelector(selector))
+
+		exp, err := otlpmetrichttp.New(ctx, opts...)
+		if err != nil {
+			return nil, ewrap.Wrap(err, "create otlp http metric exporter")
+		}
+
+		return exp, nil
+	default:
+		opts, err := otlpMetricGRPCOptions(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, otlpmetricgrpc.WithTemporalitySelector(selector))
+
+		exp, err := otlpmetricgrpc.New(ctx, opts...)
+		if err != nil {
+			return nil, ewrap.Wrap(err, "create otlp grpc metric exporter")
+		}
+
+		return exp, nil
+	}
+}
+
+// newOTLPLogExporter builds the log exporter from the shared settings merged with cfg.Logs.
+func newOTLPLogExporter(ctx context.Context, shared *config.OTLPConfig) (sdklog.Exporter, error) {
+	resolved := shared.ForSignal(string(SignalLogs))
+	cfg := &resolved
+
+	switch strings.ToLower(cfg.Protocol) {
+	case "http", "https":
+		opts, err := otlpLogHTTPOptions(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		exp, err := otlploghttp.New(ctx, opts...)
+		if err != nil {
+			return nil, ewrap.Wrap(err, "create otlp http log exporter")
+		}
+
+		return exp, nil
+	default:
+		opts, err := otlpLogGRPCOptions(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		exp, err := otlploggrpc.New(ctx, opts...)
+		if err != nil {
+			return nil, ewrap.Wrap(err, "create otlp grpc log exporter")
+		}
+
+		return exp, nil
+	}
+}
+
+func otlpGRPCOptions(cfg *config.OTLPConfig) ([]otlptracegrpc.Option, error) {
+	opts := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(cfg.Endpoint),
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	} else {
+		tlsCfg, err := tlsConfigFrom(cfg.TLS, cfg.Endpoint)
+		if err != nil && !ErrTLSNotEnabled.Is(err) {
+			return nil, err
+		}
+
+		if tlsCfg != nil {
+			opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+		}
+	}
+
+	if cfg.Timeout > 0 {
+		opts = append(opts, otlptracegrpc.WithTimeout(cfg.Timeout))
+	}
+
+	if cfg.Compression != "" {
+		opts = append(opts, otlptracegrpc.WithCompressor(cfg.Compression))
+	}
+
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlptracegrpc.WithHeaders(cfg.Headers))
+	}
+
+	provider, err := headerProviderFrom(cfg.Auth)
+	if err != nil {
+		return nil, err
+	}
+
+	if provider != nil {
+		opts = append(opts, otlptracegrpc.WithDialOption(grpc.WithPerRPCCredentials(grpcCredentials{provider: provider})))
+	}
+
+	if cfg.Retry.Enabled {
+		opts = append(opts, otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{
+			Enabled:         true,
+			InitialInterval: cfg.Retry.InitialInterval,
+			MaxInterval:     cfg.Retry.MaxInterval,
+			MaxElapsedTime:  cfg.Retry.MaxElapsedTime,
+		}))
+	}
+
+	return opts, nil
+}
+
+func otlpHTTPOptions(cfg *config.OTLPConfig) ([]otlptracehttp.Option, error) {
+	return buildHTTPOptions(cfg, httpOptionFactory[otlptracehttp.Option]{
+		signalPath:     "/v1/traces",
+		withEndpoint:   otlptracehttp.WithEndpoint,
+		withURLPath:    otlptracehttp.WithURLPath,
+		withInsecure:   otlptracehttp.WithInsecure,
+		withTLS:        otlptracehttp.WithTLSClientConfig,
+		withTimeout:    otlptracehttp.WithTimeout,
+		withHeaders:    otlptracehttp.WithHeaders,
+		withHTTPClient: otlptracehttp.WithHTTPClient,
+		withCompression: func(value string) (otlptracehttp.Option, bool) {
+			return otlptracehttp.WithCompression(traceHTTPCompression(value)), true
+		},
+		withRetry: func(retryCfg config.RetryConfig) otlptracehttp.Option {
+			return otlptracehttp.WithRetry(otlptracehttp.RetryConfig{
+				Enabled:         true,
+				InitialInterval: retryCfg.InitialInterval,
+				MaxInterval:     retryCfg.MaxInterval,
+				MaxElapsedTime:  retryCfg.MaxElapsedTime,
+			})
+		},
+	})
+}
+
+func otlpMetricGRPCOptions(cfg *config.OTLPConfig) ([]otlpmetricgrpc.Option, error) {
+	opts := []otlpmetricgrpc.Option{
+		otlpmetricgrpc.WithEndpoint(cfg.Endpoint),
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	} else {
+		tlsCfg, err := tlsConfigFrom(cfg.TLS, cfg.Endpoint)
+		if err != nil && !ErrTLSNotEnabled.Is(err) {
+			return nil, err
+		}
+
+		if tlsCfg != nil {
+			opts = append(opts, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+		}
+	}
+
+	if cfg.Timeout > 0 {
+		opts = append(opts, otlpmetricgrpc.WithTimeout(cfg.Timeout))
+	}
+
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlpmetricgrpc.WithHeaders(cfg.Headers))
+	}
+
+	provider, err := headerProviderFrom(cfg.Auth)
+	if err != nil {
+		return nil, err
+	}
+
+	if provider != nil {
+		opts = append(opts, otlpmetricgrpc.WithDialOption(grpc.WithPerRPCCredentials(grpcCredentials{provider: provider})))
+	}
+
+	if cmp := strings.ToLower(cfg.Compression); cmp != "" {
+		opts = append(opts, otlpmetricgrpc.WithCompressor(cmp))
+	}
+
+	if cfg.Retry.Enabled {
+		opts = append(opts, otlpmetricgrpc.WithRetry(otlpmetricgrpc.RetryConfig{
+			Enabled:         true,
+			InitialInterval: cfg.Retry.InitialInterval,
+			MaxInterval:     cfg.Retry.MaxInterval,
+			MaxElapsedTime:  cfg.Retry.MaxElapsedTime,
+		}))
+	}
+
+	return opts, nil
+}
+
+func otlpMetricHTTPOptions(cfg *config.OTLPConfig) ([]otlpmetrichttp.Option, error) {
+	return buildHTTPOptions(cfg, httpOptionFactory[otlpmetrichttp.Option]{
+		signalPath:     "/v1/metrics",
+		withEndpoint:   otlpmetrichttp.WithEndpoint,
+		withURLPath:    otlpmetrichttp.WithURLPath,
+		withInsecure:   otlpmetrichttp.WithInsecure,
+		withTLS:        otlpmetrichttp.WithTLSClientConfig,
+		withTimeout:    otlpmetrichttp.WithTimeout,
+		withHeaders:    otlpmetrichttp.WithHeaders,
+		withHTTPClient: otlpmetrichttp.WithHTTPClient,
+		withCompression: func(value string) (otlpmetrichttp.Option, bool) {
+			return otlpmetrichttp.WithCompression(metricHTTPCompression(value)), true
+		},
+		withRetry: func(retryCfg config.RetryConfig) otlpmetrichttp.Option {
+			return otlpmetrichttp.WithRetry(otlpmetrichttp.RetryConfig{
+				Enabled:         true,
+				InitialInterval: retryCfg.InitialInterval,
+				MaxInterval:     retryCfg.MaxInterval,
+				MaxElapsedTime:  retryCfg.MaxElapsedTime,
+			})
+		},
+	})
+}
+
+func otlpLogGRPCOptions(cfg *config.OTLPConfig) ([]otlploggrpc.Option, error) {
+	opts := []otlploggrpc.Option{
+		otlploggrpc.WithEndpoint(cfg.Endpoint),
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlploggrpc.WithInsecure())
+	} else {
+		tlsCfg, err := tlsConfigFrom(cfg.TLS, cfg.Endpoint)
+		if err != nil && !ErrTLSNotEnabled.Is(err) {
+			return nil, err
+		}
+
+		if tlsCfg != nil {
+			opts = append(opts, otlploggrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+		}
+	}
+
+	if cfg.Timeout > 0 {
+		opts = append(opts, otlploggrpc.WithTimeout(cfg.Timeout))
+	}
+
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlploggrpc.WithHeaders(cfg.Headers))
+	}
+
+	provider, err := headerProviderFrom(cfg.Auth)
+	if err != nil {
+		return nil, err
+	}
+
+	if provider != nil {
+		opts = append(opts, otlploggrpc.WithDialOption(grpc.WithPerRPCCredentials(grpcCredentials{provider: provider})))
+	}
+
+	if cmp := strings.ToLower(cfg.Compression); cmp != "" {
+		opts = append(opts, otlploggrpc.WithCompressor(cmp))
+	}
+
+	if cfg.Retry.Enabled {
+		opts = append(opts, otlploggrpc.WithRetry(otlploggrpc.RetryConfig{
+			Enabled:         true,
+			InitialInterval: cfg.Retry.InitialInterval,
+			MaxInterval:     cfg.Retry.MaxInterval,
+			MaxElapsedTime:  cfg.Retry.MaxElapsedTime,
+		}))
+	}
+
+	return opts, nil
+}
+
+func otlpLogHTTPOptions(cfg *config.OTLPConfig) ([]otlploghttp.Option, error) {
+	return buildHTTPOptions(cfg, httpOptionFactory[otlploghttp.Option]{
+		signalPath:     "/v1/logs",
+		withEndpoint:   otlploghttp.WithEndpoint,
+		withURLPath:    otlploghttp.WithURLPath,
+		withInsecure:   otlploghttp.WithInsecure,
+		withTLS:        otlploghttp.WithTLSClientConfig,
+		withTimeout:    otlploghttp.WithTimeout,
+		withHeaders:    otlploghttp.WithHeaders,
+		withHTTPClient: otlploghttp.WithHTTPClient,
+		withCompression: func(value string) (otlploghttp.Option, bool) {
+			return otlploghttp.WithCompression(logHTTPCompression(value)), true
+		},
+		withRetry: func(retryCfg config.RetryConfig) otlploghttp.Option {
+			return otlploghttp.WithRetry(otlploghttp.RetryConfig{
+				Enabled:         true,
+				InitialInterval: retryCfg.InitialInterval,
+				MaxInterval:     retryCfg.MaxInterval,
+				MaxElapsedTime:  retryCfg.MaxElapsedTime,
+			})
+		},
+	})
+}
+
+type httpOptionFactory[T any] struct {
+	signalPath      string
+	withEndpoint    func(string) T
+	withURLPath     func(string) T
+	withInsecure    func() T
+	withTLS         func(*tls.Config) T
+	withTimeout     func(time.Duration) T
+	withHeaders     func(map[string]string) T
+	withHTTPClient  func(*http.Client) T
+	withCompression func(string) (T, bool)
+	withRetry       func(config.RetryConfig) T
+}
+
+func buildHTTPOptions[T any](cfg *config.OTLPConfig, factory httpOptionFactory[T]) ([]T, error) {
+	provider, err := headerProviderFrom(cfg.Auth)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []T{factory.withEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, factory.withInsecure())
+	}
+
+	switch {
+	case cfg.URLPath != "":
+		opts = append(opts, factory.withURLPath(cfg.URLPath))
+	case cfg.PathPrefix != "":
+		opts = append(opts, factory.withURLPath(strings.TrimSuffix(cfg.PathPrefix, "/")+factory.signalPath))
+	}
+
+	switch {
+	case provider != nil:
+		// The client carries the TLS settings, which the exporter ignores once a client is set.
+		client, err := authHTTPClient(cfg, provider)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, factory.withHTTPClient(client))
+	case !cfg.Insecure:
+		tlsCfg, err := tlsConfigFrom(cfg.TLS, cfg.Endpoint)
+		if err != nil && !ErrTLSNotEnabled.Is(err) {
+			return nil, err
+		}
+
+		if tlsCfg != nil {
+			opts = append(opts, factory.withTLS(tlsCfg))
+		}
+	}
+
+	if cfg.Timeout > 0 {
+		opts = append(opts, factory.withTimeout(cfg.Timeout))
+	}
+
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, factory.withHeaders(cfg.Headers))
+	}
+
+	if factory.withCompression != nil && cfg.Compression != "" {
+		opt, ok := factory.withCompression(strings.ToLower(cfg.Compression))
+		if ok {
+			opts = append(opts, opt)
+		}
+	}
+
+	if factory.withRetry != nil && cfg.Retry.Enabled {
+		opts = append(opts, factory.withRetry(cfg.Retry))
+	}
+
+	return opts, nil
+}
+
+func traceHTTPCompression(value string) otlptracehttp.Compression {
+	if value == "gzip" {
+		return otlptracehttp.GzipCompression
+	}
+
+	return otlptracehttp.NoCompression
+}
+
+func metricHTTPCompression(value string) otlpmetrichttp.Compression {
+	if value == "gzip" {
+		return otlpmetrichttp.GzipCompression
+	}
+
+	return otlpmetrichttp.NoCompression
+}
+
+func logHTTPCompression(value string) otlploghttp.Compression {
+	if value == "gzip" {
+		return otlploghttp.GzipCompression
+	}
+
+	return otlploghttp.NoCompression
+}
@@ -0,0 +1,555 @@
+package exporters
+
+import (
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// The types below mirror the OTLP protobuf messages using the OTLP/JSON mapping:
+// lowerCamelCase keys, hex-encoded trace and span IDs, integer enums, and 64-bit
+// integers encoded as decimal strings.
+
+type otlpTracesData struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	SchemaURL  string           `json:"schemaUrl,omitempty"`
+}
+
+type otlpScopeSpans struct {
+	Scope     otlpScope  `json:"scope"`
+	Spans     []otlpSpan `json:"spans"`
+	SchemaURL string     `json:"schemaUrl,omitempty"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpScope struct {
+	Name       string         `json:"name,omitempty"`
+	Version    string         `json:"version,omitempty"`
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID                string         `json:"traceId"`
+	SpanID                 string         `json:"spanId"`
+	TraceState             string         `json:"traceState,omitempty"`
+	ParentSpanID           string         `json:"parentSpanId,omitempty"`
+	Flags                  uint32         `json:"flags,omitempty"`
+	Name                   string         `json:"name"`
+	Kind                   int            `json:"kind"`
+	StartTimeUnixNano      uint64         `json:"startTimeUnixNano,string"`
+	EndTimeUnixNano        uint64         `json:"endTimeUnixNano,string"`
+	Attributes             []otlpKeyValue `json:"attributes,omitempty"`
+	DroppedAttributesCount int            `json:"droppedAttributesCount,omitempty"`
+	Events                 []otlpEvent    `json:"events,omitempty"`
+	DroppedEventsCount     int            `json:"droppedEventsCount,omitempty"`
+	Links                  []otlpLink     `json:"links,omitempty"`
+	DroppedLinksCount      int            `json:"droppedLinksCount,omitempty"`
+	Status                 otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano           uint64         `json:"timeUnixNano,string"`
+	Name                   string         `json:"name"`
+	Attributes             []otlpKeyValue `json:"attributes,omitempty"`
+	DroppedAttributesCount int            `json:"droppedAttributesCount,omitempty"`
+}
+
+type otlpLink struct {
+	TraceID                string         `json:"traceId"`
+	SpanID                 string         `json:"spanId"`
+	TraceState             string         `json:"traceState,omitempty"`
+	Attributes             []otlpKeyValue `json:"attributes,omitempty"`
+	DroppedAttributesCount int            `json:"droppedAttributesCount,omitempty"`
+}
+
+type otlpStatus struct {
+	Message string `json:"message,omitempty"`
+	Code    int    `json:"code,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string           `json:"stringValue,omitempty"`
+	BoolValue   *bool             `json:"boolValue,omitempty"`
+	IntValue    *string           `json:"intValue,omitempty"`
+	DoubleValue *float64          `json:"doubleValue,omitempty"`
+	ArrayValue  *otlpArrayJSON    `json:"arrayValue,omitempty"`
+	KvlistValue *otlpKeyValueList `json:"kvlistValue,omitempty"`
+	BytesValue  *string           `json:"bytesValue,omitempty"`
+}
+
+type otlpArrayJSON struct {
+	Values []otlpAnyValue `json:"values"`
+}
+
+type otlpMetricsData struct {
+	ResourceMetrics []otlpResourceMetrics `json:"resourceMetrics"`
+}
+
+type otlpResourceMetrics struct {
+	Resource     otlpResource       `json:"resource"`
+	ScopeMetrics []otlpScopeMetrics `json:"scopeMetrics"`
+	SchemaURL    string             `json:"schemaUrl,omitempty"`
+}
+
+type otlpScopeMetrics struct {
+	Scope     otlpScope    `json:"scope"`
+	Metrics   []otlpMetric `json:"metrics"`
+	SchemaURL string       `json:"schemaUrl,omitempty"`
+}
+
+type otlpMetric struct {
+	Name                 string                    `json:"name"`
+	Description          string                    `json:"description,omitempty"`
+	Unit                 string                    `json:"unit,omitempty"`
+	Gauge                *otlpGauge                `json:"gauge,omitempty"`
+	Sum                  *otlpSum                  `json:"sum,omitempty"`
+	Histogram            *otlpHistogram            `json:"histogram,omitempty"`
+	ExponentialHistogram *otlpExponentialHistogram `json:"exponentialHistogram,omitempty"`
+}
+
+type otlpGauge struct {
+	DataPoints []otlpNumberDataPoint `json:"dataPoints"`
+}
+
+type otlpSum struct {
+	DataPoints             []otlpNumberDataPoint `json:"dataPoints"`
+	AggregationTemporality int                   `json:"aggregationTemporality"`
+	IsMonotonic            bool                  `json:"isMonotonic,omitempty"`
+}
+
+type otlpNumberDataPoint struct {
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano uint64         `json:"startTimeUnixNano,omitempty,string"`
+	TimeUnixNano      uint64         `json:"timeUnixNano,string"`
+	AsDouble          *float64       `json:"asDouble,omitempty"`
+	AsInt             *string        `json:"asInt,omitempty"`
+}
+
+type otlpHistogram struct {
+	DataPoints             []otlpHistogramDataPoint `json:"dataPoints"`
+	AggregationTemporality int                      `json:"aggregationTemporality"`
+}
+
+type otlpHistogramDataPoint struct {
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano uint64         `json:"startTimeUnixNano,omitempty,string"`
+	TimeUnixNano      uint64         `json:"timeUnixNano,string"`
+	Count             uint64         `json:"count,string"`
+	Sum               *float64       `json:"sum,omitempty"`
+	BucketCounts      []string       `json:"bucketCounts,omitempty"`
+	ExplicitBounds    []float64      `json:"explicitBounds,omitempty"`
+	Min               *float64       `json:"min,omitempty"`
+	Max               *float64       `json:"max,omitempty"`
+}
+
+type otlpExponentialHistogram struct {
+	DataPoints             []otlpExponentialHistogramDataPoint `json:"dataPoints"`
+	AggregationTemporality int                                 `json:"aggregationTemporality"`
+}
+
+type otlpExponentialHistogramDataPoint struct {
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano uint64         `json:"startTimeUnixNano,omitempty,string"`
+	TimeUnixNano      uint64         `json:"timeUnixNano,string"`
+	Count             uint64         `json:"count,string"`
+	Sum               *float64       `json:"sum,omitempty"`
+	Scale             int32          `json:"scale"`
+	ZeroCount         uint64         `json:"zeroCount,string"`
+	Positive          otlpBuckets    `json:"positive"`
+	Negative          otlpBuckets    `json:"negative"`
+	Min               *float64       `json:"min,omitempty"`
+	Max               *float64       `json:"max,omitempty"`
+	ZeroThreshold     float64        `json:"zeroThreshold,omitempty"`
+}
+
+type otlpBuckets struct {
+	Offset       int32    `json:"offset"`
+	BucketCounts []string `json:"bucketCounts,omitempty"`
+}
+
+// OTLP enum values; they differ from the SDK's own constants.
+const (
+	otlpStatusOk    = 1
+	otlpStatusError = 2
+
+	otlpTemporalityDelta      = 1
+	otlpTemporalityCumulative = 2
+)
+
+func toOTLPTraces(spans []sdktrace.ReadOnlySpan) otlpTracesData {
+	type scopeKey struct {
+		res   *resource.Resource
+		scope instrumentation.Scope
+	}
+
+	var (
+		data       otlpTracesData
+		resIndex   = map[*resource.Resource]int{}
+		scopeIndex = map[scopeKey]int{}
+	)
+
+	for _, span := range spans {
+		res := span.Resource()
+
+		ri, ok := resIndex[res]
+		if !ok {
+			ri = len(data.ResourceSpans)
+			resIndex[res] = ri
+			data.ResourceSpans = append(data.ResourceSpans, otlpResourceSpans{
+				Resource:  toOTLPResource(res),
+				SchemaURL: schemaURL(res),
+			})
+		}
+
+		key := scopeKey{res: res, scope: span.InstrumentationScope()}
+
+		si, ok := scopeIndex[key]
+		if !ok {
+			rs := &data.ResourceSpans[ri]
+			si = len(rs.ScopeSpans)
+			scopeIndex[key] = si
+			rs.ScopeSpans = append(rs.ScopeSpans, otlpScopeSpans{
+				Scope:     toOTLPScope(key.scope),
+				SchemaURL: key.scope.SchemaURL,
+			})
+		}
+
+		ss := &data.ResourceSpans[ri].ScopeSpans[si]
+		ss.Spans = append(ss.Spans, toOTLPSpan(span))
+	}
+
+	return data
+}
+
+func toOTLPSpan(span sdktrace.ReadOnlySpan) otlpSpan {
+	sc := span.SpanContext()
+
+	out := otlpSpan{
+		TraceID:                sc.TraceID().String(),
+		SpanID:                 sc.SpanID().String(),
+		TraceState:             sc.TraceState().String(),
+		Flags:                  uint32(sc.TraceFlags()),
+		Name:                   span.Name(),
+		Kind:                   int(span.SpanKind()),
+		StartTimeUnixNano:      unixNano(span.StartTime().UnixNano()),
+		EndTimeUnixNano:        unixNano(span.EndTime().UnixNano()),
+		Attributes:             toOTLPAttributes(span.Attributes()),
+		DroppedAttributesCount: span.DroppedAttributes(),
+		DroppedEventsCount:     span.DroppedEvents(),
+		DroppedLinksCount:      span.DroppedLinks(),
+		Status:                 toOTLPStatus(span.Status()),
+	}
+
+	if parent := span.Parent(); parent.SpanID().IsValid() {
+		out.ParentSpanID = parent.SpanID().String()
+	}
+
+	for _, event := range span.Events() {
+		out.Events = append(out.Events, otlpEvent{
+			TimeUnixNano:           unixNano(event.Time.UnixNano()),
+			Name:                   event.Name,
+			Attributes:             toOTLPAttributes(event.Attributes),
+			DroppedAttributesCount: event.DroppedAttributeCount,
+		})
+	}
+
+	for _, link := range span.Links() {
+		out.Links = append(out.Links, otlpLink{
+			TraceID:                link.SpanContext.TraceID().String(),
+			SpanID:                 link.SpanContext.SpanID().String(),
+			TraceState:             link.SpanContext.TraceState().String(),
+			Attributes:             toOTLPAttributes(link.Attributes),
+			DroppedAttributesCount: link.DroppedAttributeCount,
+		})
+	}
+
+	return out
+}
+
+func toOTLPStatus(status sdktrace.Status) otlpStatus {
+	switch status.Code {
+	case codes.Error:
+		return otlpStatus{Code: otlpStatusError, Message: status.Description}
+	case codes.Ok:
+		return otlpStatus{Code: otlpStatusOk}
+	default:
+		return otlpStatus{}
+	}
+}
+
+func toOTLPMetrics(rm *metricdata.ResourceMetrics) otlpMetricsData {
+	out := otlpResourceMetrics{
+		Resource:  toOTLPResource(rm.Resource),
+		SchemaURL: schemaURL(rm.Resource),
+	}
+
+	for _, sm := range rm.ScopeMetrics {
+		scope := otlpScopeMetrics{Scope: toOTLPScope(sm.Scope), SchemaURL: sm.Scope.SchemaURL}
+
+		for _, m := range sm.Metrics {
+			metric, ok := toOTLPMetric(m)
+			if ok {
+				scope.Metrics = append(scope.Metrics, metric)
+			}
+		}
+
+		out.ScopeMetrics = append(out.ScopeMetrics, scope)
+	}
+
+	return otlpMetricsData{ResourceMetrics: []otlpResourceMetrics{out}}
+}
+
+func toOTLPMetric(m metricdata.Metrics) (otlpMetric, bool) {
+	out := otlpMetric{Name: m.Name, Description: m.Description, Unit: m.Unit}
+
+	switch agg := m.Data.(type) {
+	case metricdata.Gauge[int64]:
+		out.Gauge = &otlpGauge{DataPoints: numberPoints(agg.DataPoints)}
+	case metricdata.Gauge[float64]:
+		out.Gauge = &otlpGauge{DataPoints: numberPoints(agg.DataPoints)}
+	case metricdata.Sum[int64]:
+		out.Sum = &otlpSum{
+			DataPoints:             numberPoints(agg.DataPoints),
+			AggregationTemporality: otlpTemporality(agg.Temporality),
+			IsMonotonic:            agg.IsMonotonic,
+		}
+	case metricdata.Sum[float64]:
+		out.Sum = &otlpSum{
+			DataPoints:             numberPoints(agg.DataPoints),
+			AggregationTemporality: otlpTemporality(agg.Temporality),
+			IsMonotonic:            agg.IsMonotonic,
+		}
+	case metricdata.Histogram[int64]:
+		out.Histogram = &otlpHistogram{
+			DataPoints:             histogramPoints(agg.DataPoints),
+			AggregationTemporality: otlpTemporality(agg.Temporality),
+		}
+	case metricdata.Histogram[float64]:
+		out.Histogram = &otlpHistogram{
+			DataPoints:             histogramPoints(agg.DataPoints),
+			AggregationTemporality: otlpTemporality(agg.Temporality),
+		}
+	case metricdata.ExponentialHistogram[int64]:
+		out.ExponentialHistogram = &otlpExponentialHistogram{
+			DataPoints:             exponentialPoints(agg.DataPoints),
+			AggregationTemporality: otlpTemporality(agg.Temporality),
+		}
+	case metricdata.ExponentialHistogram[float64]:
+		out.ExponentialHistogram = &otlpExponentialHistogram{
+			DataPoints:             exponentialPoints(agg.DataPoints),
+			AggregationTemporality: otlpTemporality(agg.Temporality),
+		}
+	default:
+		return otlpMetric{}, false
+	}
+
+	return out, true
+}
+
+func numberPoints[N int64 | float64](points []metricdata.DataPoint[N]) []otlpNumberDataPoint {
+	out := make([]otlpNumberDataPoint, 0, len(points))
+
+	for _, dp := range points {
+		point := otlpNumberDataPoint{
+			Attributes:        toOTLPAttributes(dp.Attributes.ToSlice()),
+			StartTimeUnixNano: unixNano(dp.StartTime.UnixNano()),
+			TimeUnixNano:      unixNano(dp.Time.UnixNano()),
+		}
+
+		switch v := any(dp.Value).(type) {
+		case int64:
+			point.AsInt = int64String(v)
+		case float64:
+			point.AsDouble = &v
+		}
+
+		out = append(out, point)
+	}
+
+	return out
+}
+
+func histogramPoints[N int64 | float64](points []metricdata.HistogramDataPoint[N]) []otlpHistogramDataPoint {
+	out := make([]otlpHistogramDataPoint, 0, len(points))
+
+	for _, dp := range points {
+		sum := float64(dp.Sum)
+		out = append(out, otlpHistogramDataPoint{
+			Attributes:        toOTLPAttributes(dp.Attributes.ToSlice()),
+			StartTimeUnixNano: unixNano(dp.StartTime.UnixNano()),
+			TimeUnixNano:      unixNano(dp.Time.UnixNano()),
+			Count:             dp.Count,
+			Sum:               &sum,
+			BucketCounts:      uintStrings(dp.BucketCounts),
+			ExplicitBounds:    dp.Bounds,
+			Min:               extremaValue(dp.Min),
+			Max:               extremaValue(dp.Max),
+		})
+	}
+
+	return out
+}
+
+func exponentialPoints[N int64 | float64](
+	points []metricdata.ExponentialHistogramDataPoint[N],
+) []otlpExponentialHistogramDataPoint {
+	out := make([]otlpExponentialHistogramDataPoint, 0, len(points))
+
+	for _, dp := range points {
+		sum := float64(dp.Sum)
+		out = append(out, otlpExponentialHistogramDataPoint{
+			Attributes:        toOTLPAttributes(dp.Attributes.ToSlice()),
+			StartTimeUnixNano: unixNano(dp.StartTime.UnixNano()),
+			TimeUnixNano:      unixNano(dp.Time.UnixNano()),
+			Count:             dp.Count,
+			Sum:               &sum,
+			Scale:             dp.Scale,
+			ZeroCount:         dp.ZeroCount,
+			Positive:          otlpBuckets{Offset: dp.PositiveBucket.Offset, BucketCounts: uintStrings(dp.PositiveBucket.Counts)},
+			Negative:          otlpBuckets{Offset: dp.NegativeBucket.Offset, BucketCounts: uintStrings(dp.NegativeBucket.Counts)},
+			Min:               extremaValue(dp.Min),
+			Max:               extremaValue(dp.Max),
+			ZeroThreshold:     dp.ZeroThreshold,
+		})
+	}
+
+	return out
+}
+
+func extremaValue[N int64 | float64](e metricdata.Extrema[N]) *float64 {
+	v, ok := e.Value()
+	if !ok {
+		return nil
+	}
+
+	f := float64(v)
+
+	return &f
+}
+
+func otlpTemporality(t metricdata.Temporality) int {
+	if t == metricdata.DeltaTemporality {
+		return otlpTemporalityDelta
+	}
+
+	return otlpTemporalityCumulative
+}
+
+func toOTLPResource(res *resource.Resource) otlpResource {
+	if res == nil {
+		return otlpResource{}
+	}
+
+	return otlpResource{Attributes: toOTLPAttributes(res.Attributes())}
+}
+
+func schemaURL(res *resource.Resource) string {
+	if res == nil {
+		return ""
+	}
+
+	return res.SchemaURL()
+}
+
+func toOTLPScope(scope instrumentation.Scope) otlpScope {
+	return otlpScope{
+		Name:       scope.Name,
+		Version:    scope.Version,
+		Attributes: toOTLPAttributes(scope.Attributes.ToSlice()),
+	}
+}
+
+func toOTLPAttributes(attrs []attribute.KeyValue) []otlpKeyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	out := make([]otlpKeyValue, 0, len(attrs))
+	for _, kv := range attrs {
+		out = append(out, otlpKeyValue{Key: string(kv.Key), Value: toOTLPValue(kv.Value)})
+	}
+
+	return out
+}
+
+func toOTLPValue(v attribute.Value) otlpAnyValue {
+	switch v.Type() {
+	case attribute.BOOL:
+		b := v.AsBool()
+
+		return otlpAnyValue{BoolValue: &b}
+	case attribute.INT64:
+		return otlpAnyValue{IntValue: int64String(v.AsInt64())}
+	case attribute.FLOAT64:
+		f := v.AsFloat64()
+
+		return otlpAnyValue{DoubleValue: &f}
+	case attribute.BOOLSLICE:
+		return arrayValue(v.AsBoolSlice(), func(b bool) otlpAnyValue { return otlpAnyValue{BoolValue: &b} })
+	case attribute.INT64SLICE:
+		return arrayValue(v.AsInt64Slice(), func(i int64) otlpAnyValue { return otlpAnyValue{IntValue: int64String(i)} })
+	case attribute.FLOAT64SLICE:
+		return arrayValue(v.AsFloat64Slice(), func(f float64) otlpAnyValue { return otlpAnyValue{DoubleValue: &f} })
+	case attribute.STRINGSLICE:
+		return arrayValue(v.AsStringSlice(), func(s string) otlpAnyValue { return otlpAnyValue{StringValue: &s} })
+	default:
+		s := v.Emit()
+
+		return otlpAnyValue{StringValue: &s}
+	}
+}
+
+func arrayValue[T any](values []T, convert func(T) otlpAnyValue) otlpAnyValue {
+	out := make([]otlpAnyValue, 0, len(values))
+	for _, v := range values {
+		out = append(out, convert(v))
+	}
+
+	return otlpAnyValue{ArrayValue: &otlpArrayJSON{Values: out}}
+}
+
+func int64String(v int64) *string {
+	s := strconv.FormatInt(v, 10)
+
+	return &s
+}
+
+func uintStrings(values []uint64) []string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		out = append(out, strconv.FormatUint(v, 10))
+	}
+
+	return out
+}
+
+// unixNano converts SDK timestamps, clamping pre-epoch values to zero.
+func unixNano(nanos int64) uint64 {
+	if nanos < 0 {
+		return 0
+	}
+
+	return uint64(nanos)
+}
@@ -0,0 +1,497 @@
+package exporters
+
+import (
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/hyp3rd/ewrap"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// decodedSpan is a ReadOnlySpan rebuilt from its OTLP-JSON form. The embedded interface is
+// always nil: it only satisfies ReadOnlySpan's unexported method, and every method it declares
+// is overridden below.
+type decodedSpan struct {
+	sdktrace.ReadOnlySpan
+
+	name              string
+	spanContext       trace.SpanContext
+	parent            trace.SpanContext
+	kind              trace.SpanKind
+	startTime         time.Time
+	endTime           time.Time
+	attributes        []attribute.KeyValue
+	links             []sdktrace.Link
+	events            []sdktrace.Event
+	status            sdktrace.Status
+	scope             instrumentation.Scope
+	resource          *resource.Resource
+	droppedAttributes int
+	droppedLinks      int
+	droppedEvents     int
+}
+
+func (s *decodedSpan) Name() string                                { return s.name }
+func (s *decodedSpan) SpanContext() trace.SpanContext              { return s.spanContext }
+func (s *decodedSpan) Parent() trace.SpanContext                   { return s.parent }
+func (s *decodedSpan) SpanKind() trace.SpanKind                    { return s.kind }
+func (s *decodedSpan) StartTime() time.Time                        { return s.startTime }
+func (s *decodedSpan) EndTime() time.Time                          { return s.endTime }
+func (s *decodedSpan) Attributes() []attribute.KeyValue            { return s.attributes }
+func (s *decodedSpan) Links() []sdktrace.Link                      { return s.links }
+func (s *decodedSpan) Events() []sdktrace.Event                    { return s.events }
+func (s *decodedSpan) Status() sdktrace.Status                     { return s.status }
+func (s *decodedSpan) InstrumentationScope() instrumentation.Scope { return s.scope }
+func (s *decodedSpan) Resource() *resource.Resource                { return s.resource }
+func (s *decodedSpan) DroppedAttributes() int                      { return s.droppedAttributes }
+func (s *decodedSpan) DroppedLinks() int                           { return s.droppedLinks }
+func (s *decodedSpan) DroppedEvents() int                          { return s.droppedEvents }
+func (*decodedSpan) ChildSpanCount() int                           { return 0 }
+
+//nolint:staticcheck // ReadOnlySpan still declares the deprecated method.
+func (s *decodedSpan) InstrumentationLibrary() instrumentation.Library { return s.scope }
+
+// fromOTLPTraces rebuilds read-only spans from their OTLP-JSON form.
+func fromOTLPTraces(data otlpTracesData) ([]sdktrace.ReadOnlySpan, error) {
+	var spans []sdktrace.ReadOnlySpan
+
+	for _, rs := range data.ResourceSpans {
+		res := resource.NewWithAttributes(rs.SchemaURL, fromOTLPAttributes(rs.Resource.Attributes)...)
+
+		for _, ss := range rs.ScopeSpans {
+			scope := fromOTLPScope(ss.Scope, ss.SchemaURL)
+
+			for _, s := range ss.Spans {
+				span, err := fromOTLPSpan(s)
+				if err != nil {
+					return nil, err
+				}
+
+				span.resource = res
+				span.scope = scope
+				spans = append(spans, span)
+			}
+		}
+	}
+
+	return spans, nil
+}
+
+func fromOTLPSpan(s otlpSpan) (*decodedSpan, error) {
+	sc, err := spanContext(s.TraceID, s.SpanID, s.TraceState, s.Flags)
+	if err != nil {
+		return nil, err
+	}
+
+	span := &decodedSpan{
+		name:              s.Name,
+		spanContext:       sc,
+		kind:              trace.SpanKind(s.Kind),
+		startTime:         fromUnixNano(s.StartTimeUnixNano),
+		endTime:           fromUnixNano(s.EndTimeUnixNano),
+		attributes:        fromOTLPAttributes(s.Attributes),
+		status:            fromOTLPStatus(s.Status),
+		droppedAttributes: s.DroppedAttributesCount,
+		droppedEvents:     s.DroppedEventsCount,
+		droppedLinks:      s.DroppedLinksCount,
+	}
+
+	if s.ParentSpanID != "" {
+		parent, err := spanContext(s.TraceID, s.ParentSpanID, "", 0)
+		if err != nil {
+			return nil, err
+		}
+
+		span.parent = parent
+	}
+
+	for _, event := range s.Events {
+		span.events = append(span.events, sdktrace.Event{
+			Name:                  event.Name,
+			Time:                  fromUnixNano(event.TimeUnixNano),
+			Attributes:            fromOTLPAttributes(event.Attributes),
+			DroppedAttributeCount: event.DroppedAttributesCount,
+		})
+	}
+
+	for _, link := range s.Links {
+		linked, err := spanContext(link.TraceID, link.SpanID, link.TraceState, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		span.links = append(span.links, sdktrace.Link{
+			SpanContext:           linked,
+			Attributes:            fromOTLPAttributes(link.Attributes),
+			DroppedAttributeCount: link.DroppedAttributesCount,
+		})
+	}
+
+	return span, nil
+}
+
+func spanContext(traceHex, spanHex, traceState string, flags uint32) (trace.SpanContext, error) {
+	var cfg trace.SpanContextConfig
+
+	raw, err := hex.DecodeString(traceHex)
+	if err != nil || len(raw) != len(cfg.TraceID) {
+		return trace.SpanContext{}, ewrap.Newf("invalid trace id %q", traceHex)
+	}
+
+	copy(cfg.TraceID[:], raw)
+
+	raw, err = hex.DecodeString(spanHex)
+	if err != nil || len(raw) != len(cfg.SpanID) {
+		return trace.SpanContext{}, ewrap.Newf("invalid span id %q", spanHex)
+	}
+
+	copy(cfg.SpanID[:], raw)
+
+	cfg.TraceFlags = trace.TraceFlags(byte(flags))
+
+	if traceState != "" {
+		cfg.TraceState, err = trace.ParseTraceState(traceState)
+		if err != nil {
+			return trace.SpanContext{}, ewrap.Wrap(err, "parse trace state")
+		}
+	}
+
+	return trace.NewSpanContext(cfg), nil
+}
+
+func fromOTLPStatus(status otlpStatus) sdktrace.Status {
+	switch status.Code {
+	case otlpStatusError:
+		return sdktrace.Status{Code: codes.Error, Description: status.Message}
+	case otlpStatusOk:
+		return sdktrace.Status{Code: codes.Ok}
+	default:
+		return sdktrace.Status{Code: codes.Unset}
+	}
+}
+
+func fromOTLPMetrics(data otlpMetricsData) ([]*metricdata.ResourceMetrics, error) {
+	out := make([]*metricdata.ResourceMetrics, 0, len(data.ResourceMetrics))
+
+	for _, rm := range data.ResourceMetrics {
+		decoded := &metricdata.ResourceMetrics{
+			Resource: resource.NewWithAttributes(rm.SchemaURL, fromOTLPAttributes(rm.Resource.Attributes)...),
+		}
+
+		for _, sm := range rm.ScopeMetrics {
+			scope := metricdata.ScopeMetrics{Scope: fromOTLPScope(sm.Scope, sm.SchemaURL)}
+
+			for _, m := range sm.Metrics {
+				metric, err := fromOTLPMetric(m)
+				if err != nil {
+					return nil, err
+				}
+
+				scope.Metrics = append(scope.Metrics, metric)
+			}
+
+			decoded.ScopeMetrics = append(decoded.ScopeMetrics, scope)
+		}
+
+		out = append(out, decoded)
+	}
+
+	return out, nil
+}
+
+func fromOTLPMetric(m otlpMetric) (metricdata.Metrics, error) {
+	out := metricdata.Metrics{Name: m.Name, Description: m.Description, Unit: m.Unit}
+
+	switch {
+	case m.Gauge != nil:
+		if isIntPoints(m.Gauge.DataPoints) {
+			points, err := intPoints(m.Gauge.DataPoints)
+			out.Data = metricdata.Gauge[int64]{DataPoints: points}
+
+			return out, err
+		}
+
+		out.Data = metricdata.Gauge[float64]{DataPoints: floatPoints(m.Gauge.DataPoints)}
+	case m.Sum != nil:
+		temporality := fromOTLPTemporality(m.Sum.AggregationTemporality)
+
+		if isIntPoints(m.Sum.DataPoints) {
+			points, err := intPoints(m.Sum.DataPoints)
+			out.Data = metricdata.Sum[int64]{DataPoints: points, Temporality: temporality, IsMonotonic: m.Sum.IsMonotonic}
+
+			return out, err
+		}
+
+		out.Data = metricdata.Sum[float64]{
+			DataPoints:  floatPoints(m.Sum.DataPoints),
+			Temporality: temporality,
+			IsMonotonic: m.Sum.IsMonotonic,
+		}
+	case m.Histogram != nil:
+		points, err := fromHistogramPoints(m.Histogram.DataPoints)
+		out.Data = metricdata.Histogram[float64]{
+			DataPoints:  points,
+			Temporality: fromOTLPTemporality(m.Histogram.AggregationTemporality),
+		}
+
+		return out, err
+	case m.ExponentialHistogram != nil:
+		points, err := fromExponentialPoints(m.ExponentialHistogram.DataPoints)
+		out.Data = metricdata.ExponentialHistogram[float64]{
+			DataPoints:  points,
+			Temporality: fromOTLPTemporality(m.ExponentialHistogram.AggregationTemporality),
+		}
+
+		return out, err
+	default:
+		return out, ewrap.Newf("metric %q has no supported data", m.Name)
+	}
+
+	return out, nil
+}
+
+func isIntPoints(points []otlpNumberDataPoint) bool {
+	return len(points) > 0 && points[0].AsInt != nil
+}
+
+func intPoints(points []otlpNumberDataPoint) ([]metricdata.DataPoint[int64], error) {
+	out := make([]metricdata.DataPoint[int64], 0, len(points))
+
+	for _, dp := range points {
+		var value int64
+
+		if dp.AsInt != nil {
+			v, err := strconv.ParseInt(*dp.AsInt, 10, 64)
+			if err != nil {
+				return nil, ewrap.Wrap(err, "parse int data point")
+			}
+
+			value = v
+		}
+
+		out = append(out, metricdata.DataPoint[int64]{
+			Attributes: attribute.NewSet(fromOTLPAttributes(dp.Attributes)...),
+			StartTime:  fromUnixNano(dp.StartTimeUnixNano),
+			Time:       fromUnixNano(dp.TimeUnixNano),
+			Value:      value,
+		})
+	}
+
+	return out, nil
+}
+
+func floatPoints(points []otlpNumberDataPoint) []metricdata.DataPoint[float64] {
+	out := make([]metricdata.DataPoint[float64], 0, len(points))
+
+	for _, dp := range points {
+		var value float64
+		if dp.AsDouble != nil {
+			value = *dp.AsDouble
+		}
+
+		out = append(out, metricdata.DataPoint[float64]{
+			Attributes: attribute.NewSet(fromOTLPAttributes(dp.Attributes)...),
+			StartTime:  fromUnixNano(dp.StartTimeUnixNano),
+			Time:       fromUnixNano(dp.TimeUnixNano),
+			Value:      value,
+		})
+	}
+
+	return out
+}
+
+func fromHistogramPoints(points []otlpHistogramDataPoint) ([]metricdata.HistogramDataPoint[float64], error) {
+	out := make([]metricdata.HistogramDataPoint[float64], 0, len(points))
+
+	for _, dp := range points {
+		counts, err := parseUints(dp.BucketCounts)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, metricdata.HistogramDataPoint[float64]{
+			Attributes:   attribute.NewSet(fromOTLPAttributes(dp.Attributes)...),
+			StartTime:    fromUnixNano(dp.StartTimeUnixNano),
+			Time:         fromUnixNano(dp.TimeUnixNano),
+			Count:        dp.Count,
+			Bounds:       dp.ExplicitBounds,
+			BucketCounts: counts,
+			Min:          fromExtrema(dp.Min),
+			Max:          fromExtrema(dp.Max),
+			Sum:          valueOrZero(dp.Sum),
+		})
+	}
+
+	return out, nil
+}
+
+func fromExponentialPoints(
+	points []otlpExponentialHistogramDataPoint,
+) ([]metricdata.ExponentialHistogramDataPoint[float64], error) {
+	out := make([]metricdata.ExponentialHistogramDataPoint[float64], 0, len(points))
+
+	for _, dp := range points {
+		positive, err := parseUints(dp.Positive.BucketCounts)
+		if err != nil {
+			return nil, err
+		}
+
+		negative, err := parseUints(dp.Negative.BucketCounts)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, metricdata.ExponentialHistogramDataPoint[float64]{
+			Attributes:     attribute.NewSet(fromOTLPAttributes(dp.Attributes)...),
+			StartTime:      fromUnixNano(dp.StartTimeUnixNano),
+			Time:           fromUnixNano(dp.TimeUnixNano),
+			Count:          dp.Count,
+			Min:            fromExtrema(dp.Min),
+			Max:            fromExtrema(dp.Max),
+			Sum:            valueOrZero(dp.Sum),
+			Scale:          dp.Scale,
+			ZeroCount:      dp.ZeroCount,
+			ZeroThreshold:  dp.ZeroThreshold,
+			PositiveBucket: metricdata.ExponentialBucket{Offset: dp.Positive.Offset, Counts: positive},
+			NegativeBucket: metricdata.ExponentialBucket{Offset: dp.Negative.Offset, Counts: negative},
+		})
+	}
+
+	return out, nil
+}
+
+func fromExtrema(v *float64) metricdata.Extrema[float64] {
+	if v == nil {
+		return metricdata.Extrema[float64]{}
+	}
+
+	return metricdata.NewExtrema(*v)
+}
+
+func valueOrZero(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+
+	return *v
+}
+
+func parseUints(values []string) ([]uint64, error) {
+	out := make([]uint64, 0, len(values))
+
+	for _, raw := range values {
+		v, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return nil, ewrap.Wrap(err, "parse bucket count")
+		}
+
+		out = append(out, v)
+	}
+
+	return out, nil
+}
+
+func fromOTLPTemporality(t int) metricdata.Temporality {
+	if t == otlpTemporalityDelta {
+		return metricdata.DeltaTemporality
+	}
+
+	return metricdata.CumulativeTemporality
+}
+
+func fromOTLPScope(scope otlpScope, schemaURL string) instrumentation.Scope {
+	return instrumentation.Scope{
+		Name:       scope.Name,
+		Version:    scope.Version,
+		SchemaURL:  schemaURL,
+		Attributes: attribute.NewSet(fromOTLPAttributes(scope.Attributes)...),
+	}
+}
+
+func fromOTLPAttributes(kvs []otlpKeyValue) []attribute.KeyValue {
+	if len(kvs) == 0 {
+		return nil
+	}
+
+	out := make([]attribute.KeyValue, 0, len(kvs))
+	for _, kv := range kvs {
+		out = append(out, fromOTLPValue(attribute.Key(kv.Key), kv.Value))
+	}
+
+	return out
+}
+
+func fromOTLPValue(key attribute.Key, v otlpAnyValue) attribute.KeyValue {
+	switch {
+	case v.StringValue != nil:
+		return key.String(*v.StringValue)
+	case v.BoolValue != nil:
+		return key.Bool(*v.BoolValue)
+	case v.IntValue != nil:
+		i, _ := strconv.ParseInt(*v.IntValue, 10, 64)
+
+		return key.Int64(i)
+	case v.DoubleValue != nil:
+		return key.Float64(*v.DoubleValue)
+	case v.ArrayValue != nil:
+		return fromOTLPArray(key, v.ArrayValue.Values)
+	default:
+		return key.String("")
+	}
+}
+
+// fromOTLPArray restores homogeneous slices, which is all the SDK attribute model can hold.
+func fromOTLPArray(key attribute.Key, values []otlpAnyValue) attribute.KeyValue {
+	if len(values) == 0 {
+		return key.StringSlice(nil)
+	}
+
+	switch first := values[0]; {
+	case first.BoolValue != nil:
+		return key.BoolSlice(collect(values, func(v otlpAnyValue) bool { return v.BoolValue != nil && *v.BoolValue }))
+	case first.IntValue != nil:
+		return key.Int64Slice(collect(values, func(v otlpAnyValue) int64 {
+			if v.IntValue == nil {
+				return 0
+			}
+
+			i, _ := strconv.ParseInt(*v.IntValue, 10, 64)
+
+			return i
+		}))
+	case first.DoubleValue != nil:
+		return key.Float64Slice(collect(values, func(v otlpAnyValue) float64 { return valueOrZero(v.DoubleValue) }))
+	default:
+		return key.StringSlice(collect(values, func(v otlpAnyValue) string {
+			if v.StringValue == nil {
+				return ""
+			}
+
+			return *v.StringValue
+		}))
+	}
+}
+
+func collect[T any](values []otlpAnyValue, convert func(otlpAnyValue) T) []T {
+	out := make([]T, 0, len(values))
+	for _, v := range values {
+		out = append(out, convert(v))
+	}
+
+	return out
+}
+
+func fromUnixNano(nanos uint64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, int64(nanos)) //nolint:gosec // OTLP timestamps fit in int64 until 2262.
+}
@@ -0,0 +1,169 @@
+package exporters
+
+import (
+	"encoding/base64"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+type otlpLogsData struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+	SchemaURL string          `json:"schemaUrl,omitempty"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpScope       `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+	SchemaURL  string          `json:"schemaUrl,omitempty"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano           uint64         `json:"timeUnixNano,omitempty,string"`
+	ObservedTimeUnixNano   uint64         `json:"observedTimeUnixNano,omitempty,string"`
+	SeverityNumber         int            `json:"severityNumber,omitempty"`
+	SeverityText           string         `json:"severityText,omitempty"`
+	Body                   *otlpAnyValue  `json:"body,omitempty"`
+	Attributes             []otlpKeyValue `json:"attributes,omitempty"`
+	DroppedAttributesCount int            `json:"droppedAttributesCount,omitempty"`
+	Flags                  uint32         `json:"flags,omitempty"`
+	TraceID                string         `json:"traceId,omitempty"`
+	SpanID                 string         `json:"spanId,omitempty"`
+	EventName              string         `json:"eventName,omitempty"`
+}
+
+type otlpKeyValueList struct {
+	Values []otlpKeyValue `json:"values"`
+}
+
+func toOTLPLogs(records []sdklog.Record) otlpLogsData {
+	type scopeKey struct {
+		res   *resource.Resource
+		scope instrumentation.Scope
+	}
+
+	var (
+		data       otlpLogsData
+		resIndex   = map[*resource.Resource]int{}
+		scopeIndex = map[scopeKey]int{}
+	)
+
+	for i := range records {
+		record := &records[i]
+		res := record.Resource()
+
+		ri, ok := resIndex[res]
+		if !ok {
+			ri = len(data.ResourceLogs)
+			resIndex[res] = ri
+			data.ResourceLogs = append(data.ResourceLogs, otlpResourceLogs{
+				Resource:  toOTLPResource(res),
+				SchemaURL: schemaURL(res),
+			})
+		}
+
+		key := scopeKey{res: res, scope: record.InstrumentationScope()}
+
+		si, ok := scopeIndex[key]
+		if !ok {
+			rl := &data.ResourceLogs[ri]
+			si = len(rl.ScopeLogs)
+			scopeIndex[key] = si
+			rl.ScopeLogs = append(rl.ScopeLogs, otlpScopeLogs{
+				Scope:     toOTLPScope(key.scope),
+				SchemaURL: key.scope.SchemaURL,
+			})
+		}
+
+		sl := &data.ResourceLogs[ri].ScopeLogs[si]
+		sl.LogRecords = append(sl.LogRecords, toOTLPLogRecord(record))
+	}
+
+	return data
+}
+
+func toOTLPLogRecord(record *sdklog.Record) otlpLogRecord {
+	out := otlpLogRecord{
+		TimeUnixNano:           logTime(record.Timestamp()),
+		ObservedTimeUnixNano:   logTime(record.ObservedTimestamp()),
+		SeverityNumber:         int(record.Severity()),
+		SeverityText:           record.SeverityText(),
+		DroppedAttributesCount: record.DroppedAttributes(),
+		Flags:                  uint32(record.TraceFlags()),
+		EventName:              record.EventName(),
+	}
+
+	if body := record.Body(); body.Kind() != otellog.KindEmpty {
+		value := toOTLPLogValue(body)
+		out.Body = &value
+	}
+
+	if traceID := record.TraceID(); traceID.IsValid() {
+		out.TraceID = traceID.String()
+	}
+
+	if spanID := record.SpanID(); spanID.IsValid() {
+		out.SpanID = spanID.String()
+	}
+
+	record.WalkAttributes(func(kv otellog.KeyValue) bool {
+		out.Attributes = append(out.Attributes, otlpKeyValue{Key: kv.Key, Value: toOTLPLogValue(kv.Value)})
+
+		return true
+	})
+
+	return out
+}
+
+// logTime leaves unset timestamps at zero, which OTLP reads as unknown.
+func logTime(ts time.Time) uint64 {
+	if ts.IsZero() {
+		return 0
+	}
+
+	return unixNano(ts.UnixNano())
+}
+
+func toOTLPLogValue(v otellog.Value) otlpAnyValue {
+	switch v.Kind() {
+	case otellog.KindBool:
+		b := v.AsBool()
+
+		return otlpAnyValue{BoolValue: &b}
+	case otellog.KindInt64:
+		return otlpAnyValue{IntValue: int64String(v.AsInt64())}
+	case otellog.KindFloat64:
+		f := v.AsFloat64()
+
+		return otlpAnyValue{DoubleValue: &f}
+	case otellog.KindBytes:
+		s := base64.StdEncoding.EncodeToString(v.AsBytes())
+
+		return otlpAnyValue{BytesValue: &s}
+	case otellog.KindSlice:
+		return arrayValue(v.AsSlice(), toOTLPLogValue)
+	case otellog.KindMap:
+		kvs := v.AsMap()
+		list := otlpKeyValueList{Values: make([]otlpKeyValue, 0, len(kvs))}
+
+		for _, kv := range kvs {
+			list.Values = append(list.Values, otlpKeyValue{Key: kv.Key, Value: toOTLPLogValue(kv.Value)})
+		}
+
+		return otlpAnyValue{KvlistValue: &list}
+	case otellog.KindEmpty:
+		return otlpAnyValue{}
+	default:
+		s := v.AsString()
+
+		return otlpAnyValue{StringValue: &s}
+	}
+}
@@ -0,0 +1,347 @@
+package exporters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hyp3rd/ewrap"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/hyp3rd/observe/pkg/config"
+)
+
+// QueueOption customizes a persistent exporter.
+type QueueOption func(*queueOptions)
+
+type queueOptions struct {
+	onDrop func(items int)
+}
+
+// WithDropHandler is called with the number of items lost whenever the queue evicts a batch.
+func WithDropHandler(fn func(items int)) QueueOption {
+	return func(o *queueOptions) {
+		if fn != nil {
+			o.onDrop = fn
+		}
+	}
+}
+
+// persistentQueue is the signal-agnostic half of the persistent exporters: it spools failed
+// batches to disk and replays them oldest-first from a background loop.
+type persistentQueue struct {
+	queue    *diskQueue
+	replay   func(context.Context, []byte) error
+	onDrop   func(items int)
+	interval time.Duration
+
+	cancel   context.CancelFunc
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func newPersistentQueue(
+	dir string,
+	cfg config.QueueConfig,
+	replay func(context.Context, []byte) error,
+	opts []QueueOption,
+) (*persistentQueue, error) {
+	options := queueOptions{onDrop: func(int) {}}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	queue, err := openDiskQueue(dir, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	pq := &persistentQueue{
+		queue:    queue,
+		replay:   replay,
+		onDrop:   options.onDrop,
+		interval: durationOr(cfg.ReplayInterval, defaultReplayInterval),
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+
+	go pq.run(ctx, !queue.empty())
+
+	return pq, nil
+}
+
+// submit exports directly while the queue is empty and spools the batch otherwise, so a
+// backend outage never blocks the batch processor and delivery order is preserved.
+func (pq *persistentQueue) submit(
+	ctx context.Context,
+	items int,
+	direct func(context.Context) error,
+	encode func() ([]byte, error),
+) error {
+	if pq.queue.empty() && direct(ctx) == nil {
+		return nil
+	}
+
+	data, err := encode()
+	if err != nil {
+		return ewrap.Wrap(err, "encode queued batch")
+	}
+
+	dropped, err := pq.queue.push(data, items)
+	if dropped > 0 {
+		pq.onDrop(dropped)
+	}
+
+	return err
+}
+
+func (pq *persistentQueue) run(ctx context.Context, leftover bool) {
+	defer close(pq.done)
+
+	ticker := time.NewTicker(pq.interval)
+	defer ticker.Stop()
+
+	// Batches left over from a previous process are replayed right away.
+	if leftover {
+		pq.drain(ctx)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pq.drain(ctx)
+		}
+	}
+}
+
+// drain replays queued batches oldest-first until one fails or the queue is empty.
+// Only the replay loop calls it, so batches never overtake each other.
+func (pq *persistentQueue) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		entry, ok := pq.queue.next()
+		if !ok {
+			return
+		}
+
+		data, err := pq.queue.read(entry)
+		if err == nil {
+			err = pq.replay(ctx, data)
+			if err == nil {
+				pq.handle(pq.queue.ack(entry))
+
+				continue
+			}
+
+			var decodeErr *queueDecodeError
+			if !errors.As(err, &decodeErr) {
+				// The backend is still unavailable; try again on the next tick.
+				pq.queue.release()
+
+				return
+			}
+		}
+
+		// Unreadable batches would block the queue forever.
+		otel.Handle(err)
+		pq.handle(pq.queue.discard(entry))
+		pq.onDrop(entry.items)
+	}
+}
+
+func (*persistentQueue) handle(err error) {
+	if err != nil {
+		otel.Handle(err)
+	}
+}
+
+// stop ends the replay loop; queued batches stay on disk for the next process.
+func (pq *persistentQueue) stop(ctx context.Context) error {
+	pq.stopOnce.Do(pq.cancel)
+
+	select {
+	case <-pq.done:
+		return nil
+	case <-ctx.Done():
+		return ewrap.Wrap(ctx.Err(), "stop persistent queue")
+	}
+}
+
+// queueDecodeError marks a queued batch that can no longer be decoded.
+type queueDecodeError struct {
+	err error
+}
+
+func (e *queueDecodeError) Error() string { return "decode queued batch: " + e.err.Error() }
+
+func (e *queueDecodeError) Unwrap() error { return e.err }
+
+// queueDir isolates each exporter and signal inside the configured queue directory.
+func queueDir(cfg config.QueueConfig, name string, signal Signal) string {
+	return filepath.Join(cfg.Dir, normalizeName(name), string(signal))
+}
+
+// PersistentSpanExporter spools span batches to disk while the wrapped exporter fails and
+// replays them once it recovers, including after a process restart.
+type PersistentSpanExporter struct {
+	inner sdktrace.SpanExporter
+	queue *persistentQueue
+}
+
+// NewPersistentSpanExporter wraps inner with a disk queue under cfg.Dir/<name>/traces.
+func NewPersistentSpanExporter(
+	inner sdktrace.SpanExporter,
+	name string,
+	cfg config.QueueConfig,
+	opts ...QueueOption,
+) (*PersistentSpanExporter, error) {
+	exp := &PersistentSpanExporter{inner: inner}
+
+	queue, err := newPersistentQueue(queueDir(cfg, name, SignalTraces), cfg, exp.replay, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	exp.queue = queue
+
+	return exp, nil
+}
+
+// ExportSpans implements sdktrace.SpanExporter.
+func (e *PersistentSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	return e.queue.submit(ctx, len(spans),
+		func(ctx context.Context) error { return e.inner.ExportSpans(ctx, spans) },
+		func() ([]byte, error) { return json.Marshal(toOTLPTraces(spans)) },
+	)
+}
+
+func (e *PersistentSpanExporter) replay(ctx context.Context, data []byte) error {
+	var payload otlpTracesData
+
+	err := json.Unmarshal(data, &payload)
+	if err != nil {
+		return &queueDecodeError{err: err}
+	}
+
+	spans, err := fromOTLPTraces(payload)
+	if err != nil {
+		return &queueDecodeError{err: err}
+	}
+
+	return e.inner.ExportSpans(ctx, spans)
+}
+
+// Stats reports the queue depth, size on disk and replay/eviction counters.
+func (e *PersistentSpanExporter) Stats() QueueStats {
+	return e.queue.queue.Stats()
+}
+
+// Shutdown implements sdktrace.SpanExporter.
+func (e *PersistentSpanExporter) Shutdown(ctx context.Context) error {
+	return errors.Join(e.queue.stop(ctx), e.inner.Shutdown(ctx))
+}
+
+// PersistentMetricExporter spools metric collections to disk while the wrapped exporter fails
+// and replays them once it recovers, including after a process restart.
+type PersistentMetricExporter struct {
+	inner sdkmetric.Exporter
+	queue *persistentQueue
+}
+
+// NewPersistentMetricExporter wraps inner with a disk queue under cfg.Dir/<name>/metrics.
+func NewPersistentMetricExporter(
+	inner sdkmetric.Exporter,
+	name string,
+	cfg config.QueueConfig,
+	opts ...QueueOption,
+) (*PersistentMetricExporter, error) {
+	exp := &PersistentMetricExporter{inner: inner}
+
+	queue, err := newPersistentQueue(queueDir(cfg, name, SignalMetrics), cfg, exp.replay, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	exp.queue = queue
+
+	return exp, nil
+}
+
+// Temporality implements sdkmetric.Exporter.
+func (e *PersistentMetricExporter) Temporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	return e.inner.Temporality(kind)
+}
+
+// Aggregation implements sdkmetric.Exporter.
+func (e *PersistentMetricExporter) Aggregation(kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	return e.inner.Aggregation(kind)
+}
+
+// Export implements sdkmetric.Exporter. Each queued batch holds exactly one collection. The
+// reader reuses rm after Export returns, so the batch is encoded before returning whenever it
+// has to be queued.
+func (e *PersistentMetricExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	if rm == nil || len(rm.ScopeMetrics) == 0 {
+		return nil
+	}
+
+	items := 0
+	for _, sm := range rm.ScopeMetrics {
+		items += len(sm.Metrics)
+	}
+
+	return e.queue.submit(ctx, items,
+		func(ctx context.Context) error { return e.inner.Export(ctx, rm) },
+		func() ([]byte, error) { return json.Marshal(toOTLPMetrics(rm)) },
+	)
+}
+
+func (e *PersistentMetricExporter) replay(ctx context.Context, data []byte) error {
+	var payload otlpMetricsData
+
+	err := json.Unmarshal(data, &payload)
+	if err != nil {
+		return &queueDecodeError{err: err}
+	}
+
+	collections, err := fromOTLPMetrics(payload)
+	if err != nil {
+		return &queueDecodeError{err: err}
+	}
+
+	// Export spools one collection per batch, so a batch is delivered or retried as a whole.
+	// Replaying several collections could fail partway and resend the delivered ones.
+	if len(collections) != 1 {
+		return &queueDecodeError{err: ewrap.Newf("batch holds %d metric collections, want 1", len(collections))}
+	}
+
+	return e.inner.Export(ctx, collections[0])
+}
+
+// Stats reports the queue depth, size on disk and replay/eviction counters.
+func (e *PersistentMetricExporter) Stats() QueueStats {
+	return e.queue.queue.Stats()
+}
+
+// ForceFlush implements sdkmetric.Exporter.
+func (e *PersistentMetricExporter) ForceFlush(ctx context.Context) error {
+	return e.inner.ForceFlush(ctx)
+}
+
+// Shutdown implements sdkmetric.Exporter.
+func (e *PersistentMetricExporter) Shutdown(ctx context.Context) error {
+	return errors.Join(e.queue.stop(ctx), e.inner.Shutdown(ctx))
+}
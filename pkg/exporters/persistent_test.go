@@ -0,0 +1,357 @@
+package exporters_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/hyp3rd/observe/pkg/config"
+	"github.com/hyp3rd/observe/pkg/exporters"
+)
+
+var errBackendDown = errors.New("backend down")
+
+// flakySpanExporter fails while down is set and records spans otherwise.
+type flakySpanExporter struct {
+	*tracetest.InMemoryExporter
+
+	down atomic.Bool
+}
+
+func newFlakySpanExporter(down bool) *flakySpanExporter {
+	exp := &flakySpanExporter{InMemoryExporter: tracetest.NewInMemoryExporter()}
+	exp.down.Store(down)
+
+	return exp
+}
+
+func (e *flakySpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if e.down.Load() {
+		return errBackendDown
+	}
+
+	return e.InMemoryExporter.ExportSpans(ctx, spans)
+}
+
+func TestPersistentSpanExporterSpoolsAndReplaysOnRecovery(t *testing.T) {
+	t.Parallel()
+
+	inner := newFlakySpanExporter(true)
+	cfg := config.QueueConfig{Enabled: true, Dir: t.TempDir(), ReplayInterval: 10 * time.Millisecond}
+
+	exp, err := exporters.NewPersistentSpanExporter(inner, "collector", cfg)
+	if err != nil {
+		t.Fatalf("NewPersistentSpanExporter returned error: %v", err)
+	}
+
+	spans := recordSpans(t)
+
+	err = exp.ExportSpans(context.Background(), spans)
+	if err != nil {
+		t.Fatalf("ExportSpans should spool instead of failing, got %v", err)
+	}
+
+	if stats := exp.Stats(); stats.Depth != 1 || stats.Bytes == 0 {
+		t.Fatalf("expected one queued batch on disk, got %+v", stats)
+	}
+
+	inner.down.Store(false)
+	waitFor(t, func() bool { return len(inner.GetSpans()) == len(spans) })
+
+	replayed := inner.GetSpans()
+
+	closeAll(t, exp.Shutdown)
+
+	if stats := exp.Stats(); stats.Depth != 0 || stats.Bytes != 0 || stats.Replayed != 1 {
+		t.Fatalf("expected the batch to be replayed and removed, got %+v", stats)
+	}
+
+	for i, got := range replayed {
+		want := spans[i]
+		if got.Name != want.Name() || got.SpanContext.TraceID() != want.SpanContext().TraceID() ||
+			got.Parent.SpanID() != want.Parent().SpanID() || got.SpanKind != want.SpanKind() {
+			t.Fatalf("replayed span %d differs: got %+v", i, got)
+		}
+	}
+
+	client := replayed[0]
+	if client.Status.Code != codes.Error || len(client.Events) != 1 ||
+		client.Resource.String() != spans[0].Resource().String() {
+		t.Fatalf("expected status, events and resource to survive the round trip, got %+v", client)
+	}
+
+	if value, ok := attributeValue(client.Attributes, "peer.service"); !ok || value.AsString() != "ledger" {
+		t.Fatalf("expected peer.service attribute, got %v", client.Attributes)
+	}
+}
+
+func TestPersistentSpanExporterReplaysAfterRestart(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.QueueConfig{Enabled: true, Dir: t.TempDir(), ReplayInterval: time.Hour}
+
+	first, err := exporters.NewPersistentSpanExporter(newFlakySpanExporter(true), "collector", cfg)
+	if err != nil {
+		t.Fatalf("NewPersistentSpanExporter returned error: %v", err)
+	}
+
+	for range 2 {
+		err = first.ExportSpans(context.Background(), recordSpans(t))
+		if err != nil {
+			t.Fatalf("ExportSpans returned error: %v", err)
+		}
+	}
+
+	closeAll(t, first.Shutdown)
+
+	inner := newFlakySpanExporter(false)
+
+	second, err := exporters.NewPersistentSpanExporter(inner, "collector", cfg)
+	if err != nil {
+		t.Fatalf("NewPersistentSpanExporter returned error: %v", err)
+	}
+
+	// Replay on startup must not wait for the (hour-long) interval.
+	waitFor(t, func() bool { return len(inner.GetSpans()) == 4 })
+	closeAll(t, second.Shutdown)
+
+	if stats := second.Stats(); stats.Depth != 0 || stats.Replayed != 2 {
+		t.Fatalf("expected both batches replayed after restart, got %+v", stats)
+	}
+}
+
+func TestPersistentSpanExporterEvictionPolicies(t *testing.T) {
+	t.Parallel()
+
+	payload := strings.Repeat("x", 600*1024)
+
+	for _, tc := range []struct {
+		policy    string
+		keepFirst bool
+	}{
+		{policy: exporters.QueueDropOldest, keepFirst: false},
+		{policy: exporters.QueueDropNewest, keepFirst: true},
+	} {
+		t.Run(tc.policy, func(t *testing.T) {
+			t.Parallel()
+
+			var dropped atomic.Int64
+
+			cfg := config.QueueConfig{
+				Enabled:        true,
+				Dir:            t.TempDir(),
+				MaxSizeMB:      1,
+				Eviction:       tc.policy,
+				ReplayInterval: time.Hour,
+			}
+
+			exp, err := exporters.NewPersistentSpanExporter(newFlakySpanExporter(true), "collector", cfg,
+				exporters.WithDropHandler(func(items int) { dropped.Add(int64(items)) }),
+			)
+			if err != nil {
+				t.Fatalf("NewPersistentSpanExporter returned error: %v", err)
+			}
+
+			first, second := largeSpan(payload), largeSpan(payload)
+			for _, batch := range [][]sdktrace.ReadOnlySpan{first, second} {
+				err = exp.ExportSpans(context.Background(), batch)
+				if err != nil {
+					t.Fatalf("ExportSpans returned error: %v", err)
+				}
+			}
+
+			if stats := exp.Stats(); stats.Depth != 1 || stats.Evicted != 1 || dropped.Load() != 1 {
+				t.Fatalf("expected one batch evicted and reported, got %+v (dropped %d)", stats, dropped.Load())
+			}
+
+			closeAll(t, exp.Shutdown)
+
+			// Replay the surviving batch through a fresh exporter to see which one was kept.
+			inner := newFlakySpanExporter(false)
+
+			restarted, err := exporters.NewPersistentSpanExporter(inner, "collector", cfg)
+			if err != nil {
+				t.Fatalf("NewPersistentSpanExporter returned error: %v", err)
+			}
+
+			waitFor(t, func() bool { return len(inner.GetSpans()) == 1 })
+
+			got := inner.GetSpans()[0].SpanContext.SpanID()
+
+			closeAll(t, restarted.Shutdown)
+
+			want := second[0].SpanContext().SpanID()
+			if tc.keepFirst {
+				want = first[0].SpanContext().SpanID()
+			}
+
+			if got != want {
+				t.Fatalf("%s kept the wrong batch: got span %s, want %s", tc.policy, got, want)
+			}
+		})
+	}
+}
+
+// flakyMetricExporter fails while down is set and keeps the last collection otherwise.
+type flakyMetricExporter struct {
+	sdkmetric.Exporter
+
+	down     atomic.Bool
+	exported atomic.Pointer[metricdata.ResourceMetrics]
+}
+
+func (e *flakyMetricExporter) Export(_ context.Context, rm *metricdata.ResourceMetrics) error {
+	if e.down.Load() {
+		return errBackendDown
+	}
+
+	e.exported.Store(rm)
+
+	return nil
+}
+
+func (*flakyMetricExporter) Shutdown(context.Context) error { return nil }
+
+func TestPersistentMetricExporterReplaysCollections(t *testing.T) {
+	t.Parallel()
+
+	inner := &flakyMetricExporter{}
+	inner.down.Store(true)
+
+	cfg := config.QueueConfig{Enabled: true, Dir: t.TempDir(), ReplayInterval: 10 * time.Millisecond}
+
+	exp, err := exporters.NewPersistentMetricExporter(inner, "collector", cfg)
+	if err != nil {
+		t.Fatalf("NewPersistentMetricExporter returned error: %v", err)
+	}
+
+	err = exp.Export(context.Background(), collectCounter(t))
+	if err != nil {
+		t.Fatalf("Export should spool instead of failing, got %v", err)
+	}
+
+	inner.down.Store(false)
+	waitFor(t, func() bool { return inner.exported.Load() != nil })
+	closeAll(t, exp.Shutdown)
+
+	metrics := inner.exported.Load().ScopeMetrics[0].Metrics
+	if len(metrics) != 1 || metrics[0].Name != "orders.created" {
+		t.Fatalf("unexpected replayed metrics %+v", metrics)
+	}
+
+	sum, ok := metrics[0].Data.(metricdata.Sum[int64])
+	if !ok || !sum.IsMonotonic || sum.Temporality != metricdata.CumulativeTemporality ||
+		len(sum.DataPoints) != 1 || sum.DataPoints[0].Value != 3 {
+		t.Fatalf("expected the int64 counter to survive the round trip, got %#v", metrics[0].Data)
+	}
+}
+
+func TestPersistentMetricExporterDiscardsBatchesWithSeveralCollections(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.QueueConfig{Enabled: true, Dir: t.TempDir(), ReplayInterval: time.Hour}
+
+	down := &flakyMetricExporter{}
+	down.down.Store(true)
+
+	first, err := exporters.NewPersistentMetricExporter(down, "collector", cfg)
+	if err != nil {
+		t.Fatalf("NewPersistentMetricExporter returned error: %v", err)
+	}
+
+	err = first.Export(context.Background(), collectCounter(t))
+	if err != nil {
+		t.Fatalf("Export should spool instead of failing, got %v", err)
+	}
+
+	closeAll(t, first.Shutdown)
+
+	// A batch carrying two collections could only be replayed partially; it must not be sent.
+	batches, err := filepath.Glob(filepath.Join(cfg.Dir, "collector", "metrics", "*"))
+	if err != nil || len(batches) != 1 {
+		t.Fatalf("expected one spooled batch, got %v (%v)", batches, err)
+	}
+
+	duplicateResourceMetrics(t, batches[0])
+
+	var dropped atomic.Int64
+
+	inner := &flakyMetricExporter{}
+
+	second, err := exporters.NewPersistentMetricExporter(inner, "collector", cfg,
+		exporters.WithDropHandler(func(items int) { dropped.Add(int64(items)) }))
+	if err != nil {
+		t.Fatalf("NewPersistentMetricExporter returned error: %v", err)
+	}
+
+	waitFor(t, func() bool { return second.Stats().Depth == 0 })
+	closeAll(t, second.Shutdown)
+
+	if inner.exported.Load() != nil || dropped.Load() != 1 {
+		t.Fatalf("expected the batch to be discarded unsent, dropped %d", dropped.Load())
+	}
+}
+
+func duplicateResourceMetrics(t *testing.T, path string) {
+	t.Helper()
+
+	data, err := os.ReadFile(path) //nolint:gosec // path is the test's own queue file.
+	if err != nil {
+		t.Fatalf("read batch: %v", err)
+	}
+
+	var payload map[string][]json.RawMessage
+
+	err = json.Unmarshal(data, &payload)
+	if err != nil {
+		t.Fatalf("decode batch: %v", err)
+	}
+
+	payload["resourceMetrics"] = append(payload["resourceMetrics"], payload["resourceMetrics"]...)
+
+	data, err = json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("encode batch: %v", err)
+	}
+
+	err = os.WriteFile(path, data, 0o600)
+	if err != nil {
+		t.Fatalf("write batch: %v", err)
+	}
+}
+
+func attributeValue(attrs []attribute.KeyValue, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range attrs {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+
+	return attribute.Value{}, false
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before timeout")
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,69 @@
+package exporters
+
+import (
+	"net/http"
+
+	"github.com/hyp3rd/ewrap"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	"github.com/hyp3rd/observe/pkg/config"
+)
+
+// DefaultPrometheusPath is the scrape path used when none is configured.
+const DefaultPrometheusPath = "/metrics"
+
+// PrometheusReader is a pull-based metric reader paired with the HTTP handler that serves it.
+type PrometheusReader struct {
+	sdkmetric.Reader
+
+	// Handler serves the exposition format, negotiating protobuf for native histograms.
+	Handler http.Handler
+	// Path is the configured scrape path.
+	Path string
+}
+
+// NewPrometheusReader builds a Prometheus reader backed by a private registry, so scrapes
+// only expose telemetry recorded through the runtime's meter provider. Metric names and units
+// follow the OpenTelemetry-to-Prometheus translation rules and resource attributes are exposed
+// as target_info.
+func NewPrometheusReader(cfg config.PrometheusConfig) (*PrometheusReader, error) {
+	registry := prometheus.NewRegistry()
+
+	opts := []otelprom.Option{otelprom.WithRegisterer(registry)}
+	if cfg.WithoutUnits {
+		opts = append(opts, otelprom.WithoutUnits())
+	}
+
+	if cfg.NativeHistograms {
+		opts = append(opts, otelprom.WithAggregationSelector(nativeHistogramSelector))
+	}
+
+	exporter, err := otelprom.New(opts...)
+	if err != nil {
+		return nil, ewrap.Wrap(err, "create prometheus exporter")
+	}
+
+	path := cfg.Path
+	if path == "" {
+		path = DefaultPrometheusPath
+	}
+
+	return &PrometheusReader{
+		Reader:  exporter,
+		Handler: promhttp.HandlerFor(registry, promhttp.HandlerOpts{EnableOpenMetrics: true}),
+		Path:    path,
+	}, nil
+}
+
+// nativeHistogramSelector maps histogram instruments to exponential buckets, which the
+// Prometheus exporter exposes as native histograms.
+func nativeHistogramSelector(kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	if kind == sdkmetric.InstrumentKindHistogram {
+		return sdkmetric.AggregationBase2ExponentialHistogram{MaxSize: 160, MaxScale: 20}
+	}
+
+	return sdkmetric.DefaultAggregationSelector(kind)
+}
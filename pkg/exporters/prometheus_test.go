@@ -0,0 +1,71 @@
+package exporters_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+
+	"github.com/hyp3rd/observe/pkg/config"
+	"github.com/hyp3rd/observe/pkg/exporters"
+)
+
+func TestPrometheusReaderServesTranslatedMetrics(t *testing.T) {
+	t.Parallel()
+
+	reader, err := exporters.NewPrometheusReader(config.PrometheusConfig{Enabled: true})
+	if err != nil {
+		t.Fatalf("NewPrometheusReader returned error: %v", err)
+	}
+
+	if reader.Path != exporters.DefaultPrometheusPath {
+		t.Fatalf("expected default path, got %q", reader.Path)
+	}
+
+	mp := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(reader),
+		sdkmetric.WithResource(resource.NewSchemaless(semconv.ServiceName("payments"))),
+	)
+
+	counter, err := mp.Meter("test").Float64Counter("http.server.request.duration", metric.WithUnit("s"))
+	if err != nil {
+		t.Fatalf("counter: %v", err)
+	}
+
+	counter.Add(context.Background(), 1.5)
+
+	body := scrape(t, reader.Handler)
+
+	if !strings.Contains(body, "# TYPE http_server_request_duration_seconds_total counter") {
+		t.Fatalf("expected translated metric name with unit suffix, got:\n%s", body)
+	}
+
+	if !strings.Contains(body, `target_info{service_name="payments"} 1`) {
+		t.Fatalf("expected target_info with resource attributes, got:\n%s", body)
+	}
+}
+
+func scrape(t *testing.T, handler http.Handler) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, exporters.DefaultPrometheusPath, nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 from scrape, got %d", rec.Code)
+	}
+
+	data, err := io.ReadAll(rec.Body)
+	if err != nil {
+		t.Fatalf("read scrape body: %v", err)
+	}
+
+	return string(data)
+}
@@ -0,0 +1,315 @@
+package exporters
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyp3rd/ewrap"
+
+	"github.com/hyp3rd/observe/pkg/config"
+)
+
+const (
+	// QueueDropOldest evicts the oldest queued batches to make room for new ones.
+	QueueDropOldest = "drop_oldest"
+	// QueueDropNewest rejects incoming batches while the queue is full.
+	QueueDropNewest = "drop_newest"
+
+	defaultQueueSizeMB     = 100
+	defaultReplayInterval  = 5 * time.Second
+	queueFileExt           = ".json"
+	queueTempExt           = ".tmp"
+	queueNameSeparator     = "-"
+	queueNameParts         = 3
+	queueTimestampDigits   = 20
+	queueSequenceDigits    = 10
+	queueItemsPartPosition = 2
+)
+
+// QueueStats reports the state of a persistent queue. Depth, Replayed and Evicted count batches.
+type QueueStats struct {
+	Depth    int64
+	Bytes    int64
+	Replayed int64
+	Evicted  int64
+}
+
+// queueEntry is one batch persisted as its own file.
+type queueEntry struct {
+	path  string
+	size  int64
+	items int
+}
+
+// diskQueue stores batches as files named <unixnano>-<seq>-<items>.json so that a lexical
+// sort yields FIFO order and the item count survives restarts without decoding payloads.
+type diskQueue struct {
+	dir        string
+	maxBytes   int64
+	dropNewest bool
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries []queueEntry
+	bytes   int64
+	seq     uint64
+	// inflight is the batch being replayed; eviction skips it so it is never both delivered and dropped.
+	inflight string
+
+	replayed atomic.Int64
+	evicted  atomic.Int64
+}
+
+func openDiskQueue(dir string, cfg config.QueueConfig) (*diskQueue, error) {
+	err := os.MkdirAll(dir, dirPermissions)
+	if err != nil {
+		return nil, ewrap.Wrapf(err, "create queue directory %s", dir)
+	}
+
+	sizeMB := cfg.MaxSizeMB
+	if sizeMB <= 0 {
+		sizeMB = defaultQueueSizeMB
+	}
+
+	q := &diskQueue{
+		dir:        dir,
+		maxBytes:   int64(sizeMB) * bytesPerMegabyte,
+		dropNewest: strings.EqualFold(strings.TrimSpace(cfg.Eviction), QueueDropNewest),
+		now:        time.Now,
+	}
+
+	err = q.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+// load indexes batches left behind by a previous process so they are replayed first.
+func (q *diskQueue) load() error {
+	files, err := os.ReadDir(q.dir)
+	if err != nil {
+		return ewrap.Wrapf(err, "list queue directory %s", q.dir)
+	}
+
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() {
+			continue
+		}
+
+		// Interrupted writes never reached their final name.
+		if strings.HasSuffix(name, queueTempExt) {
+			_ = os.Remove(filepath.Join(q.dir, name))
+
+			continue
+		}
+
+		items, ok := parseQueueName(name)
+		if !ok {
+			continue
+		}
+
+		info, err := file.Info()
+		if err != nil {
+			return ewrap.Wrapf(err, "stat queued batch %s", name)
+		}
+
+		q.entries = append(q.entries, queueEntry{path: filepath.Join(q.dir, name), size: info.Size(), items: items})
+		q.bytes += info.Size()
+	}
+
+	slices.SortFunc(q.entries, func(a, b queueEntry) int { return strings.Compare(a.path, b.path) })
+
+	return nil
+}
+
+// push persists a batch, evicting according to the policy when the queue is full.
+// It returns the number of items discarded to make room (or the batch itself under drop_newest).
+func (q *diskQueue) push(data []byte, items int) (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	size := int64(len(data))
+	if size > q.maxBytes || (q.dropNewest && q.bytes+size > q.maxBytes) {
+		q.evicted.Add(1)
+
+		return items, nil
+	}
+
+	dropped := 0
+
+	for q.bytes+size > q.maxBytes {
+		idx := slices.IndexFunc(q.entries, func(e queueEntry) bool { return e.path != q.inflight })
+		if idx < 0 {
+			// Only the batch under replay is left; the incoming one has to go.
+			q.evicted.Add(1)
+
+			return dropped + items, nil
+		}
+
+		oldest := q.entries[idx]
+
+		err := q.removeLocked(oldest)
+		if err != nil {
+			return dropped, err
+		}
+
+		q.evicted.Add(1)
+
+		dropped += oldest.items
+	}
+
+	q.seq++
+
+	name := fmt.Sprintf("%0*d%s%0*d%s%d%s",
+		queueTimestampDigits, q.now().UnixNano(), queueNameSeparator,
+		queueSequenceDigits, q.seq, queueNameSeparator, items, queueFileExt)
+	path := filepath.Join(q.dir, name)
+
+	err := writeFileAtomic(path, data)
+	if err != nil {
+		return dropped, err
+	}
+
+	q.entries = append(q.entries, queueEntry{path: path, size: size, items: items})
+	q.bytes += size
+
+	return dropped, nil
+}
+
+// next returns the oldest queued batch without removing it and marks it in flight until
+// it is acked, discarded or released.
+func (q *diskQueue) next() (queueEntry, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.entries) == 0 {
+		return queueEntry{}, false
+	}
+
+	q.inflight = q.entries[0].path
+
+	return q.entries[0], true
+}
+
+// release keeps a batch queued after a failed replay.
+func (q *diskQueue) release() {
+	q.mu.Lock()
+	q.inflight = ""
+	q.mu.Unlock()
+}
+
+func (q *diskQueue) read(entry queueEntry) ([]byte, error) {
+	data, err := os.ReadFile(entry.path)
+	if err != nil {
+		return nil, ewrap.Wrapf(err, "read queued batch %s", entry.path)
+	}
+
+	return data, nil
+}
+
+// ack removes a batch after it was replayed successfully.
+func (q *diskQueue) ack(entry queueEntry) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.replayed.Add(1)
+	q.inflight = ""
+
+	return q.removeLocked(entry)
+}
+
+// discard removes a batch that can never be replayed, counting it as evicted.
+func (q *diskQueue) discard(entry queueEntry) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.evicted.Add(1)
+	q.inflight = ""
+
+	return q.removeLocked(entry)
+}
+
+func (q *diskQueue) empty() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.entries) == 0
+}
+
+// Stats returns a point-in-time view of the queue.
+func (q *diskQueue) Stats() QueueStats {
+	q.mu.Lock()
+	depth, bytes := int64(len(q.entries)), q.bytes
+	q.mu.Unlock()
+
+	return QueueStats{
+		Depth:    depth,
+		Bytes:    bytes,
+		Replayed: q.replayed.Load(),
+		Evicted:  q.evicted.Load(),
+	}
+}
+
+func (q *diskQueue) removeLocked(entry queueEntry) error {
+	idx := slices.IndexFunc(q.entries, func(e queueEntry) bool { return e.path == entry.path })
+	if idx < 0 {
+		return nil
+	}
+
+	q.entries = slices.Delete(q.entries, idx, idx+1)
+	q.bytes -= entry.size
+
+	err := os.Remove(entry.path)
+	if err != nil && !os.IsNotExist(err) {
+		return ewrap.Wrapf(err, "remove queued batch %s", entry.path)
+	}
+
+	return nil
+}
+
+func parseQueueName(name string) (int, bool) {
+	if !strings.HasSuffix(name, queueFileExt) {
+		return 0, false
+	}
+
+	parts := strings.Split(strings.TrimSuffix(name, queueFileExt), queueNameSeparator)
+	if len(parts) != queueNameParts {
+		return 0, false
+	}
+
+	items, err := strconv.Atoi(parts[queueItemsPartPosition])
+	if err != nil {
+		return 0, false
+	}
+
+	return items, true
+}
+
+// writeFileAtomic writes through a temporary file so a crash never leaves a truncated batch.
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + queueTempExt
+
+	err := os.WriteFile(tmp, data, filePermissions)
+	if err != nil {
+		return ewrap.Wrapf(err, "write queued batch %s", path)
+	}
+
+	err = os.Rename(tmp, path)
+	if err != nil {
+		_ = os.Remove(tmp)
+
+		return ewrap.Wrapf(err, "commit queued batch %s", path)
+	}
+
+	return nil
+}
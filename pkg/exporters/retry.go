@@ -0,0 +1,93 @@
+package exporters
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hyp3rd/ewrap"
+
+	"github.com/hyp3rd/observe/pkg/config"
+)
+
+const (
+	defaultRetryInitialInterval = 500 * time.Millisecond
+	defaultRetryMaxInterval     = 5 * time.Second
+	defaultRetryMaxElapsedTime  = time.Minute
+)
+
+// retryableError marks a failure worth retrying, optionally with a server-requested delay.
+type retryableError struct {
+	err   error
+	delay time.Duration
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+func retryable(err error, delay time.Duration) error {
+	return &retryableError{err: err, delay: delay}
+}
+
+// withRetry runs fn until it succeeds, fails permanently, or the retry budget in cfg is spent.
+// Only errors wrapped by retryable are retried; intervals back off exponentially.
+func withRetry(ctx context.Context, cfg config.RetryConfig, fn func(context.Context) error) error {
+	err := fn(ctx)
+	if err == nil || !cfg.Enabled {
+		return unwrapRetryable(err)
+	}
+
+	interval := durationOr(cfg.InitialInterval, defaultRetryInitialInterval)
+	maxInterval := durationOr(cfg.MaxInterval, defaultRetryMaxInterval)
+	deadline := time.Now().Add(durationOr(cfg.MaxElapsedTime, defaultRetryMaxElapsedTime))
+
+	for {
+		var retry *retryableError
+		if !errors.As(err, &retry) {
+			return err
+		}
+
+		wait := max(interval, retry.delay)
+		if time.Now().Add(wait).After(deadline) {
+			return ewrap.Wrap(retry.err, "retry budget exhausted")
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return errors.Join(retry.err, ctx.Err())
+		case <-timer.C:
+		}
+
+		err = fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		interval = min(interval*2, maxInterval)
+	}
+}
+
+func unwrapRetryable(err error) error {
+	var retry *retryableError
+	if errors.As(err, &retry) {
+		return retry.err
+	}
+
+	return err
+}
+
+func durationOr(value, fallback time.Duration) time.Duration {
+	if value > 0 {
+		return value
+	}
+
+	return fallback
+}
@@ -0,0 +1,330 @@
+package exporters
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyp3rd/ewrap"
+	"go.opentelemetry.io/otel"
+
+	"github.com/hyp3rd/observe/pkg/config"
+)
+
+const (
+	bytesPerMegabyte = 1 << 20
+	rotationLayout   = "20060102T150405.000000000Z"
+	filePermissions  = 0o600
+	dirPermissions   = 0o750
+)
+
+// rotatingFile appends lines to a file, rotating it by size and age. Rotated files are
+// renamed with a UTC timestamp suffix, optionally gzipped, and pruned to the retention count.
+type rotatingFile struct {
+	cfg    config.FileConfig
+	now    func() time.Time
+	remove func(name string) error
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	refs     int
+}
+
+func newRotatingFile(cfg config.FileConfig) (*rotatingFile, error) {
+	if cfg.Path == "" {
+		return nil, ewrap.New("file path is required")
+	}
+
+	rf := &rotatingFile{cfg: cfg, now: time.Now, remove: os.Remove}
+
+	err := rf.open()
+	if err != nil {
+		return nil, err
+	}
+
+	return rf, nil
+}
+
+// WriteLine appends data followed by a newline, rotating first when limits are reached.
+func (rf *rotatingFile) WriteLine(data []byte) error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.file == nil {
+		return ewrap.Newf("file %s is closed", rf.cfg.Path)
+	}
+
+	var rotateErr error
+	if rf.shouldRotate(int64(len(data) + 1)) {
+		rotateErr = rf.rotate()
+		if rf.file == nil {
+			return rotateErr
+		}
+	}
+
+	n, err := rf.file.Write(append(data, '\n'))
+	rf.size += int64(n)
+
+	if err != nil {
+		return errors.Join(rotateErr, ewrap.Wrapf(err, "write %s", rf.cfg.Path))
+	}
+
+	return rotateErr
+}
+
+// Close flushes and closes the active file.
+func (rf *rotatingFile) Close() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.file == nil {
+		return nil
+	}
+
+	err := rf.file.Close()
+	rf.file = nil
+
+	if err != nil {
+		return ewrap.Wrapf(err, "close %s", rf.cfg.Path)
+	}
+
+	return nil
+}
+
+func (rf *rotatingFile) shouldRotate(incoming int64) bool {
+	if rf.size == 0 {
+		return false
+	}
+
+	if rf.cfg.MaxSizeMB > 0 && rf.size+incoming > int64(rf.cfg.MaxSizeMB)*bytesPerMegabyte {
+		return true
+	}
+
+	return rf.cfg.MaxAge > 0 && rf.now().Sub(rf.openedAt) >= rf.cfg.MaxAge
+}
+
+func (rf *rotatingFile) open() error {
+	err := os.MkdirAll(filepath.Dir(rf.cfg.Path), dirPermissions)
+	if err != nil {
+		return ewrap.Wrapf(err, "create directory for %s", rf.cfg.Path)
+	}
+
+	file, err := os.OpenFile(rf.cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, filePermissions)
+	if err != nil {
+		return ewrap.Wrapf(err, "open %s", rf.cfg.Path)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+
+		return ewrap.Wrapf(err, "stat %s", rf.cfg.Path)
+	}
+
+	rf.file = file
+	rf.size = info.Size()
+	rf.openedAt = rf.now()
+
+	return nil
+}
+
+// rotate moves the active file aside and reopens the active path, which it does even when
+// the move fails so the exporter keeps writing. Compressing and pruning the rotated files is
+// housekeeping: their failures go to the OpenTelemetry error handler.
+func (rf *rotatingFile) rotate() error {
+	err := rf.file.Close()
+	rf.file = nil
+
+	if err != nil {
+		err = ewrap.Wrapf(err, "close %s", rf.cfg.Path)
+	} else {
+		err = rf.archive()
+	}
+
+	openErr := rf.open()
+	if openErr != nil {
+		return errors.Join(err, openErr)
+	}
+
+	return err
+}
+
+// archive renames the closed active file to its backup name, then compresses and prunes the backups.
+func (rf *rotatingFile) archive() error {
+	backup := rf.backupName(rf.now())
+
+	err := os.Rename(rf.cfg.Path, backup)
+	if err != nil {
+		return ewrap.Wrapf(err, "rotate %s", rf.cfg.Path)
+	}
+
+	if rf.cfg.Compress {
+		err = gzipFile(backup)
+		if err != nil {
+			otel.Handle(err)
+		}
+	}
+
+	err = rf.prune()
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return nil
+}
+
+// backupName inserts the rotation timestamp before the extension: telemetry.jsonl -> telemetry-<ts>.jsonl.
+func (rf *rotatingFile) backupName(at time.Time) string {
+	ext := filepath.Ext(rf.cfg.Path)
+	base := strings.TrimSuffix(rf.cfg.Path, ext)
+
+	return base + "-" + at.UTC().Format(rotationLayout) + ext
+}
+
+func (rf *rotatingFile) prune() error {
+	if rf.cfg.MaxBackups <= 0 {
+		return nil
+	}
+
+	backups, err := rf.backups()
+	if err != nil {
+		return err
+	}
+
+	for len(backups) > rf.cfg.MaxBackups {
+		err = rf.remove(backups[0])
+		if err != nil {
+			return ewrap.Wrapf(err, "remove rotated file %s", backups[0])
+		}
+
+		backups = backups[1:]
+	}
+
+	return nil
+}
+
+// backups lists rotated files oldest first; the timestamp suffix sorts lexically.
+func (rf *rotatingFile) backups() ([]string, error) {
+	ext := filepath.Ext(rf.cfg.Path)
+	prefix := filepath.Base(strings.TrimSuffix(rf.cfg.Path, ext)) + "-"
+	dir := filepath.Dir(rf.cfg.Path)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, ewrap.Wrapf(err, "list %s", dir)
+	}
+
+	var backups []string
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		stamp := strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(name, prefix), ".gz"), ext)
+
+		_, err := time.Parse(rotationLayout, stamp)
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, filepath.Join(dir, name))
+	}
+
+	slices.Sort(backups)
+
+	return backups, nil
+}
+
+func gzipFile(path string) error {
+	src, err := os.Open(path) //nolint:gosec // path is the exporter's own rotated file.
+	if err != nil {
+		return ewrap.Wrapf(err, "open %s", path)
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, filePermissions)
+	if err != nil {
+		return ewrap.Wrapf(err, "create %s.gz", path)
+	}
+
+	gz := gzip.NewWriter(dst)
+
+	_, err = io.Copy(gz, src)
+	if err == nil {
+		err = gz.Close()
+	}
+
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return ewrap.Wrapf(err, "compress %s", path)
+	}
+
+	err = os.Remove(path)
+	if err != nil {
+		return ewrap.Wrapf(err, "remove %s", path)
+	}
+
+	return nil
+}
+
+var (
+	sharedFilesMu sync.Mutex
+	sharedFiles   = map[string]*rotatingFile{}
+)
+
+// acquireFile returns the rotating writer for cfg.Path, shared by every signal exporting to it.
+func acquireFile(cfg config.FileConfig) (*rotatingFile, error) {
+	key := filepath.Clean(cfg.Path)
+
+	sharedFilesMu.Lock()
+	defer sharedFilesMu.Unlock()
+
+	if rf, ok := sharedFiles[key]; ok {
+		rf.refs++
+
+		return rf, nil
+	}
+
+	rf, err := newRotatingFile(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	rf.refs = 1
+	sharedFiles[key] = rf
+
+	return rf, nil
+}
+
+// releaseFile drops a reference and closes the writer once no exporter uses it.
+func releaseFile(rf *rotatingFile) error {
+	key := filepath.Clean(rf.cfg.Path)
+
+	sharedFilesMu.Lock()
+	rf.refs--
+	last := rf.refs <= 0
+
+	if last && sharedFiles[key] == rf {
+		delete(sharedFiles, key)
+	}
+	sharedFilesMu.Unlock()
+
+	if !last {
+		return nil
+	}
+
+	return rf.Close()
+}
@@ -0,0 +1,103 @@
+package exporters
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyp3rd/ewrap"
+
+	"github.com/hyp3rd/observe/pkg/config"
+)
+
+func TestRotatingFileKeepsWritingWhenPruningFails(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	rf, err := newRotatingFile(config.FileConfig{Path: filepath.Join(dir, "spans.jsonl"), MaxSizeMB: 1, MaxBackups: 1})
+	if err != nil {
+		t.Fatalf("newRotatingFile: %v", err)
+	}
+
+	defer rf.Close()
+
+	now := time.Unix(0, 0)
+	rf.now = func() time.Time {
+		now = now.Add(time.Second)
+
+		return now
+	}
+	rf.remove = func(name string) error { return ewrap.Newf("remove %s: read-only filesystem", name) }
+
+	line := []byte(strings.Repeat("x", 600*1024))
+	for range 4 {
+		err = rf.WriteLine(line)
+		if err != nil {
+			t.Fatalf("expected pruning failures not to fail writes, got %v", err)
+		}
+	}
+
+	backups, err := rf.backups()
+	if err != nil {
+		t.Fatalf("backups: %v", err)
+	}
+
+	if len(backups) != 3 {
+		t.Fatalf("expected every rotation to keep its backup, got %v", backups)
+	}
+}
+
+func TestRotatingFileReopensWhenRotationFails(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	rf, err := newRotatingFile(config.FileConfig{Path: filepath.Join(dir, "spans.jsonl"), MaxSizeMB: 1})
+	if err != nil {
+		t.Fatalf("newRotatingFile: %v", err)
+	}
+
+	defer rf.Close()
+
+	now := time.Unix(0, 0)
+	rf.now = func() time.Time { return now }
+
+	// A non-empty directory at the backup name makes the rename fail.
+	blocker := rf.backupName(now)
+
+	err = os.MkdirAll(filepath.Join(blocker, "occupied"), 0o750)
+	if err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	line := []byte(strings.Repeat("x", 600*1024))
+
+	err = rf.WriteLine(line)
+	if err != nil {
+		t.Fatalf("WriteLine: %v", err)
+	}
+
+	err = rf.WriteLine(line)
+	if err == nil {
+		t.Fatal("expected the failed rotation to be reported")
+	}
+
+	now = now.Add(time.Second)
+
+	err = rf.WriteLine(line)
+	if err != nil {
+		t.Fatalf("expected the exporter to recover once rotation succeeds, got %v", err)
+	}
+
+	info, err := os.Stat(rf.cfg.Path)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+
+	if info.Size() != int64(len(line)+1) {
+		t.Fatalf("expected the reopened file to hold the latest line, got %d bytes", info.Size())
+	}
+}
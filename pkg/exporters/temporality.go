@@ -0,0 +1,59 @@
+package exporters
+
+import (
+	"strings"
+
+	"github.com/hyp3rd/ewrap"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// Temporality preferences accepted by TemporalitySelector, named after the values of
+// OTEL_EXPORTER_OTLP_METRICS_TEMPORALITY_PREFERENCE.
+const (
+	TemporalityCumulative = "cumulative"
+	TemporalityDelta      = "delta"
+	TemporalityLowMemory  = "lowmemory"
+)
+
+// TemporalitySelector maps a temporality preference to a selector. An empty preference
+// selects cumulative; "low-memory" and "low_memory" are accepted as spellings of lowmemory.
+//
+// Delta reports counters, histograms and observable counters as deltas; low-memory does so
+// only for synchronous counters and histograms. Up-down counters and gauges stay cumulative.
+func TemporalitySelector(preference string) (sdkmetric.TemporalitySelector, error) {
+	switch normalizeTemporality(preference) {
+	case "", TemporalityCumulative:
+		return sdkmetric.DefaultTemporalitySelector, nil
+	case TemporalityDelta:
+		return deltaTemporality, nil
+	case TemporalityLowMemory:
+		return lowMemoryTemporality, nil
+	default:
+		return nil, ewrap.Newf("unsupported metric temporality %q", preference)
+	}
+}
+
+func normalizeTemporality(preference string) string {
+	return strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(strings.TrimSpace(preference)))
+}
+
+func deltaTemporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	switch kind {
+	case sdkmetric.InstrumentKindCounter,
+		sdkmetric.InstrumentKindHistogram,
+		sdkmetric.InstrumentKindObservableCounter:
+		return metricdata.DeltaTemporality
+	default:
+		return metricdata.CumulativeTemporality
+	}
+}
+
+func lowMemoryTemporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	switch kind {
+	case sdkmetric.InstrumentKindCounter, sdkmetric.InstrumentKindHistogram:
+		return metricdata.DeltaTemporality
+	default:
+		return metricdata.CumulativeTemporality
+	}
+}
@@ -0,0 +1,376 @@
+package exporters
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyp3rd/ewrap"
+
+	"github.com/hyp3rd/observe/pkg/config"
+)
+
+// ErrTLSNotEnabled is returned when TLS configuration is incomplete.
+var ErrTLSNotEnabled = ewrap.New("tls is not enabled").WithContext(
+	&ewrap.ErrorContext{
+		Severity: ewrap.SeverityError,
+		Type:     ewrap.ErrorTypeConfiguration,
+	},
+)
+
+// CertificateStatus describes the certificates currently served for a TLS block.
+// CertNotAfter and CANotAfter are zero when no client certificate or CA file is configured;
+// CANotAfter is the earliest expiry in the bundle.
+type CertificateStatus struct {
+	CertNotAfter time.Time
+	CANotAfter   time.Time
+	Reloads      int64
+	LastReload   time.Time
+	LastError    string
+}
+
+// tlsConfigFrom builds a tls.Config from the provided TLSConfig. The CA bundle and client
+// certificate are re-read when their files change, so rotated certificates are picked up on
+// the next handshake without rebuilding the exporter. endpoint names the server for
+// verification when the dialer sends no SNI, as with IP addresses.
+func tlsConfigFrom(cfg config.TLSConfig, endpoint string) (*tls.Config, error) {
+	if cfg.CAFile == "" && cfg.CertFile == "" && cfg.KeyFile == "" && !cfg.Insecure {
+		return nil, ErrTLSNotEnabled
+	}
+
+	if (cfg.CertFile != "") != (cfg.KeyFile != "") {
+		return nil, ewrap.New("tls cert_file and key_file must both be set")
+	}
+
+	tlsCfg := &tls.Config{
+		//nolint:gosec // allow insecure skip verify via config.
+		InsecureSkipVerify: cfg.Insecure,
+	}
+
+	if cfg.CAFile == "" && cfg.CertFile == "" {
+		return tlsCfg, nil
+	}
+
+	source, err := certSourceFor(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.CertFile != "" {
+		tlsCfg.GetClientCertificate = source.clientCertificate
+	}
+
+	if cfg.CAFile != "" && !cfg.Insecure {
+		// The handshake skips the static verification so VerifyConnection can check the
+		// chain against the current CA bundle.
+		tlsCfg.InsecureSkipVerify = true //nolint:gosec // verified in VerifyConnection.
+		tlsCfg.VerifyConnection = source.verifier(endpointHost(endpoint))
+	}
+
+	return tlsCfg, nil
+}
+
+// TLSCertificateStatus reports the certificates loaded for cfg by an exporter, re-reading
+// any file that changed. ok is false when no exporter loaded certificates for cfg.
+func TLSCertificateStatus(cfg config.TLSConfig) (CertificateStatus, bool) {
+	certSourcesMu.Lock()
+	source, ok := certSources[certSourceKey(cfg)]
+	certSourcesMu.Unlock()
+
+	if !ok {
+		return CertificateStatus{}, false
+	}
+
+	_ = source.refresh()
+
+	return source.status(), true
+}
+
+var (
+	certSourcesMu sync.Mutex
+	certSources   = map[string]*certSource{}
+)
+
+// certSourceFor returns the shared source for cfg's files, loading them on first use.
+// Exporters configured with the same files share one source across rebuilds.
+func certSourceFor(cfg config.TLSConfig) (*certSource, error) {
+	key := certSourceKey(cfg)
+
+	certSourcesMu.Lock()
+	defer certSourcesMu.Unlock()
+
+	if source, ok := certSources[key]; ok {
+		return source, source.refresh()
+	}
+
+	source := &certSource{caFile: cfg.CAFile, certFile: cfg.CertFile, keyFile: cfg.KeyFile}
+
+	err := source.refresh()
+	if err != nil {
+		return nil, err
+	}
+
+	certSources[key] = source
+
+	return source, nil
+}
+
+func certSourceKey(cfg config.TLSConfig) string {
+	return cfg.CAFile + "\x00" + cfg.CertFile + "\x00" + cfg.KeyFile
+}
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func stampOf(path string) (fileStamp, error) {
+	if path == "" {
+		return fileStamp{}, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}, ewrap.Wrapf(err, "stat %s", path)
+	}
+
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}, nil
+}
+
+// certSource holds the current CA pool and client certificate for a set of TLS files.
+// refresh compares the files' size and modification time and reloads them when either
+// changed; a failed reload keeps serving the previous material.
+type certSource struct {
+	caFile   string
+	certFile string
+	keyFile  string
+
+	mu         sync.RWMutex
+	stamps     [3]fileStamp
+	roots      *x509.CertPool
+	cert       *tls.Certificate
+	certExpiry time.Time
+	caExpiry   time.Time
+	loaded     bool
+	reloads    int64
+	lastReload time.Time
+	lastErr    error
+}
+
+func (s *certSource) refresh() error {
+	var stamps [3]fileStamp
+
+	for i, path := range []string{s.caFile, s.certFile, s.keyFile} {
+		stamp, err := stampOf(path)
+		if err != nil {
+			return s.fail(err)
+		}
+
+		stamps[i] = stamp
+	}
+
+	s.mu.RLock()
+	current := s.loaded && stamps == s.stamps
+	s.mu.RUnlock()
+
+	if current {
+		return nil
+	}
+
+	roots, caExpiry, err := loadCAPool(s.caFile)
+	if err != nil {
+		return s.fail(err)
+	}
+
+	cert, certExpiry, err := loadClientCertificate(s.certFile, s.keyFile)
+	if err != nil {
+		return s.fail(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.loaded && s.stamps == stamps {
+		return nil // a concurrent refresh loaded the same files.
+	}
+
+	if s.loaded {
+		s.reloads++
+	}
+
+	s.stamps = stamps
+	s.roots, s.caExpiry = roots, caExpiry
+	s.cert, s.certExpiry = cert, certExpiry
+	s.loaded = true
+	s.lastReload = time.Now().UTC()
+	s.lastErr = nil
+
+	return nil
+}
+
+// fail records err; it is only returned while nothing has been loaded yet.
+func (s *certSource) fail(err error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastErr = err
+	if s.loaded {
+		return nil
+	}
+
+	return err
+}
+
+func (s *certSource) clientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	_ = s.refresh()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.cert, nil
+}
+
+func (s *certSource) verifier(fallbackHost string) func(tls.ConnectionState) error {
+	return func(state tls.ConnectionState) error {
+		_ = s.refresh()
+
+		if len(state.PeerCertificates) == 0 {
+			return ewrap.New("tls: server presented no certificate")
+		}
+
+		s.mu.RLock()
+		roots := s.roots
+		s.mu.RUnlock()
+
+		serverName := state.ServerName
+		if serverName == "" {
+			serverName = fallbackHost
+		}
+
+		opts := x509.VerifyOptions{
+			Roots:         roots,
+			DNSName:       serverName,
+			Intermediates: x509.NewCertPool(),
+		}
+		for _, cert := range state.PeerCertificates[1:] {
+			opts.Intermediates.AddCert(cert)
+		}
+
+		_, err := state.PeerCertificates[0].Verify(opts)
+		if err != nil {
+			return ewrap.Wrap(err, "verify server certificate")
+		}
+
+		return nil
+	}
+}
+
+func (s *certSource) status() CertificateStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	status := CertificateStatus{
+		CertNotAfter: s.certExpiry,
+		CANotAfter:   s.caExpiry,
+		Reloads:      s.reloads,
+		LastReload:   s.lastReload,
+	}
+	if s.lastErr != nil {
+		status.LastError = s.lastErr.Error()
+	}
+
+	return status
+}
+
+func loadCAPool(path string) (*x509.CertPool, time.Time, error) {
+	if path == "" {
+		return nil, time.Time{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}, ewrap.Wrapf(err, "read ca file %s", path)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, time.Time{}, ewrap.Newf("failed to parse ca file %s", path)
+	}
+
+	var expiry time.Time
+
+	for _, cert := range parseCertificates(data) {
+		if expiry.IsZero() || cert.NotAfter.Before(expiry) {
+			expiry = cert.NotAfter
+		}
+	}
+
+	return pool, expiry, nil
+}
+
+func loadClientCertificate(certFile, keyFile string) (*tls.Certificate, time.Time, error) {
+	if certFile == "" {
+		return nil, time.Time{}, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, time.Time{}, ewrap.Wrap(err, "load tls client certificate")
+	}
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return nil, time.Time{}, ewrap.Wrap(err, "parse tls client certificate")
+	}
+
+	cert.Leaf = leaf
+
+	return &cert, leaf.NotAfter, nil
+}
+
+func parseCertificates(data []byte) []*x509.Certificate {
+	var certs []*x509.Certificate
+
+	for {
+		block, rest := pem.Decode(data)
+		if block == nil {
+			return certs
+		}
+
+		data = rest
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err == nil {
+			certs = append(certs, cert)
+		}
+	}
+}
+
+// endpointHost extracts the host from a "host:port" endpoint or a URL.
+func endpointHost(endpoint string) string {
+	if strings.Contains(endpoint, "://") {
+		parsed, err := url.Parse(endpoint)
+		if err != nil {
+			return ""
+		}
+
+		return parsed.Hostname()
+	}
+
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return endpoint
+	}
+
+	return host
+}
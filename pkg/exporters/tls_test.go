@@ -0,0 +1,236 @@
+package exporters_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/hyp3rd/observe/pkg/config"
+	"github.com/hyp3rd/observe/pkg/exporters"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func issueCert(t *testing.T, name string, notAfter time.Time, parent *testCert, server bool) *testCert {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	signer, signerKey := template, key
+
+	switch {
+	case parent == nil:
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+	case server:
+		template.IPAddresses = []net.IP{net.IPv4(127, 0, 0, 1)}
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+		signer, signerKey = parent.cert, parent.key
+	default:
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse certificate: %v", err)
+	}
+
+	return &testCert{cert: cert, key: key, der: der}
+}
+
+func (c *testCert) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.der}, PrivateKey: c.key, Leaf: c.cert}
+}
+
+func writeCertFiles(t *testing.T, certPath, keyPath string, cert *testCert) {
+	t.Helper()
+
+	keyDER, err := x509.MarshalECPrivateKey(cert.key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+
+	writePEM(t, certPath, "CERTIFICATE", cert.der)
+
+	if keyPath != "" {
+		writePEM(t, keyPath, "EC PRIVATE KEY", keyDER)
+	}
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+
+	err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600)
+	if err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+// newMTLSCollector serves the certificate in serverCert, requires a client certificate from one
+// of clientCAs and records the common name of every client it sees.
+func newMTLSCollector(t *testing.T, serverCert *atomic.Pointer[tls.Certificate], clientCAs ...*testCert) (string, *headerRecorder) {
+	t.Helper()
+
+	clients := x509.NewCertPool()
+	for _, ca := range clientCAs {
+		clients.AddCert(ca.cert)
+	}
+
+	recorder := &headerRecorder{}
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recorder.add(r.TLS.PeerCertificates[0].Subject.CommonName)
+		w.WriteHeader(http.StatusOK)
+	}))
+	// StartTLS would install its own certificate; wrapping the listener serves serverCert.
+	server.Listener = tls.NewListener(server.Listener, &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clients,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return serverCert.Load(), nil
+		},
+	})
+	// Every export dials a new connection, so each one performs a handshake.
+	server.Config.SetKeepAlivesEnabled(false)
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return server.Listener.Addr().String(), recorder
+}
+
+func TestOTLPExporterReloadsRotatedClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	ca := issueCert(t, "ca", time.Now().Add(48*time.Hour), nil, false)
+
+	var serverCert atomic.Pointer[tls.Certificate]
+
+	collectorCert := issueCert(t, "collector", time.Now().Add(24*time.Hour), ca, true).tlsCertificate()
+	serverCert.Store(&collectorCert)
+
+	endpoint, clients := newMTLSCollector(t, &serverCert, ca)
+
+	tlsCfg := config.TLSConfig{
+		CAFile:   filepath.Join(dir, "ca.pem"),
+		CertFile: filepath.Join(dir, "client.pem"),
+		KeyFile:  filepath.Join(dir, "client-key.pem"),
+	}
+	writePEM(t, tlsCfg.CAFile, "CERTIFICATE", ca.der)
+	writeCertFiles(t, tlsCfg.CertFile, tlsCfg.KeyFile, issueCert(t, "client", time.Now().Add(24*time.Hour), ca, false))
+
+	exp := otlpTraceExporter(t, &config.OTLPConfig{Protocol: "http", Endpoint: endpoint, TLS: tlsCfg})
+	spans := tracetest.SpanStubs{{Name: "op"}}.Snapshots()
+
+	err := exp.ExportSpans(context.Background(), spans)
+	if err != nil {
+		t.Fatalf("export with the initial client certificate: %v", err)
+	}
+
+	rotatedExpiry := time.Now().Add(96 * time.Hour).Truncate(time.Second)
+	writeCertFiles(t, tlsCfg.CertFile, tlsCfg.KeyFile, issueCert(t, "client-rotated", rotatedExpiry, ca, false))
+
+	err = exp.ExportSpans(context.Background(), spans)
+	if err != nil {
+		t.Fatalf("export with the rotated client certificate: %v", err)
+	}
+
+	if seen := clients.all(); !slices.Equal(seen, []string{"client", "client-rotated"}) {
+		t.Fatalf("expected the collector to see the rotated client certificate, got %v", seen)
+	}
+
+	status, ok := exporters.TLSCertificateStatus(tlsCfg)
+	if !ok || !status.CertNotAfter.Equal(rotatedExpiry) || status.Reloads < 1 || status.LastError != "" {
+		t.Fatalf("expected the rotated certificate to be reloaded cleanly, got %+v", status)
+	}
+}
+
+func TestOTLPExporterReloadsRotatedCABundle(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	firstCA := issueCert(t, "first-ca", time.Now().Add(48*time.Hour), nil, false)
+	secondCA := issueCert(t, "second-ca", time.Now().Add(72*time.Hour), nil, false)
+
+	var serverCert atomic.Pointer[tls.Certificate]
+
+	first := issueCert(t, "collector", time.Now().Add(24*time.Hour), firstCA, true).tlsCertificate()
+	serverCert.Store(&first)
+
+	endpoint, _ := newMTLSCollector(t, &serverCert, firstCA)
+
+	tlsCfg := config.TLSConfig{
+		CAFile:   filepath.Join(dir, "ca.pem"),
+		CertFile: filepath.Join(dir, "client.pem"),
+		KeyFile:  filepath.Join(dir, "client-key.pem"),
+	}
+	writePEM(t, tlsCfg.CAFile, "CERTIFICATE", firstCA.der)
+	writeCertFiles(t, tlsCfg.CertFile, tlsCfg.KeyFile, issueCert(t, "client", time.Now().Add(24*time.Hour), firstCA, false))
+
+	exp := otlpTraceExporter(t, &config.OTLPConfig{Protocol: "http", Endpoint: endpoint, TLS: tlsCfg})
+	spans := tracetest.SpanStubs{{Name: "op"}}.Snapshots()
+
+	err := exp.ExportSpans(context.Background(), spans)
+	if err != nil {
+		t.Fatalf("export with the initial CA bundle: %v", err)
+	}
+
+	// The collector moves to a certificate from a new CA; exports fail until the bundle follows.
+	second := issueCert(t, "collector", time.Now().Add(24*time.Hour), secondCA, true).tlsCertificate()
+	serverCert.Store(&second)
+
+	err = exp.ExportSpans(context.Background(), spans)
+	if err == nil {
+		t.Fatal("expected the old CA bundle to reject the new server certificate")
+	}
+
+	writePEM(t, tlsCfg.CAFile, "CERTIFICATE", secondCA.der)
+
+	err = exp.ExportSpans(context.Background(), spans)
+	if err != nil {
+		t.Fatalf("expected the rotated CA bundle to trust the collector, got %v", err)
+	}
+
+	status, ok := exporters.TLSCertificateStatus(tlsCfg)
+	if !ok || !status.CANotAfter.Equal(secondCA.cert.NotAfter) || status.Reloads < 1 || status.LastError != "" {
+		t.Fatalf("expected the rotated CA bundle to be reloaded cleanly, got %+v", status)
+	}
+}
@@ -0,0 +1,334 @@
+package exporters
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyp3rd/ewrap"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hyp3rd/observe/pkg/config"
+)
+
+// TypeZipkin is the registry name of the Zipkin v2 JSON trace exporter.
+const TypeZipkin = "zipkin"
+
+const defaultZipkinTimeout = 10 * time.Second
+
+// peerServiceKey is the deprecated peer.service attribute, still the preferred Zipkin remote service hint.
+const peerServiceKey = attribute.Key("peer.service")
+
+func zipkinFactory() Factory {
+	return Factory{
+		Traces: func(_ context.Context, spec config.ExporterSpec) (sdktrace.SpanExporter, error) {
+			if spec.Zipkin == nil {
+				return nil, ewrap.Newf("exporter %q: zipkin settings are required", spec.Name)
+			}
+
+			return NewZipkinExporter(*spec.Zipkin)
+		},
+		Describe: func(spec config.ExporterSpec) Target {
+			if spec.Zipkin == nil {
+				return Target{Protocol: TypeZipkin}
+			}
+
+			return Target{Protocol: TypeZipkin, Endpoint: spec.Zipkin.URL}
+		},
+	}
+}
+
+// ZipkinExporter converts spans to the Zipkin v2 JSON model and POSTs them to a collector.
+type ZipkinExporter struct {
+	cfg    config.ZipkinConfig
+	client *http.Client
+	gzip   bool
+
+	mu      sync.Mutex
+	stopped bool
+}
+
+// NewZipkinExporter returns a span exporter posting to cfg.URL.
+func NewZipkinExporter(cfg config.ZipkinConfig) (*ZipkinExporter, error) {
+	if cfg.URL == "" {
+		return nil, ewrap.New("zipkin url is required")
+	}
+
+	transport, err := httpTransport(cfg.TLS, cfg.URL)
+	if err != nil {
+		return nil, ewrap.Wrap(err, "configure zipkin tls")
+	}
+
+	compression := strings.ToLower(cfg.Compression)
+	if compression != "" && compression != "none" && compression != "gzip" {
+		return nil, ewrap.Newf("unsupported zipkin compression %q", cfg.Compression)
+	}
+
+	return &ZipkinExporter{
+		cfg: cfg,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   durationOr(cfg.Timeout, defaultZipkinTimeout),
+		},
+		gzip: compression == "gzip",
+	}, nil
+}
+
+// ExportSpans implements sdktrace.SpanExporter.
+func (e *ZipkinExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	stopped := e.stopped
+	e.mu.Unlock()
+
+	if stopped || len(spans) == 0 {
+		return nil
+	}
+
+	body, err := e.encode(spans)
+	if err != nil {
+		return err
+	}
+
+	return withRetry(ctx, e.cfg.Retry, func(ctx context.Context) error {
+		return e.post(ctx, body)
+	})
+}
+
+// Shutdown implements sdktrace.SpanExporter.
+func (e *ZipkinExporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	e.stopped = true
+	e.mu.Unlock()
+
+	e.client.CloseIdleConnections()
+
+	return nil
+}
+
+func (e *ZipkinExporter) encode(spans []sdktrace.ReadOnlySpan) ([]byte, error) {
+	models := make([]zipkinSpan, 0, len(spans))
+	for _, span := range spans {
+		models = append(models, toZipkinSpan(span))
+	}
+
+	var buf bytes.Buffer
+
+	var w io.Writer = &buf
+
+	var gz *gzip.Writer
+	if e.gzip {
+		gz = gzip.NewWriter(&buf)
+		w = gz
+	}
+
+	err := json.NewEncoder(w).Encode(models)
+	if err != nil {
+		return nil, ewrap.Wrap(err, "encode zipkin spans")
+	}
+
+	if gz != nil {
+		err = gz.Close()
+		if err != nil {
+			return nil, ewrap.Wrap(err, "compress zipkin spans")
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (e *ZipkinExporter) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return ewrap.Wrap(err, "build zipkin request")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if e.gzip {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
+	for key, value := range e.cfg.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return retryable(ewrap.Wrap(err, "post zipkin spans"), 0)
+	}
+
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+
+	statusErr := ewrap.Newf("zipkin collector responded %s", resp.Status)
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return retryable(statusErr, retryAfter(resp.Header.Get("Retry-After")))
+	default:
+		return statusErr
+	}
+}
+
+func retryAfter(header string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(header))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+type zipkinEndpoint struct {
+	ServiceName string `json:"serviceName,omitempty"`
+}
+
+type zipkinAnnotation struct {
+	Timestamp int64  `json:"timestamp"`
+	Value     string `json:"value"`
+}
+
+type zipkinSpan struct {
+	TraceID        string             `json:"traceId"`
+	ID             string             `json:"id"`
+	ParentID       string             `json:"parentId,omitempty"`
+	Name           string             `json:"name"`
+	Kind           string             `json:"kind,omitempty"`
+	Timestamp      int64              `json:"timestamp"`
+	Duration       int64              `json:"duration"`
+	LocalEndpoint  *zipkinEndpoint    `json:"localEndpoint,omitempty"`
+	RemoteEndpoint *zipkinEndpoint    `json:"remoteEndpoint,omitempty"`
+	Annotations    []zipkinAnnotation `json:"annotations,omitempty"`
+	Tags           map[string]string  `json:"tags,omitempty"`
+}
+
+// toZipkinSpan maps a span onto the Zipkin v2 model following the OpenTelemetry
+// Zipkin exporter conventions: attributes become tags, events become annotations,
+// and status is reported through otel.status_code and error tags.
+func toZipkinSpan(span sdktrace.ReadOnlySpan) zipkinSpan {
+	sc := span.SpanContext()
+
+	model := zipkinSpan{
+		TraceID:   sc.TraceID().String(),
+		ID:        sc.SpanID().String(),
+		Name:      span.Name(),
+		Kind:      zipkinKind(span.SpanKind()),
+		Timestamp: span.StartTime().UnixMicro(),
+		Duration:  max(span.EndTime().Sub(span.StartTime()).Microseconds(), 1),
+	}
+
+	if parent := span.Parent(); parent.SpanID().IsValid() {
+		model.ParentID = parent.SpanID().String()
+	}
+
+	if res := span.Resource(); res != nil {
+		if name, ok := res.Set().Value(semconv.ServiceNameKey); ok {
+			model.LocalEndpoint = &zipkinEndpoint{ServiceName: name.AsString()}
+		}
+	}
+
+	tags := make(map[string]string, len(span.Attributes())+4)
+	for _, kv := range span.Attributes() {
+		tags[string(kv.Key)] = kv.Value.Emit()
+	}
+
+	if remote := remoteServiceName(span); remote != "" {
+		model.RemoteEndpoint = &zipkinEndpoint{ServiceName: remote}
+	}
+
+	switch span.Status().Code {
+	case codes.Error:
+		tags["otel.status_code"] = "ERROR"
+		tags["error"] = span.Status().Description
+	case codes.Ok:
+		tags["otel.status_code"] = "OK"
+	case codes.Unset:
+	}
+
+	if scope := span.InstrumentationScope(); scope.Name != "" {
+		tags["otel.scope.name"] = scope.Name
+		if scope.Version != "" {
+			tags["otel.scope.version"] = scope.Version
+		}
+	}
+
+	if len(tags) > 0 {
+		model.Tags = tags
+	}
+
+	for _, event := range span.Events() {
+		model.Annotations = append(model.Annotations, zipkinAnnotation{
+			Timestamp: event.Time.UnixMicro(),
+			Value:     annotationValue(event),
+		})
+	}
+
+	return model
+}
+
+func zipkinKind(kind trace.SpanKind) string {
+	switch kind {
+	case trace.SpanKindServer:
+		return "SERVER"
+	case trace.SpanKindClient:
+		return "CLIENT"
+	case trace.SpanKindProducer:
+		return "PRODUCER"
+	case trace.SpanKindConsumer:
+		return "CONSUMER"
+	default:
+		return ""
+	}
+}
+
+func remoteServiceName(span sdktrace.ReadOnlySpan) string {
+	if kind := span.SpanKind(); kind != trace.SpanKindClient && kind != trace.SpanKindProducer {
+		return ""
+	}
+
+	candidates := []attribute.Key{peerServiceKey, semconv.ServerAddressKey, semconv.DBSystemNameKey}
+	for _, key := range candidates {
+		for _, kv := range span.Attributes() {
+			if kv.Key == key && kv.Value.AsString() != "" {
+				return kv.Value.AsString()
+			}
+		}
+	}
+
+	return ""
+}
+
+func annotationValue(event sdktrace.Event) string {
+	if len(event.Attributes) == 0 {
+		return event.Name
+	}
+
+	attrs := make(map[string]any, len(event.Attributes))
+	for _, kv := range event.Attributes {
+		attrs[string(kv.Key)] = kv.Value.AsInterface()
+	}
+
+	data, err := json.Marshal(attrs)
+	if err != nil {
+		return event.Name
+	}
+
+	return event.Name + ": " + string(data)
+}
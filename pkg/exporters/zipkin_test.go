@@ -0,0 +1,168 @@
+package exporters_test
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hyp3rd/observe/pkg/config"
+	"github.com/hyp3rd/observe/pkg/exporters"
+)
+
+type zipkinSpan struct {
+	TraceID       string            `json:"traceId"`
+	ID            string            `json:"id"`
+	ParentID      string            `json:"parentId"`
+	Name          string            `json:"name"`
+	Kind          string            `json:"kind"`
+	Duration      int64             `json:"duration"`
+	LocalEndpoint map[string]string `json:"localEndpoint"`
+	Tags          map[string]string `json:"tags"`
+	Annotations   []struct {
+		Value string `json:"value"`
+	} `json:"annotations"`
+}
+
+func TestZipkinExporterPostsGzippedV2JSON(t *testing.T) {
+	t.Parallel()
+
+	var (
+		attempts atomic.Int32
+		received []zipkinSpan
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		if r.Header.Get("Content-Encoding") != "gzip" || r.Header.Get("X-Api-Key") != "k" {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		err = json.NewDecoder(gz).Decode(&received)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	exp, err := exporters.NewZipkinExporter(config.ZipkinConfig{
+		URL:         server.URL + "/api/v2/spans",
+		Headers:     map[string]string{"X-Api-Key": "k"},
+		Compression: "gzip",
+		Retry:       config.RetryConfig{Enabled: true, InitialInterval: time.Millisecond, MaxElapsedTime: time.Second},
+	})
+	if err != nil {
+		t.Fatalf("NewZipkinExporter returned error: %v", err)
+	}
+
+	err = exp.ExportSpans(context.Background(), recordSpans(t))
+	if err != nil {
+		t.Fatalf("ExportSpans returned error: %v", err)
+	}
+
+	if got := attempts.Load(); got != 2 {
+		t.Fatalf("expected one retry after 503, got %d attempts", got)
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(received))
+	}
+
+	child, parent := received[0], received[1]
+	if child.ParentID != parent.ID || child.TraceID != parent.TraceID {
+		t.Fatalf("expected parent/child linkage, got %+v / %+v", child, parent)
+	}
+
+	if child.Kind != "CLIENT" || child.Tags["otel.status_code"] != "ERROR" || child.Tags["error"] != "boom" {
+		t.Fatalf("unexpected child span mapping %+v", child)
+	}
+
+	if child.Tags["peer.service"] != "ledger" || len(child.Annotations) != 1 {
+		t.Fatalf("expected tags and annotations on child, got %+v", child)
+	}
+
+	if parent.Kind != "SERVER" || parent.LocalEndpoint["serviceName"] != "payments" || parent.Duration < 1 {
+		t.Fatalf("unexpected parent span mapping %+v", parent)
+	}
+}
+
+func TestZipkinExporterDoesNotRetryClientErrors(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	exp, err := exporters.NewZipkinExporter(config.ZipkinConfig{
+		URL:   server.URL,
+		Retry: config.RetryConfig{Enabled: true, InitialInterval: time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("NewZipkinExporter returned error: %v", err)
+	}
+
+	err = exp.ExportSpans(context.Background(), recordSpans(t))
+	if err == nil {
+		t.Fatal("expected export error for 400 response")
+	}
+
+	if got := attempts.Load(); got != 1 {
+		t.Fatalf("expected a single attempt, got %d", got)
+	}
+}
+
+func recordSpans(t *testing.T) []sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(recorder),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName("payments"))),
+	)
+	tracer := tp.Tracer("test")
+
+	ctx, parent := tracer.Start(context.Background(), "POST /charge", trace.WithSpanKind(trace.SpanKindServer))
+
+	_, child := tracer.Start(ctx, "ledger.Debit", trace.WithSpanKind(trace.SpanKindClient))
+	child.SetAttributes(attribute.String("peer.service", "ledger"))
+	child.AddEvent("retry", trace.WithAttributes(attribute.Int("attempt", 2)))
+	child.SetStatus(codes.Error, "boom")
+	child.End()
+	parent.End()
+
+	return recorder.Ended()
+}
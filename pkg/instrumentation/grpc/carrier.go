@@ -0,0 +1,36 @@
+package grpc
+
+import (
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataCarrier adapts gRPC metadata to propagation.TextMapCarrier.
+// Keys are lower-cased the same way metadata allowlists are normalized.
+type MetadataCarrier metadata.MD
+
+// Get returns the first value stored under key.
+func (c MetadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// Set replaces the values stored under key.
+func (c MetadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(strings.ToLower(key), value)
+}
+
+// Keys lists the metadata keys present in the carrier.
+func (c MetadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
@@ -5,8 +5,10 @@ import (
 	"context"
 	"strings"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
@@ -21,14 +23,34 @@ type Interceptors struct {
 	unaryClient grpc.UnaryClientInterceptor
 }
 
+// Option customizes interceptor construction.
+type Option func(*interceptorOptions)
+
+type interceptorOptions struct {
+	propagator propagation.TextMapPropagator
+}
+
+// WithPropagator overrides the propagator used to inject and extract metadata.
+// When unset, the global propagator is resolved on every call.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(o *interceptorOptions) {
+		o.propagator = propagator
+	}
+}
+
 // NewInterceptors constructs gRPC interceptors backed by the supplied tracer provider.
-func NewInterceptors(tp trace.TracerProvider, cfg config.GRPCInstrumentationConfig) Interceptors {
+func NewInterceptors(tp trace.TracerProvider, cfg config.GRPCInstrumentationConfig, opts ...Option) Interceptors {
+	var settings interceptorOptions
+	for _, opt := range opts {
+		opt(&settings)
+	}
+
 	tracer := tp.Tracer("observe/grpc")
 	allowlist := buildAllowlist(cfg.MetadataAllowlist)
 
 	return Interceptors{
-		unaryServer: newUnaryServerInterceptor(tracer, allowlist),
-		unaryClient: newUnaryClientInterceptor(tracer, allowlist),
+		unaryServer: newUnaryServerInterceptor(tracer, allowlist, settings.propagator),
+		unaryClient: newUnaryClientInterceptor(tracer, allowlist, settings.propagator),
 	}
 }
 
@@ -42,13 +64,14 @@ func (i Interceptors) UnaryClient() grpc.UnaryClientInterceptor {
 	return i.unaryClient
 }
 
-func newUnaryServerInterceptor(tracer trace.Tracer, allowlist map[string]struct{}) grpc.UnaryServerInterceptor {
+func newUnaryServerInterceptor(
+	tracer trace.Tracer,
+	allowlist map[string]struct{},
+	propagator propagation.TextMapPropagator,
+) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
 		service, method := splitFullMethod(info.FullMethod)
 
-		ctx, span := tracer.Start(ctx, info.FullMethod, trace.WithSpanKind(trace.SpanKindServer))
-		defer span.End()
-
 		attrs := []attribute.KeyValue{
 			semconv.RPCSystemGRPC,
 			semconv.RPCServiceKey.String(service),
@@ -56,10 +79,13 @@ func newUnaryServerInterceptor(tracer trace.Tracer, allowlist map[string]struct{
 		}
 
 		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			ctx = resolvePropagator(propagator).Extract(ctx, MetadataCarrier(md))
 			attrs = append(attrs, metadataAttrs(md, allowlist)...)
 		}
 
-		span.SetAttributes(attrs...)
+		// Setting the attributes at start lets samplers match on them.
+		ctx, span := tracer.Start(ctx, info.FullMethod, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attrs...))
+		defer span.End()
 
 		resp, err := handler(ctx, req)
 		if err != nil {
@@ -73,7 +99,11 @@ func newUnaryServerInterceptor(tracer trace.Tracer, allowlist map[string]struct{
 	}
 }
 
-func newUnaryClientInterceptor(tracer trace.Tracer, allowlist map[string]struct{}) grpc.UnaryClientInterceptor {
+func newUnaryClientInterceptor(
+	tracer trace.Tracer,
+	allowlist map[string]struct{},
+	propagator propagation.TextMapPropagator,
+) grpc.UnaryClientInterceptor {
 	return func(ctx context.Context,
 		method string, req,
 		reply any,
@@ -83,20 +113,25 @@ func newUnaryClientInterceptor(tracer trace.Tracer, allowlist map[string]struct{
 	) error {
 		service, rpcMethod := splitFullMethod(method)
 
-		ctx, span := tracer.Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient))
-		defer span.End()
-
 		attrs := []attribute.KeyValue{
 			semconv.RPCSystemGRPC,
 			semconv.RPCServiceKey.String(service),
 			semconv.RPCMethodKey.String(rpcMethod),
 		}
 
-		if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		md, ok := metadata.FromOutgoingContext(ctx)
+		if ok {
 			attrs = append(attrs, metadataAttrs(md, allowlist)...)
+			md = md.Copy()
+		} else {
+			md = metadata.MD{}
 		}
 
-		span.SetAttributes(attrs...)
+		ctx, span := tracer.Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+		defer span.End()
+
+		resolvePropagator(propagator).Inject(ctx, MetadataCarrier(md))
+		ctx = metadata.NewOutgoingContext(ctx, md)
 
 		err := invoker(ctx, method, req, reply, cc, opts...)
 		if err != nil {
@@ -112,6 +147,14 @@ func newUnaryClientInterceptor(tracer trace.Tracer, allowlist map[string]struct{
 	}
 }
 
+func resolvePropagator(propagator propagation.TextMapPropagator) propagation.TextMapPropagator {
+	if propagator != nil {
+		return propagator
+	}
+
+	return otel.GetTextMapPropagator()
+}
+
 func buildAllowlist(keys []string) map[string]struct{} {
 	if len(keys) == 0 {
 		return nil
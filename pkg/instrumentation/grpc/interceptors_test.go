@@ -0,0 +1,96 @@
+package grpc_test
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/hyp3rd/observe/pkg/config"
+	observegrpc "github.com/hyp3rd/observe/pkg/instrumentation/grpc"
+)
+
+const fullMethod = "/payments.v1.Payments/Charge"
+
+func TestInterceptorsPropagateTraceThroughMetadata(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	interceptors := observegrpc.NewInterceptors(
+		tp,
+		config.GRPCInstrumentationConfig{Enabled: true, MetadataAllowlist: []string{"x-tenant"}},
+		observegrpc.WithPropagator(propagation.TraceContext{}),
+	)
+
+	var outgoing metadata.MD
+
+	invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		outgoing, _ = metadata.FromOutgoingContext(ctx)
+
+		return nil
+	}
+
+	callerCtx := metadata.AppendToOutgoingContext(context.Background(), "x-tenant", "acme")
+
+	err := interceptors.UnaryClient()(callerCtx, fullMethod, nil, nil, nil, invoker)
+	if err != nil {
+		t.Fatalf("client interceptor returned error: %v", err)
+	}
+
+	if outgoing.Get("traceparent") == nil {
+		t.Fatalf("expected traceparent in outgoing metadata, got %v", outgoing)
+	}
+
+	if got := outgoing.Get("x-tenant"); len(got) != 1 || got[0] != "acme" {
+		t.Fatalf("expected caller metadata to be preserved, got %v", got)
+	}
+
+	serverCtx := metadata.NewIncomingContext(context.Background(), outgoing)
+	info := &grpc.UnaryServerInfo{FullMethod: fullMethod}
+
+	_, err = interceptors.UnaryServer()(serverCtx, nil, info, func(context.Context, any) (any, error) {
+		return struct{}{}, nil
+	})
+	if err != nil {
+		t.Fatalf("server interceptor returned error: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected client and server spans, got %d", len(spans))
+	}
+
+	client, server := spans[0], spans[1]
+	if server.SpanContext().TraceID() != client.SpanContext().TraceID() {
+		t.Fatal("expected client and server spans to share a trace")
+	}
+
+	if server.Parent().SpanID() != client.SpanContext().SpanID() {
+		t.Fatal("expected server span to be parented by the client span")
+	}
+
+	if !server.Parent().IsRemote() {
+		t.Fatal("expected server parent to be marked remote")
+	}
+}
+
+func TestMetadataCarrierNormalizesKeys(t *testing.T) {
+	t.Parallel()
+
+	carrier := observegrpc.MetadataCarrier(metadata.MD{})
+	carrier.Set("TraceParent", "value")
+
+	if got := carrier.Get("traceparent"); got != "value" {
+		t.Fatalf("expected normalized key lookup, got %q", got)
+	}
+
+	if keys := carrier.Keys(); len(keys) != 1 || keys[0] != "traceparent" {
+		t.Fatalf("unexpected keys %v", keys)
+	}
+}
@@ -2,18 +2,22 @@
 package http
 
 import (
+	"context"
 	"net"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/hyp3rd/ewrap"
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/hyp3rd/observe/pkg/baggage"
 	"github.com/hyp3rd/observe/pkg/config"
 )
 
@@ -24,10 +28,35 @@ type Middleware struct {
 	duration      metric.Float64Histogram
 	cfg           config.HTTPInstrumentationConfig
 	ignoredRoutes map[string]struct{}
+	propagator    propagation.TextMapPropagator
+	metricBaggage *baggage.Promoter
+}
+
+// Option customizes Middleware construction.
+type Option func(*Middleware)
+
+// WithPropagator overrides the propagator used to extract incoming trace context.
+// When unset, the global propagator is resolved on every request.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(m *Middleware) {
+		m.propagator = propagator
+	}
+}
+
+// WithMetricBaggage promotes the allowlisted baggage members onto request metrics.
+func WithMetricBaggage(keys []string) Option {
+	return func(m *Middleware) {
+		m.metricBaggage = baggage.NewPromoter(keys)
+	}
 }
 
 // NewMiddleware creates a new middleware using the provided tracer and meter.
-func NewMiddleware(tp trace.TracerProvider, mp metric.MeterProvider, cfg config.HTTPInstrumentationConfig) (*Middleware, error) {
+func NewMiddleware(
+	tp trace.TracerProvider,
+	mp metric.MeterProvider,
+	cfg config.HTTPInstrumentationConfig,
+	opts ...Option,
+) (*Middleware, error) {
 	tracer := tp.Tracer("observe/http")
 	meter := mp.Meter("observe/http")
 
@@ -48,13 +77,19 @@ func NewMiddleware(tp trace.TracerProvider, mp metric.MeterProvider, cfg config.
 		return nil, ewrap.Wrap(err, "create latency histogram")
 	}
 
-	return &Middleware{
+	mw := &Middleware{
 		tracer:        tracer,
 		requests:      reqCounter,
 		duration:      latencyHist,
 		cfg:           cfg,
 		ignoredRoutes: toSet(cfg.IgnoredRoutes),
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(mw)
+	}
+
+	return mw, nil
 }
 
 // Handler wraps the supplied handler with tracing and metrics.
@@ -71,12 +106,15 @@ func (m *Middleware) Handler(next http.Handler) http.Handler {
 			semconv.HTTPRequestMethodKey.String(r.Method),
 			semconv.HTTPRouteKey.String(route),
 		}
+		if host := clientIP(r); host != "" {
+			attrs = append(attrs, semconv.ClientAddressKey.String(host))
+		}
 
-		ctx, span := m.tracer.Start(
-			r.Context(),
-			spanName(r.Method, route),
-			trace.WithSpanKind(trace.SpanKindServer),
-		)
+		// Attributes known up front go in at start, so samplers can match on them.
+		ctx, startOpts := m.extract(r)
+		startOpts = append(startOpts, trace.WithAttributes(attrs...))
+
+		ctx, span := m.tracer.Start(ctx, spanName(r.Method, route), startOpts...)
 		defer span.End()
 
 		start := time.Now()
@@ -87,24 +125,45 @@ func (m *Middleware) Handler(next http.Handler) http.Handler {
 		duration := time.Since(start)
 		statusAttr := semconv.HTTPResponseStatusCodeKey.Int(rr.status)
 
-		attrs = append(attrs, statusAttr)
-		if host := clientIP(r); host != "" {
-			attrs = append(attrs, semconv.ClientAddressKey.String(host))
-		}
-
 		if rr.status >= http.StatusInternalServerError {
 			span.SetStatus(codes.Error, http.StatusText(rr.status))
 		} else {
 			span.SetStatus(codes.Ok, "")
 		}
 
-		span.SetAttributes(attrs...)
+		span.SetAttributes(statusAttr)
 
-		m.requests.Add(ctx, 1, metric.WithAttributes(attrs...))
-		m.duration.Record(ctx, float64(duration.Milliseconds()), metric.WithAttributes(attrs...))
+		attrs = append(attrs, statusAttr)
+
+		metricAttrs := metric.WithAttributes(m.metricBaggage.Append(ctx, attrs)...)
+		m.requests.Add(ctx, 1, metricAttrs)
+		m.duration.Record(ctx, float64(duration.Milliseconds()), metricAttrs)
 	})
 }
 
+// extract reads the remote span context from the request headers. Public endpoints
+// start a new root span linked to the remote context rather than trusting it as parent.
+func (m *Middleware) extract(r *http.Request) (context.Context, []trace.SpanStartOption) {
+	propagator := m.propagator
+	if propagator == nil {
+		propagator = otel.GetTextMapPropagator()
+	}
+
+	ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+	opts := []trace.SpanStartOption{trace.WithSpanKind(trace.SpanKindServer)}
+
+	if !m.cfg.PublicEndpoint {
+		return ctx, opts
+	}
+
+	remote := trace.SpanContextFromContext(ctx)
+	if !remote.IsValid() || !remote.IsRemote() {
+		return ctx, opts
+	}
+
+	return ctx, append(opts, trace.WithNewRoot(), trace.WithLinks(trace.Link{SpanContext: remote}))
+}
+
 func (m *Middleware) shouldIgnore(route string) bool {
 	_, ok := m.ignoredRoutes[route]
 
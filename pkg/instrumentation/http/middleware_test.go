@@ -0,0 +1,95 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hyp3rd/observe/pkg/config"
+	observehttp "github.com/hyp3rd/observe/pkg/instrumentation/http"
+)
+
+const remoteTraceParent = "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+
+func TestMiddlewareExtractsRemoteParent(t *testing.T) {
+	t.Parallel()
+
+	span := serveWithTraceParent(t, config.HTTPInstrumentationConfig{Enabled: true})
+
+	if got := span.Parent().TraceID().String(); got != "0af7651916cd43dd8448eb211c80319c" {
+		t.Fatalf("expected remote trace id as parent, got %s", got)
+	}
+
+	if span.SpanContext().TraceID() != span.Parent().TraceID() {
+		t.Fatal("expected server span to join the remote trace")
+	}
+
+	if len(span.Links()) != 0 {
+		t.Fatalf("expected no links, got %d", len(span.Links()))
+	}
+}
+
+func TestMiddlewarePublicEndpointLinksRemoteContext(t *testing.T) {
+	t.Parallel()
+
+	span := serveWithTraceParent(t, config.HTTPInstrumentationConfig{Enabled: true, PublicEndpoint: true})
+
+	if span.Parent().IsValid() {
+		t.Fatal("expected public endpoint span to start a new root")
+	}
+
+	links := span.Links()
+	if len(links) != 1 {
+		t.Fatalf("expected one link, got %d", len(links))
+	}
+
+	if got := links[0].SpanContext.SpanID().String(); got != "b7ad6b7169203331" {
+		t.Fatalf("expected link to remote span, got %s", got)
+	}
+}
+
+func serveWithTraceParent(t *testing.T, cfg config.HTTPInstrumentationConfig) sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	mw, err := observehttp.NewMiddleware(
+		tp,
+		sdkmetric.NewMeterProvider(),
+		cfg,
+		observehttp.WithPropagator(propagation.TraceContext{}),
+	)
+	if err != nil {
+		t.Fatalf("NewMiddleware returned error: %v", err)
+	}
+
+	var handlerCtx context.Context
+
+	handler := mw.Handler(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		handlerCtx = r.Context()
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	req.Header.Set("traceparent", remoteTraceParent)
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !trace.SpanContextFromContext(handlerCtx).IsValid() {
+		t.Fatal("expected handler context to carry the server span")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected one span, got %d", len(spans))
+	}
+
+	return spans[0]
+}
@@ -0,0 +1,96 @@
+package kafka
+
+import (
+	"context"
+	"strings"
+
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// HeaderCarrier adapts kafka message headers to propagation.TextMapCarrier.
+type HeaderCarrier struct {
+	headers *[]kafka.Header
+}
+
+// NewHeaderCarrier returns a carrier that reads and writes the headers of msg.
+func NewHeaderCarrier(msg *kafka.Message) HeaderCarrier {
+	return HeaderCarrier{headers: &msg.Headers}
+}
+
+// Get returns the value of the first header matching key.
+func (c HeaderCarrier) Get(key string) string {
+	for _, h := range *c.headers {
+		if strings.EqualFold(h.Key, key) {
+			return string(h.Value)
+		}
+	}
+
+	return ""
+}
+
+// Set replaces any header matching key with the supplied value.
+func (c HeaderCarrier) Set(key, value string) {
+	headers := (*c.headers)[:0]
+	for _, h := range *c.headers {
+		if strings.EqualFold(h.Key, key) {
+			continue
+		}
+
+		headers = append(headers, h)
+	}
+
+	*c.headers = append(headers, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+// Keys lists the header keys present in the carrier.
+func (c HeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(*c.headers))
+	for _, h := range *c.headers {
+		keys = append(keys, h.Key)
+	}
+
+	return keys
+}
+
+// Option customizes the Kafka reader and writer wrappers.
+type Option func(*options)
+
+type options struct {
+	propagator propagation.TextMapPropagator
+}
+
+// WithPropagator overrides the propagator used for message headers.
+// When unset, the global propagator is resolved on every call.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(o *options) {
+		o.propagator = propagator
+	}
+}
+
+func newOptions(opts []Option) options {
+	var settings options
+	for _, opt := range opts {
+		opt(&settings)
+	}
+
+	return settings
+}
+
+func (o options) textMapPropagator() propagation.TextMapPropagator {
+	if o.propagator != nil {
+		return o.propagator
+	}
+
+	return otel.GetTextMapPropagator()
+}
+
+// ExtractContext returns ctx enriched with the trace context and baggage carried in msg headers.
+func ExtractContext(ctx context.Context, propagator propagation.TextMapPropagator, msg kafka.Message) context.Context {
+	if propagator == nil {
+		propagator = otel.GetTextMapPropagator()
+	}
+
+	return propagator.Extract(ctx, NewHeaderCarrier(&msg))
+}
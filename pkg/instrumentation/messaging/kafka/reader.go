@@ -5,6 +5,7 @@ import (
 	"context"
 
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/hyp3rd/observe/pkg/instrumentation/messaging"
 )
@@ -13,6 +14,7 @@ import (
 type Reader struct {
 	reader kafkaReader
 	helper *messaging.Helper
+	opts   options
 }
 
 type kafkaReader interface {
@@ -22,19 +24,22 @@ type kafkaReader interface {
 }
 
 // NewReader instruments the provided kafka.Reader.
-func NewReader(inner *kafka.Reader, helper *messaging.Helper) *Reader {
-	return NewReaderWith(inner, helper)
+func NewReader(inner *kafka.Reader, helper *messaging.Helper, opts ...Option) *Reader {
+	return NewReaderWith(inner, helper, opts...)
 }
 
 // NewReaderWith instruments the provided kafka.Reader.
-func NewReaderWith(inner kafkaReader, helper *messaging.Helper) *Reader {
+func NewReaderWith(inner kafkaReader, helper *messaging.Helper, opts ...Option) *Reader {
 	return &Reader{
 		reader: inner,
 		helper: helper,
+		opts:   newOptions(opts),
 	}
 }
 
 // FetchMessage instruments the fetch operation and returns the fetched message.
+// The consume span is linked to the producer context found in the message headers;
+// use ExtractContext to continue the producer trace while processing the message.
 func (r *Reader) FetchMessage(ctx context.Context) (kafka.Message, error) {
 	if r.helper == nil {
 		return r.reader.FetchMessage(ctx)
@@ -55,8 +60,16 @@ func (r *Reader) FetchMessage(ctx context.Context) (kafka.Message, error) {
 
 	wrappedErr := r.helper.InstrumentConsume(ctx, info, func(ctx context.Context) error {
 		msg, err = r.reader.FetchMessage(ctx)
+		if err != nil {
+			return err
+		}
 
-		return err
+		remote := trace.SpanContextFromContext(ExtractContext(ctx, r.opts.textMapPropagator(), msg))
+		if remote.IsValid() && remote.IsRemote() {
+			trace.SpanFromContext(ctx).AddLink(trace.Link{SpanContext: remote})
+		}
+
+		return nil
 	})
 	if wrappedErr != nil {
 		return kafka.Message{}, wrappedErr
@@ -7,8 +7,11 @@ import (
 
 	"github.com/hyp3rd/ewrap"
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/hyp3rd/observe/pkg/instrumentation/messaging"
 	observekafka "github.com/hyp3rd/observe/pkg/instrumentation/messaging/kafka"
@@ -69,6 +72,49 @@ func TestReaderFetchMessagePropagatesErrors(t *testing.T) {
 	}
 }
 
+func TestReaderFetchMessageLinksProducerContext(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := trace.NewTracerProvider(trace.WithSpanProcessor(recorder))
+
+	helper, err := messaging.NewHelper(tp, metric.NewMeterProvider())
+	if err != nil {
+		t.Fatalf("NewHelper returned error: %v", err)
+	}
+
+	stub := &stubKafkaReader{
+		config: kafka.ReaderConfig{Topic: "payments"},
+		message: kafka.Message{
+			Topic: "payments",
+			Headers: []kafka.Header{
+				{Key: "traceparent", Value: []byte("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")},
+			},
+		},
+	}
+	instrumented := observekafka.NewReaderWith(stub, helper, observekafka.WithPropagator(propagation.TraceContext{}))
+
+	msg, err := instrumented.FetchMessage(context.Background())
+	if err != nil {
+		t.Fatalf("FetchMessage returned error: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected consume span, got %d", len(spans))
+	}
+
+	links := spans[0].Links()
+	if len(links) != 1 || links[0].SpanContext.SpanID().String() != "b7ad6b7169203331" {
+		t.Fatalf("expected link to producer span, got %v", links)
+	}
+
+	ctx := observekafka.ExtractContext(context.Background(), propagation.TraceContext{}, msg)
+	if !oteltrace.SpanContextFromContext(ctx).IsRemote() {
+		t.Fatal("expected ExtractContext to return the remote producer context")
+	}
+}
+
 type stubKafkaReader struct {
 	config   kafka.ReaderConfig
 	message  kafka.Message
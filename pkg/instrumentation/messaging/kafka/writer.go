@@ -12,6 +12,7 @@ import (
 type Writer struct {
 	writer kafkaWriter
 	helper *messaging.Helper
+	opts   options
 }
 
 type kafkaWriter interface {
@@ -19,24 +20,30 @@ type kafkaWriter interface {
 }
 
 // NewWriter returns a Writer wrapper that instruments publish operations via the messaging helper.
-func NewWriter(inner *kafka.Writer, helper *messaging.Helper) *Writer {
-	return NewWriterWith(inner, helper)
+func NewWriter(inner *kafka.Writer, helper *messaging.Helper, opts ...Option) *Writer {
+	return NewWriterWith(inner, helper, opts...)
 }
 
 // NewWriterWith returns a Writer wrapper that instruments publish operations via the messaging helper.
-func NewWriterWith(inner kafkaWriter, helper *messaging.Helper) *Writer {
+func NewWriterWith(inner kafkaWriter, helper *messaging.Helper, opts ...Option) *Writer {
 	return &Writer{
 		writer: inner,
 		helper: helper,
+		opts:   newOptions(opts),
 	}
 }
 
-// WriteMessages instruments the call and delegates to the underlying writer.
+// WriteMessages instruments the call, injects the active span context into each
+// message's headers, and delegates to the underlying writer.
 func (w *Writer) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
-	if len(msgs) == 0 || w.helper == nil {
+	if len(msgs) == 0 {
 		return w.writer.WriteMessages(ctx, msgs...)
 	}
 
+	if w.helper == nil {
+		return w.writer.WriteMessages(ctx, w.inject(ctx, msgs)...)
+	}
+
 	info := messaging.PublishInfo{
 		System:          "kafka",
 		Destination:     msgs[0].Topic,
@@ -45,10 +52,25 @@ func (w *Writer) WriteMessages(ctx context.Context, msgs ...kafka.Message) error
 	}
 
 	return w.helper.InstrumentPublish(ctx, info, func(ctx context.Context) error {
-		return w.writer.WriteMessages(ctx, msgs...)
+		return w.writer.WriteMessages(ctx, w.inject(ctx, msgs)...)
 	})
 }
 
+// inject returns copies of msgs carrying the propagated context so the caller's
+// messages and header slices are left untouched.
+func (w *Writer) inject(ctx context.Context, msgs []kafka.Message) []kafka.Message {
+	propagator := w.opts.textMapPropagator()
+
+	out := make([]kafka.Message, len(msgs))
+	for idx, msg := range msgs {
+		msg.Headers = append([]kafka.Header(nil), msg.Headers...)
+		propagator.Inject(ctx, NewHeaderCarrier(&msg))
+		out[idx] = msg
+	}
+
+	return out
+}
+
 func totalPayloadBytes(msgs []kafka.Message) int64 {
 	var total int64
 	for _, msg := range msgs {
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
@@ -51,12 +52,69 @@ func TestWriterInstrumentsPublish(t *testing.T) {
 	}
 }
 
+func TestWriterInjectsTraceHeaders(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := trace.NewTracerProvider(trace.WithSpanProcessor(recorder))
+
+	helper, err := messaging.NewHelper(tp, metric.NewMeterProvider())
+	if err != nil {
+		t.Fatalf("NewHelper returned error: %v", err)
+	}
+
+	stub := &stubKafkaWriter{}
+	writer := observekafka.NewWriterWith(stub, helper, observekafka.WithPropagator(propagation.TraceContext{}))
+
+	msg := kafka.Message{
+		Topic:   "payments",
+		Headers: []kafka.Header{{Key: "job-name", Value: []byte("settle")}},
+	}
+
+	err = writer.WriteMessages(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("WriteMessages returned error: %v", err)
+	}
+
+	if len(msg.Headers) != 1 {
+		t.Fatalf("expected caller headers untouched, got %d", len(msg.Headers))
+	}
+
+	if len(stub.messages) != 1 {
+		t.Fatalf("expected one message written, got %d", len(stub.messages))
+	}
+
+	written := stub.messages[0]
+	carrier := observekafka.NewHeaderCarrier(&written)
+
+	if carrier.Get("job-name") != "settle" {
+		t.Fatal("expected existing headers to be preserved")
+	}
+
+	traceParent := carrier.Get("traceparent")
+	if traceParent == "" {
+		t.Fatalf("expected traceparent header, got %v", written.Headers)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected publish span, got %d", len(spans))
+	}
+
+	want := "00-" + spans[0].SpanContext().TraceID().String() + "-" + spans[0].SpanContext().SpanID().String() + "-01"
+	if traceParent != want {
+		t.Fatalf("expected traceparent %s, got %s", want, traceParent)
+	}
+}
+
 type stubKafkaWriter struct {
-	called bool
+	called   bool
+	messages []kafka.Message
 }
 
-func (s *stubKafkaWriter) WriteMessages(_ context.Context, _ ...kafka.Message) error {
+func (s *stubKafkaWriter) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
 	s.called = true
+	s.messages = append(s.messages, msgs...)
 
 	return nil
 }
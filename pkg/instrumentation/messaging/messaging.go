@@ -12,6 +12,8 @@ import (
 	"go.opentelemetry.io/otel/metric/noop"
 	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
 	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hyp3rd/observe/pkg/baggage"
 )
 
 const (
@@ -38,6 +40,7 @@ type PublishInfo struct {
 }
 
 // ConsumeInfo captures metadata for consumer spans/metrics.
+// Links relate the consume span to the producer spans of the consumed messages.
 type ConsumeInfo struct {
 	System          string
 	Destination     string
@@ -45,6 +48,7 @@ type ConsumeInfo struct {
 	Group           string
 	Attributes      []attribute.KeyValue
 	Operation       string
+	Links           []trace.Link
 }
 
 // Helper provides helpers for messaging instrumentation.
@@ -54,10 +58,21 @@ type Helper struct {
 	publishCount   metric.Int64Counter
 	consumeLatency metric.Float64Histogram
 	consumeCount   metric.Int64Counter
+	metricBaggage  *baggage.Promoter
+}
+
+// Option customizes Helper construction.
+type Option func(*Helper)
+
+// WithMetricBaggage promotes the allowlisted baggage members onto publish/consume metrics.
+func WithMetricBaggage(keys []string) Option {
+	return func(h *Helper) {
+		h.metricBaggage = baggage.NewPromoter(keys)
+	}
 }
 
 // NewHelper initializes messaging instrumentation helpers.
-func NewHelper(tp trace.TracerProvider, mp metric.MeterProvider) (*Helper, error) {
+func NewHelper(tp trace.TracerProvider, mp metric.MeterProvider, opts ...Option) (*Helper, error) {
 	if tp == nil {
 		return nil, ewrap.New("tracer provider is nil")
 	}
@@ -103,13 +118,19 @@ func NewHelper(tp trace.TracerProvider, mp metric.MeterProvider) (*Helper, error
 		return nil, ewrap.Wrap(err, "create consume counter")
 	}
 
-	return &Helper{
+	helper := &Helper{
 		tracer:         tr,
 		publishLatency: pubLatency,
 		publishCount:   pubCount,
 		consumeLatency: conLatency,
 		consumeCount:   conCount,
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(helper)
+	}
+
+	return helper, nil
 }
 
 // InstrumentPublish wraps a publish function with tracing and metrics.
@@ -124,6 +145,7 @@ func (h *Helper) InstrumentPublish(ctx context.Context, info PublishInfo, fn fun
 		info.Operation,
 		info.Destination,
 		publishAttributes(info),
+		nil,
 		fn,
 		h.publishLatency,
 		h.publishCount,
@@ -142,6 +164,7 @@ func (h *Helper) InstrumentConsume(ctx context.Context, info ConsumeInfo, fn fun
 		info.Operation,
 		info.Destination,
 		consumeAttributes(info),
+		info.Links,
 		fn,
 		h.consumeLatency,
 		h.consumeCount,
@@ -185,6 +208,7 @@ func (h *Helper) instrument(
 	operation string,
 	destination string,
 	attrs []attribute.KeyValue,
+	links []trace.Link,
 	fn func(context.Context) error,
 	hist metric.Float64Histogram,
 	counter metric.Int64Counter,
@@ -193,11 +217,15 @@ func (h *Helper) instrument(
 		return fn(ctx)
 	}
 
-	ctx, span := h.tracer.Start(ctx, spanName(operation, destination), trace.WithSpanKind(kind))
+	ctx, span := h.tracer.Start(
+		ctx,
+		spanName(operation, destination),
+		trace.WithSpanKind(kind),
+		trace.WithLinks(links...),
+		trace.WithAttributes(attrs...),
+	)
 	start := time.Now()
 
-	span.SetAttributes(attrs...)
-
 	err := fn(ctx)
 	if err != nil {
 		span.RecordError(err)
@@ -209,8 +237,9 @@ func (h *Helper) instrument(
 	span.End()
 
 	duration := float64(time.Since(start)) / float64(time.Millisecond)
-	hist.Record(ctx, duration, metric.WithAttributes(attrs...))
-	counter.Add(ctx, 1, metric.WithAttributes(attrs...))
+	metricAttrs := metric.WithAttributes(h.metricBaggage.Append(ctx, attrs)...)
+	hist.Record(ctx, duration, metricAttrs)
+	counter.Add(ctx, 1, metricAttrs)
 
 	return err
 }
@@ -10,8 +10,12 @@ import (
 	"github.com/hyp3rd/ewrap"
 	"github.com/segmentio/kafka-go"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/hyp3rd/observe/pkg/instrumentation/messaging"
+	observekafka "github.com/hyp3rd/observe/pkg/instrumentation/messaging/kafka"
 	"github.com/hyp3rd/observe/pkg/instrumentation/worker"
 )
 
@@ -26,23 +30,60 @@ type reader interface {
 
 // Consumer wires worker and messaging helpers into a kafka.Reader loop.
 type Consumer struct {
-	reader    reader
-	worker    *worker.Helper
-	messaging *messaging.Helper
+	reader       reader
+	worker       *worker.Helper
+	messaging    *messaging.Helper
+	propagator   propagation.TextMapPropagator
+	linkProducer bool
+}
+
+// Option customizes Consumer construction.
+type Option func(*Consumer)
+
+// WithPropagator overrides the propagator used to read message headers.
+// When unset, the global propagator is resolved for every message.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(c *Consumer) {
+		c.propagator = propagator
+	}
+}
+
+// WithProducerLink records the producer context found in message headers as a span
+// link instead of using it as the parent of the job span.
+func WithProducerLink() Option {
+	return func(c *Consumer) {
+		c.linkProducer = true
+	}
 }
 
 // NewConsumer wraps the provided kafka.Reader.
-func NewConsumer(r *kafka.Reader, workerHelper *worker.Helper, messagingHelper *messaging.Helper) *Consumer {
-	return NewConsumerWith(r, workerHelper, messagingHelper)
+func NewConsumer(
+	r *kafka.Reader,
+	workerHelper *worker.Helper,
+	messagingHelper *messaging.Helper,
+	opts ...Option,
+) *Consumer {
+	return NewConsumerWith(r, workerHelper, messagingHelper, opts...)
 }
 
 // NewConsumerWith accepts any reader implementing the subset of kafka.Reader used by the consumer.
-func NewConsumerWith(r reader, workerHelper *worker.Helper, messagingHelper *messaging.Helper) *Consumer {
-	return &Consumer{
+func NewConsumerWith(
+	r reader,
+	workerHelper *worker.Helper,
+	messagingHelper *messaging.Helper,
+	opts ...Option,
+) *Consumer {
+	consumer := &Consumer{
 		reader:    r,
 		worker:    workerHelper,
 		messaging: messagingHelper,
 	}
+
+	for _, opt := range opts {
+		opt(consumer)
+	}
+
+	return consumer
 }
 
 // Run starts the consumption loop until the context is cancelled or the handler returns an error.
@@ -99,6 +140,8 @@ func (c *Consumer) processMessage(
 	msg kafka.Message,
 	handler Handler,
 ) error {
+	ctx, links := c.producerContext(ctx, msg)
+
 	consumeInfo := messaging.ConsumeInfo{
 		System:          "kafka",
 		Destination:     cfg.Topic,
@@ -111,6 +154,11 @@ func (c *Consumer) processMessage(
 		Queue:      cfg.Topic,
 		Attributes: jobAttributes(msg),
 		Schedule:   nextSchedule(msg.Time),
+		Links:      links,
+	}
+
+	if c.worker == nil {
+		consumeInfo.Links = links
 	}
 
 	exec := func(execCtx context.Context) error {
@@ -130,6 +178,24 @@ func (c *Consumer) processMessage(
 	return exec(ctx)
 }
 
+// producerContext continues the producer trace carried in the message headers, or
+// returns it as a link when the consumer is configured with WithProducerLink.
+func (c *Consumer) producerContext(ctx context.Context, msg kafka.Message) (context.Context, []trace.Link) {
+	extracted := observekafka.ExtractContext(ctx, c.propagator, msg)
+	if !c.linkProducer {
+		return extracted, nil
+	}
+
+	ctx = baggage.ContextWithBaggage(ctx, baggage.FromContext(extracted))
+
+	remote := trace.SpanContextFromContext(extracted)
+	if !remote.IsValid() || !remote.IsRemote() {
+		return ctx, nil
+	}
+
+	return ctx, []trace.Link{{SpanContext: remote}}
+}
+
 func (c *Consumer) commit(ctx context.Context, msg kafka.Message) error {
 	err := c.reader.CommitMessages(ctx, msg)
 	if err != nil {
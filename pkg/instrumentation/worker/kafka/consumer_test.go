@@ -8,6 +8,7 @@ import (
 
 	"github.com/hyp3rd/ewrap"
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -131,6 +132,80 @@ func TestConsumerRunHandlerError(t *testing.T) {
 	}
 }
 
+const (
+	producerTraceID   = "0af7651916cd43dd8448eb211c80319c"
+	producerSpanID    = "b7ad6b7169203331"
+	producerParentHdr = "00-" + producerTraceID + "-" + producerSpanID + "-01"
+)
+
+func TestConsumerContinuesProducerTrace(t *testing.T) {
+	t.Parallel()
+
+	job := runTracedMessage(t)
+
+	if job.SpanContext().TraceID().String() != producerTraceID {
+		t.Fatalf("expected job span in producer trace, got %s", job.SpanContext().TraceID())
+	}
+
+	if job.Parent().SpanID().String() != producerSpanID {
+		t.Fatalf("expected job span parented by producer, got %s", job.Parent().SpanID())
+	}
+}
+
+func TestConsumerProducerLink(t *testing.T) {
+	t.Parallel()
+
+	job := runTracedMessage(t, WithProducerLink())
+
+	if job.SpanContext().TraceID().String() == producerTraceID {
+		t.Fatal("expected job span to start a new trace")
+	}
+
+	links := job.Links()
+	if len(links) != 1 || links[0].SpanContext.SpanID().String() != producerSpanID {
+		t.Fatalf("expected link to producer span, got %v", links)
+	}
+}
+
+func runTracedMessage(t *testing.T, opts ...Option) sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	reader := &stubReader{
+		cfg: kafka.ReaderConfig{Topic: "payments"},
+		messages: []kafka.Message{
+			{
+				Topic:   "payments",
+				Headers: []kafka.Header{{Key: "traceparent", Value: []byte(producerParentHdr)}},
+			},
+		},
+	}
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	wHelper, err := worker.NewHelper(tp, metric.NewMeterProvider())
+	if err != nil {
+		t.Fatalf("worker helper: %v", err)
+	}
+
+	opts = append(opts, WithPropagator(propagation.TraceContext{}))
+	consumer := NewConsumerWith(reader, wHelper, nil, opts...)
+
+	err = consumer.Run(context.Background(), func(context.Context, kafka.Message) error {
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled after draining, got %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected one job span, got %d", len(spans))
+	}
+
+	return spans[0]
+}
+
 func newWorkerHelper(t *testing.T) *worker.Helper {
 	t.Helper()
 
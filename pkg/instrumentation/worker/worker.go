@@ -11,25 +11,40 @@ import (
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/noop"
 	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hyp3rd/observe/pkg/baggage"
 )
 
 // JobInfo contains metadata describing a worker job execution.
+// Links relate the job span to spans in other traces (e.g. the producer of a message).
 type JobInfo struct {
 	Name       string
 	Queue      string
 	Schedule   string
 	Attributes []attribute.KeyValue
+	Links      []trace.Link
 }
 
 // Helper provides instrumentation helpers for background workers.
 type Helper struct {
-	tracer     trace.Tracer
-	jobCounter metric.Int64Counter
-	jobLatency metric.Float64Histogram
+	tracer        trace.Tracer
+	jobCounter    metric.Int64Counter
+	jobLatency    metric.Float64Histogram
+	metricBaggage *baggage.Promoter
+}
+
+// Option customizes Helper construction.
+type Option func(*Helper)
+
+// WithMetricBaggage promotes the allowlisted baggage members onto job metrics.
+func WithMetricBaggage(keys []string) Option {
+	return func(h *Helper) {
+		h.metricBaggage = baggage.NewPromoter(keys)
+	}
 }
 
 // NewHelper constructs a worker Helper.
-func NewHelper(tp trace.TracerProvider, mp metric.MeterProvider) (*Helper, error) {
+func NewHelper(tp trace.TracerProvider, mp metric.MeterProvider, opts ...Option) (*Helper, error) {
 	if tp == nil {
 		return nil, ewrap.New("tracer provider is nil")
 	}
@@ -58,11 +73,17 @@ func NewHelper(tp trace.TracerProvider, mp metric.MeterProvider) (*Helper, error
 		return nil, ewrap.Wrap(err, "create worker job latency histogram")
 	}
 
-	return &Helper{
+	helper := &Helper{
 		tracer:     tracer,
 		jobCounter: counter,
 		jobLatency: latency,
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(helper)
+	}
+
+	return helper, nil
 }
 
 // Instrument executes fn while recording tracing and metrics for the job.
@@ -75,11 +96,10 @@ func (h *Helper) Instrument(ctx context.Context, info JobInfo, fn func(context.C
 		info.Name = "worker-job"
 	}
 
-	ctx, span := h.tracer.Start(ctx, spanName(info))
-	start := time.Now()
-
 	attrs := jobAttributes(info)
-	span.SetAttributes(attrs...)
+
+	ctx, span := h.tracer.Start(ctx, spanName(info), trace.WithLinks(info.Links...), trace.WithAttributes(attrs...))
+	start := time.Now()
 
 	err := fn(ctx)
 	if err != nil {
@@ -92,11 +112,12 @@ func (h *Helper) Instrument(ctx context.Context, info JobInfo, fn func(context.C
 	span.End()
 
 	duration := float64(time.Since(start)) / float64(time.Millisecond)
-	h.jobLatency.Record(ctx, duration, metric.WithAttributes(attrs...))
+	metricAttrs := h.metricBaggage.Append(ctx, attrs)
+	h.jobLatency.Record(ctx, duration, metric.WithAttributes(metricAttrs...))
 
 	statusAttr := attribute.String("worker.result", resultTag(err))
 
-	countAttrs := append([]attribute.KeyValue{}, attrs...)
+	countAttrs := append([]attribute.KeyValue{}, metricAttrs...)
 	countAttrs = append(countAttrs, statusAttr)
 	h.jobCounter.Add(ctx, 1, metric.WithAttributes(countAttrs...))
 
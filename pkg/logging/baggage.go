@@ -0,0 +1,37 @@
+package logging
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/hyp3rd/observe/pkg/baggage"
+)
+
+// NewBaggageAdapter decorates adapter so every log record carries the allowlisted
+// baggage members found in the context. The adapter is returned unchanged when keys is empty.
+func NewBaggageAdapter(adapter Adapter, keys []string) Adapter {
+	promoter := baggage.NewPromoter(keys)
+	if adapter == nil || promoter == nil {
+		return adapter
+	}
+
+	return &baggageAdapter{inner: adapter, promoter: promoter}
+}
+
+type baggageAdapter struct {
+	inner    Adapter
+	promoter *baggage.Promoter
+}
+
+func (b *baggageAdapter) Info(ctx context.Context, msg string, attrs ...attribute.KeyValue) {
+	b.inner.Info(ctx, msg, b.promoter.Append(ctx, attrs)...)
+}
+
+func (b *baggageAdapter) Debug(ctx context.Context, msg string, attrs ...attribute.KeyValue) {
+	b.inner.Debug(ctx, msg, b.promoter.Append(ctx, attrs)...)
+}
+
+func (b *baggageAdapter) Error(ctx context.Context, err error, msg string, attrs ...attribute.KeyValue) {
+	b.inner.Error(ctx, err, msg, b.promoter.Append(ctx, attrs)...)
+}
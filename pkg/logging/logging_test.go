@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"go.opentelemetry.io/otel/attribute"
+	otelbaggage "go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/sdk/trace"
 )
 
@@ -66,6 +67,38 @@ func TestSlogAdapterWritesTraceAttributes(t *testing.T) {
 	}
 }
 
+func TestBaggageAdapterAddsAllowlistedMembers(t *testing.T) {
+	t.Parallel()
+
+	member, err := otelbaggage.NewMember("tenant.id", "acme")
+	if err != nil {
+		t.Fatalf("new member: %v", err)
+	}
+
+	bag, err := otelbaggage.New(member)
+	if err != nil {
+		t.Fatalf("new baggage: %v", err)
+	}
+
+	ctx := otelbaggage.ContextWithBaggage(context.Background(), bag)
+
+	var buf bytes.Buffer
+
+	adapter := NewBaggageAdapter(NewSlogAdapter(slogLogger(&buf)), []string{"tenant.id"})
+	adapter.Info(ctx, "hello")
+
+	var entry map[string]any
+
+	err = json.Unmarshal(buf.Bytes(), &entry)
+	if err != nil {
+		t.Fatalf("unmarshal slog output: %v", err)
+	}
+
+	if entry["tenant.id"] != "acme" {
+		t.Fatalf("expected tenant.id attribute, got %v", entry)
+	}
+}
+
 func slogLogger(buf *bytes.Buffer) *slog.Logger {
 	handler := slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})
 
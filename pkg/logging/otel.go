@@ -0,0 +1,116 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+// OTelScopeName is the instrumentation scope of records emitted by the OTel adapter.
+const OTelScopeName = "github.com/hyp3rd/observe/pkg/logging"
+
+// OTelOption customizes the OTel adapter.
+type OTelOption func(*otelAdapter)
+
+// WithTee also forwards every call to adapter, typically the local console logger, so records
+// are written locally and shipped to the collector.
+func WithTee(adapter Adapter) OTelOption {
+	return func(o *otelAdapter) {
+		if adapter != nil {
+			o.tee = adapter
+		}
+	}
+}
+
+// NewOTelAdapter returns an Adapter that emits every call as an OpenTelemetry log record through
+// provider. The record carries the severity, the message as body, the attributes and the span
+// context found in ctx, so backends correlate logs with traces natively.
+// A nil provider yields the tee adapter alone (or a no-op adapter).
+func NewOTelAdapter(provider otellog.LoggerProvider, opts ...OTelOption) Adapter {
+	adapter := &otelAdapter{tee: NewNoopAdapter()}
+	for _, opt := range opts {
+		opt(adapter)
+	}
+
+	if provider == nil {
+		return adapter.tee
+	}
+
+	adapter.logger = provider.Logger(OTelScopeName)
+
+	return adapter
+}
+
+type otelAdapter struct {
+	logger otellog.Logger
+	tee    Adapter
+}
+
+// Debug implements Adapter.
+func (o *otelAdapter) Debug(ctx context.Context, msg string, attrs ...attribute.KeyValue) {
+	o.tee.Debug(ctx, msg, attrs...)
+	o.emit(ctx, otellog.SeverityDebug, nil, msg, attrs)
+}
+
+// Info implements Adapter.
+func (o *otelAdapter) Info(ctx context.Context, msg string, attrs ...attribute.KeyValue) {
+	o.tee.Info(ctx, msg, attrs...)
+	o.emit(ctx, otellog.SeverityInfo, nil, msg, attrs)
+}
+
+// Error implements Adapter.
+func (o *otelAdapter) Error(ctx context.Context, err error, msg string, attrs ...attribute.KeyValue) {
+	o.tee.Error(ctx, err, msg, attrs...)
+	o.emit(ctx, otellog.SeverityError, err, msg, attrs)
+}
+
+// emit builds the record; the SDK reads the span context from ctx, so trace and span IDs are
+// set as record fields rather than duplicated as attributes.
+func (o *otelAdapter) emit(
+	ctx context.Context,
+	severity otellog.Severity,
+	err error,
+	msg string,
+	attrs []attribute.KeyValue,
+) {
+	if !o.logger.Enabled(ctx, otellog.EnabledParameters{Severity: severity}) {
+		return
+	}
+
+	var record otellog.Record
+
+	now := time.Now()
+	record.SetTimestamp(now)
+	record.SetObservedTimestamp(now)
+	record.SetSeverity(severity)
+	record.SetSeverityText(severityText(severity))
+	record.SetBody(otellog.StringValue(msg))
+
+	for _, attr := range attrs {
+		record.AddAttributes(otellog.KeyValueFromAttribute(attr))
+	}
+
+	if err != nil {
+		record.AddAttributes(
+			otellog.String("exception.type", fmt.Sprintf("%T", err)),
+			otellog.String("exception.message", err.Error()),
+		)
+	}
+
+	o.logger.Emit(ctx, record)
+}
+
+func severityText(severity otellog.Severity) string {
+	//nolint:exhaustive // the adapter only emits these three severities.
+	switch severity {
+	case otellog.SeverityDebug:
+		return "DEBUG"
+	case otellog.SeverityError:
+		return "ERROR"
+	default:
+		return "INFO"
+	}
+}
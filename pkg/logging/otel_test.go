@@ -0,0 +1,97 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+type recordingLogExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (r *recordingLogExporter) Export(_ context.Context, records []sdklog.Record) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, record := range records {
+		r.records = append(r.records, record.Clone())
+	}
+
+	return nil
+}
+
+func (*recordingLogExporter) ForceFlush(context.Context) error { return nil }
+
+func (*recordingLogExporter) Shutdown(context.Context) error { return nil }
+
+func TestOTelAdapterEmitsCorrelatedRecords(t *testing.T) {
+	t.Parallel()
+
+	exporter := &recordingLogExporter{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+
+	var buf bytes.Buffer
+
+	adapter := NewOTelAdapter(provider, WithTee(NewSlogAdapter(slogLogger(&buf))))
+
+	ctx, span := trace.NewTracerProvider().Tracer("test").Start(context.Background(), "span")
+	adapter.Debug(ctx, "warming cache", attribute.Int("entries", 3))
+	adapter.Error(ctx, errors.New("boom"), "payment failed", attribute.String("order.id", "o-1"))
+	span.End()
+
+	if len(exporter.records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(exporter.records))
+	}
+
+	debug, failure := exporter.records[0], exporter.records[1]
+	if debug.Severity() != otellog.SeverityDebug || debug.SeverityText() != "DEBUG" {
+		t.Fatalf("unexpected debug severity %v %q", debug.Severity(), debug.SeverityText())
+	}
+
+	if failure.Severity() != otellog.SeverityError || failure.Body().AsString() != "payment failed" {
+		t.Fatalf("unexpected error record %v %q", failure.Severity(), failure.Body().AsString())
+	}
+
+	if failure.TraceID() != span.SpanContext().TraceID() || failure.SpanID() != span.SpanContext().SpanID() {
+		t.Fatal("expected the record to carry the span context")
+	}
+
+	attrs := map[string]string{}
+
+	failure.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value.String()
+
+		return true
+	})
+
+	if attrs["order.id"] != "o-1" || attrs["exception.message"] != "boom" {
+		t.Fatalf("expected caller and exception attributes, got %v", attrs)
+	}
+
+	if _, ok := attrs["trace_id"]; ok {
+		t.Fatal("trace context must be record fields, not attributes")
+	}
+
+	if !strings.Contains(buf.String(), "payment failed") {
+		t.Fatalf("expected the tee adapter to log locally, got %q", buf.String())
+	}
+}
+
+func TestOTelAdapterWithoutProviderFallsBackToTee(t *testing.T) {
+	t.Parallel()
+
+	local := NewNoopAdapter()
+	if got := NewOTelAdapter(nil, WithTee(local)); got != local {
+		t.Fatalf("expected the tee adapter, got %T", got)
+	}
+}
@@ -75,7 +75,7 @@ func Init(ctx context.Context, opts ...Option) (*Client, error) {
 	client := &Client{
 		runtime:      rt,
 		opts:         settings,
-		logger:       logger,
+		logger:       clientLogger(logger, cfg, rt),
 		metricsState: metricsState,
 		configDigest: digest,
 	}
@@ -105,6 +105,44 @@ func (c *Client) Runtime() *runtime.Runtime {
 	return c.runtime
 }
 
+// Logger returns the client's logging adapter. When an exporter handles the logs signal every
+// call is also emitted as an OpenTelemetry log record, correlated with the span in ctx.
+// The adapter follows configuration reloads.
+func (c *Client) Logger() logging.Adapter {
+	return reloadingLogger{client: c}
+}
+
+func (c *Client) currentLogger() logging.Adapter {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.logger
+}
+
+// clientLogger tees local onto the runtime's log exporters and decorates both with baggage.
+func clientLogger(local logging.Adapter, cfg config.Config, rt *runtime.Runtime) logging.Adapter {
+	logger := logging.NewOTelAdapter(rt.LoggerProvider(), logging.WithTee(local))
+
+	return logging.NewBaggageAdapter(logger, cfg.Propagation.Baggage.Attributes)
+}
+
+// reloadingLogger resolves the client's logger on every call so it survives runtime swaps.
+type reloadingLogger struct {
+	client *Client
+}
+
+func (l reloadingLogger) Debug(ctx context.Context, msg string, attrs ...attribute.KeyValue) {
+	l.client.currentLogger().Debug(ctx, msg, attrs...)
+}
+
+func (l reloadingLogger) Info(ctx context.Context, msg string, attrs ...attribute.KeyValue) {
+	l.client.currentLogger().Info(ctx, msg, attrs...)
+}
+
+func (l reloadingLogger) Error(ctx context.Context, err error, msg string, attrs ...attribute.KeyValue) {
+	l.client.currentLogger().Error(ctx, err, msg, attrs...)
+}
+
 // Config returns the active configuration snapshot.
 func (c *Client) Config() config.Config {
 	return c.Runtime().Config()
@@ -241,7 +279,6 @@ func (c *Client) reloadRuntime(ctx context.Context) {
 
 	if !c.opts.loggerOverride {
 		if logger := logging.FromConfig(cfg.Logging); logger != nil {
-			c.logger = logger
 			c.opts.logger = logger
 		}
 	}
@@ -260,16 +297,17 @@ func (c *Client) reloadRuntime(ctx context.Context) {
 		return
 	}
 
-	c.swapRuntime(ctx, rt)
+	c.swapRuntime(ctx, rt, clientLogger(c.opts.logger, cfg, rt))
 	c.metricsState.IncrementConfigReloads()
 	c.configDigest = digest
 	c.logger.Info(ctx, "runtime reloaded")
 }
 
-func (c *Client) swapRuntime(ctx context.Context, newRuntime *runtime.Runtime) {
+func (c *Client) swapRuntime(ctx context.Context, newRuntime *runtime.Runtime, logger logging.Adapter) {
 	c.mu.Lock()
 	old := c.runtime
 	c.runtime = newRuntime
+	c.logger = logger
 	c.mu.Unlock()
 
 	if old != nil {
@@ -26,6 +26,7 @@ func defaultOptions() options {
 	return options{
 		loaders: []config.Loader{
 			config.FileLoader{},
+			config.OTelEnvLoader{},
 			config.EnvLoader{},
 		},
 		logger:         nil,
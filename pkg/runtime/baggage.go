@@ -0,0 +1,34 @@
+package runtime
+
+import (
+	"context"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/hyp3rd/observe/pkg/baggage"
+)
+
+// baggageSpanProcessor copies allowlisted baggage members onto every span when it starts,
+// regardless of which instrumentation pack (or user code) created it.
+type baggageSpanProcessor struct {
+	promoter *baggage.Promoter
+}
+
+func (p baggageSpanProcessor) OnStart(parent context.Context, span sdktrace.ReadWriteSpan) {
+	attrs := p.promoter.Attributes(parent)
+	if len(attrs) == 0 {
+		return
+	}
+
+	span.SetAttributes(attrs...)
+}
+
+func (baggageSpanProcessor) OnEnd(sdktrace.ReadOnlySpan) {}
+
+func (baggageSpanProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+func (baggageSpanProcessor) ForceFlush(context.Context) error {
+	return nil
+}
@@ -0,0 +1,48 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelbaggage "go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/hyp3rd/observe/pkg/baggage"
+)
+
+func TestBaggageSpanProcessorPromotesAllowlistedMembers(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(baggageSpanProcessor{promoter: baggage.NewPromoter([]string{"tenant.id"})}),
+		sdktrace.WithSpanProcessor(recorder),
+	)
+
+	tenant, err := otelbaggage.NewMember("tenant.id", "acme")
+	if err != nil {
+		t.Fatalf("new member: %v", err)
+	}
+
+	secret, err := otelbaggage.NewMember("session", "s3cr3t")
+	if err != nil {
+		t.Fatalf("new member: %v", err)
+	}
+
+	bag, err := otelbaggage.New(tenant, secret)
+	if err != nil {
+		t.Fatalf("new baggage: %v", err)
+	}
+
+	ctx := otelbaggage.ContextWithBaggage(context.Background(), bag)
+
+	_, span := tp.Tracer("test").Start(ctx, "op")
+	span.End()
+
+	attrs := recorder.Ended()[0].Attributes()
+	if len(attrs) != 1 || attrs[0] != attribute.String("tenant.id", "acme") {
+		t.Fatalf("expected only tenant.id to be promoted, got %v", attrs)
+	}
+}
@@ -0,0 +1,268 @@
+package runtime
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyp3rd/ewrap"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/hyp3rd/observe/pkg/config"
+	"github.com/hyp3rd/observe/pkg/diagnostics"
+)
+
+const (
+	defaultBatchTimeout  = 5 * time.Second
+	defaultExportBatch   = 512
+	defaultExportTimeout = 30 * time.Second
+)
+
+// exportRecorder receives every export performed by the batch processor, for histograms.
+type exportRecorder func(ctx context.Context, spans int, latency time.Duration)
+
+// batchStats is maintained by instrumentedBatchProcessor: the live queue depth, spans
+// discarded because the queue was full, and the size and latency of exports. It is nil
+// for exporters that export synchronously.
+type batchStats struct {
+	limit        int64
+	depth        atomic.Int64
+	queueDropped atomic.Int64
+	batches      atomic.Int64
+	spans        atomic.Int64
+	exportNanos  atomic.Int64
+	lastBatch    atomic.Int64
+	lastLatency  atomic.Int64
+	recorder     atomic.Pointer[exportRecorder]
+}
+
+func (s *batchStats) recordExport(ctx context.Context, spans int, latency time.Duration) {
+	s.depth.Add(-int64(spans))
+	s.batches.Add(1)
+	s.spans.Add(int64(spans))
+	s.exportNanos.Add(latency.Nanoseconds())
+	s.lastBatch.Store(int64(spans))
+	s.lastLatency.Store(latency.Nanoseconds())
+
+	if recorder := s.recorder.Load(); recorder != nil {
+		(*recorder)(ctx, spans, latency)
+	}
+}
+
+func (s *batchStats) snapshot() *diagnostics.BatchStatus {
+	if s == nil {
+		return nil
+	}
+
+	status := &diagnostics.BatchStatus{
+		QueueDepth:        max(s.depth.Load(), 0),
+		QueueLimit:        s.limit,
+		QueueDropped:      s.queueDropped.Load(),
+		ExportedBatches:   s.batches.Load(),
+		ExportedSpans:     s.spans.Load(),
+		LastBatchSize:     s.lastBatch.Load(),
+		LastExportLatency: time.Duration(s.lastLatency.Load()),
+	}
+
+	if status.ExportedBatches > 0 {
+		status.AvgExportLatency = time.Duration(s.exportNanos.Load() / status.ExportedBatches)
+	}
+
+	return status
+}
+
+// instrumentedBatchProcessor batches ended spans like the SDK BatchSpanProcessor, but exposes
+// its queue depth, queue-full drops and export sizes and latencies through batchStats.
+type instrumentedBatchProcessor struct {
+	exporter      sdktrace.SpanExporter
+	stats         *batchStats
+	maxBatch      int
+	interval      time.Duration
+	exportTimeout time.Duration
+
+	queue   chan sdktrace.ReadOnlySpan
+	flushes chan chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+
+	// enqueue is held shared while OnEnd enqueues and exclusively while Shutdown sets stopped,
+	// so every enqueued span is in the queue before run drains it for the last time.
+	enqueue     sync.RWMutex
+	stopped     atomic.Bool
+	stopOnce    sync.Once
+	shutdownErr error
+}
+
+func newInstrumentedBatchProcessor(
+	exporter sdktrace.SpanExporter,
+	cfg config.BatchConfig,
+	stats *batchStats,
+) *instrumentedBatchProcessor {
+	queueSize := intOr(cfg.MaxQueueSize, defaultTraceQueueSize)
+
+	p := &instrumentedBatchProcessor{
+		exporter:      exporter,
+		stats:         stats,
+		maxBatch:      min(intOr(cfg.MaxExportBatch, defaultExportBatch), queueSize),
+		interval:      durationOr(cfg.Timeout, defaultBatchTimeout),
+		exportTimeout: durationOr(cfg.ExportTimeout, defaultExportTimeout),
+		queue:         make(chan sdktrace.ReadOnlySpan, queueSize),
+		flushes:       make(chan chan struct{}),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	if p.stats == nil {
+		p.stats = &batchStats{limit: int64(queueSize)}
+	}
+
+	go p.run()
+
+	return p
+}
+
+// OnStart implements sdktrace.SpanProcessor.
+func (*instrumentedBatchProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+// OnEnd enqueues sampled spans without blocking; spans that do not fit are counted as queue drops.
+func (p *instrumentedBatchProcessor) OnEnd(span sdktrace.ReadOnlySpan) {
+	if !span.SpanContext().IsSampled() {
+		return
+	}
+
+	p.enqueue.RLock()
+	defer p.enqueue.RUnlock()
+
+	if p.stopped.Load() {
+		return
+	}
+
+	select {
+	case p.queue <- span:
+		p.stats.depth.Add(1)
+	default:
+		p.stats.queueDropped.Add(1)
+	}
+}
+
+// ForceFlush exports every queued span before returning.
+func (p *instrumentedBatchProcessor) ForceFlush(ctx context.Context) error {
+	if p.stopped.Load() {
+		return nil
+	}
+
+	reply := make(chan struct{})
+
+	select {
+	case p.flushes <- reply:
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ewrap.Wrap(ctx.Err(), "flush span batch processor")
+	}
+
+	select {
+	case <-reply:
+		return nil
+	case <-ctx.Done():
+		return ewrap.Wrap(ctx.Err(), "flush span batch processor")
+	}
+}
+
+// Shutdown exports the remaining spans and shuts the exporter down. Like the SDK processor, it
+// stops waiting when ctx ends, but the exporter is still shut down once the queue is drained.
+// Later calls return the first call's error.
+func (p *instrumentedBatchProcessor) Shutdown(ctx context.Context) error {
+	p.stopOnce.Do(func() {
+		p.enqueue.Lock()
+		p.stopped.Store(true)
+		p.enqueue.Unlock()
+
+		close(p.stop)
+
+		result := make(chan error, 1)
+
+		go func() {
+			<-p.done
+
+			result <- p.exporter.Shutdown(ctx)
+		}()
+
+		select {
+		case p.shutdownErr = <-result:
+		case <-ctx.Done():
+			p.shutdownErr = ewrap.Wrap(ctx.Err(), "shutdown span batch processor")
+		}
+	})
+
+	return p.shutdownErr
+}
+
+func (p *instrumentedBatchProcessor) run() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	batch := make([]sdktrace.ReadOnlySpan, 0, p.maxBatch)
+
+	for {
+		select {
+		case <-p.stop:
+			p.drain(batch)
+
+			return
+		case reply := <-p.flushes:
+			batch = p.drain(batch)
+
+			close(reply)
+		case <-ticker.C:
+			batch = p.export(batch)
+		case span := <-p.queue:
+			batch = append(batch, span)
+			if len(batch) >= p.maxBatch {
+				batch = p.export(batch)
+
+				ticker.Reset(p.interval)
+			}
+		}
+	}
+}
+
+// drain exports everything queued so far, in batches of at most maxBatch spans.
+func (p *instrumentedBatchProcessor) drain(batch []sdktrace.ReadOnlySpan) []sdktrace.ReadOnlySpan {
+	for {
+		select {
+		case span := <-p.queue:
+			batch = append(batch, span)
+			if len(batch) >= p.maxBatch {
+				batch = p.export(batch)
+			}
+		default:
+			return p.export(batch)
+		}
+	}
+}
+
+func (p *instrumentedBatchProcessor) export(batch []sdktrace.ReadOnlySpan) []sdktrace.ReadOnlySpan {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.exportTimeout)
+	defer cancel()
+
+	start := time.Now()
+
+	err := p.exporter.ExportSpans(ctx, batch)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	p.stats.recordExport(ctx, len(batch), time.Since(start))
+
+	clear(batch)
+
+	return batch[:0]
+}
@@ -0,0 +1,165 @@
+package runtime
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/hyp3rd/observe/pkg/config"
+)
+
+type blockingSpanExporter struct {
+	started   chan struct{}
+	release   chan struct{}
+	exported  atomic.Int64
+	shutdowns atomic.Int64
+}
+
+func (b *blockingSpanExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	select {
+	case b.started <- struct{}{}:
+	default:
+	}
+
+	<-b.release
+	b.exported.Add(int64(len(spans)))
+
+	return nil
+}
+
+func (b *blockingSpanExporter) Shutdown(context.Context) error {
+	b.shutdowns.Add(1)
+
+	return nil
+}
+
+func TestInstrumentedBatchProcessorCountsQueueFullDrops(t *testing.T) {
+	t.Parallel()
+
+	exporter := &blockingSpanExporter{started: make(chan struct{}, 1), release: make(chan struct{})}
+	stats := &batchStats{limit: 2}
+
+	var recorded atomic.Int64
+
+	recorder := exportRecorder(func(_ context.Context, spans int, _ time.Duration) {
+		recorded.Add(int64(spans))
+	})
+	stats.recorder.Store(&recorder)
+
+	processor := newInstrumentedBatchProcessor(exporter, config.BatchConfig{
+		Enabled:        true,
+		MaxExportBatch: 1,
+		MaxQueueSize:   2,
+		Timeout:        time.Hour,
+	}, stats)
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(processor)).Tracer("test")
+
+	endSpan := func() {
+		_, span := tracer.Start(context.Background(), "op")
+		span.End()
+	}
+
+	// The first span is picked up and blocks the exporter; two more fill the queue.
+	endSpan()
+	<-exporter.started
+	endSpan()
+	endSpan()
+	endSpan()
+	endSpan()
+
+	status := stats.snapshot()
+	if status.QueueDepth != 3 || status.QueueDropped != 2 || status.QueueLimit != 2 {
+		t.Fatalf("expected 3 pending spans and 2 queue-full drops, got %+v", status)
+	}
+
+	close(exporter.release)
+
+	err := processor.ForceFlush(context.Background())
+	if err != nil {
+		t.Fatalf("ForceFlush: %v", err)
+	}
+
+	status = stats.snapshot()
+	if status.QueueDepth != 0 || status.ExportedBatches != 3 || status.ExportedSpans != 3 || status.LastBatchSize != 1 {
+		t.Fatalf("expected the queue to drain in three batches, got %+v", status)
+	}
+
+	if exporter.exported.Load() != 3 || recorded.Load() != 3 {
+		t.Fatalf("expected 3 exported and recorded spans, got %d and %d", exporter.exported.Load(), recorded.Load())
+	}
+
+	err = processor.Shutdown(context.Background())
+	if err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	endSpan()
+
+	if stats.snapshot().QueueDropped != 2 {
+		t.Fatal("expected spans ended after shutdown to be ignored")
+	}
+}
+
+func TestInstrumentedBatchProcessorShutsDownExporterAfterShutdownTimesOut(t *testing.T) {
+	t.Parallel()
+
+	exporter := &blockingSpanExporter{started: make(chan struct{}, 1), release: make(chan struct{})}
+	processor := newInstrumentedBatchProcessor(exporter, config.BatchConfig{Enabled: true, Timeout: time.Hour}, nil)
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(processor)).Tracer("test")
+
+	_, span := tracer.Start(context.Background(), "op")
+	span.End()
+
+	// The final drain blocks in the exporter until released.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := processor.Shutdown(ctx)
+	if err == nil {
+		t.Fatal("expected Shutdown to report the expired context")
+	}
+
+	<-exporter.started
+
+	if again := processor.Shutdown(context.Background()); again == nil || again.Error() != err.Error() {
+		t.Fatalf("expected later calls to return the first error, got %v", again)
+	}
+
+	close(exporter.release)
+
+	deadline := time.Now().Add(time.Second)
+	for exporter.shutdowns.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if exporter.shutdowns.Load() != 1 || exporter.exported.Load() != 1 {
+		t.Fatalf("expected the drained span to be exported and the exporter shut down, got %d exported and %d shutdowns",
+			exporter.exported.Load(), exporter.shutdowns.Load())
+	}
+
+	if depth := processor.stats.snapshot().QueueDepth; depth != 0 {
+		t.Fatalf("expected an empty queue after shutdown, got depth %d", depth)
+	}
+}
+
+func TestSnapshotAggregatesBatchQueues(t *testing.T) {
+	t.Parallel()
+
+	stats := &traceExporterStats{queueLimit: 2048, batch: &batchStats{limit: 2048}}
+	stats.batch.depth.Store(7)
+	stats.batch.queueDropped.Store(3)
+
+	rt := &Runtime{exporters: &exporterBundle{traces: []traceExport{{name: "otlp", stats: stats}}}}
+
+	snap := rt.Snapshot()
+	if snap.TraceQueueDepth != 7 || snap.TraceQueueDropped != 3 {
+		t.Fatalf("expected queue depth 7 and 3 queue-full drops, got %d and %d", snap.TraceQueueDepth, snap.TraceQueueDropped)
+	}
+
+	if batch := snap.TraceExporters[0].Batch; batch == nil || batch.QueueLimit != 2048 {
+		t.Fatalf("expected the exporter status to carry batch stats, got %+v", batch)
+	}
+}
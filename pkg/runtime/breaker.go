@@ -0,0 +1,237 @@
+package runtime
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyp3rd/observe/pkg/config"
+	"github.com/hyp3rd/observe/pkg/diagnostics"
+)
+
+const (
+	breakerClosed   = "closed"
+	breakerOpen     = "open"
+	breakerHalfOpen = "half_open"
+
+	defaultBreakerThreshold   = 0.5
+	defaultBreakerWindow      = 20
+	defaultBreakerMinRequests = 5
+	defaultBreakerCooldown    = 30 * time.Second
+	defaultBreakerProbes      = 1
+)
+
+// errCircuitOpen is returned instead of exporting while the circuit is open, so a persistent
+// queue in front spools the batch rather than losing it.
+var errCircuitOpen = errors.New("exporter circuit breaker is open")
+
+// circuitBreaker tracks the outcome of recent exports and short-circuits an exporter that
+// keeps failing. Closed lets every batch through; open rejects them until the cool-down
+// elapses; half-open admits a limited number of probe batches whose outcome decides
+// whether the circuit closes again or reopens.
+type circuitBreaker struct {
+	threshold   float64
+	minRequests int
+	cooldown    time.Duration
+	probes      int
+	now         func() time.Time
+
+	mu       sync.Mutex
+	state    string
+	outcomes []bool // ring buffer of recent results, true for failures
+	next     int
+	filled   int
+	failures int
+	openedAt time.Time
+	inflight int
+	passed   int
+	changed  time.Time
+
+	transitions atomic.Int64
+	rejected    atomic.Int64
+}
+
+// newCircuitBreaker returns nil when the breaker is not enabled; a nil breaker admits everything.
+func newCircuitBreaker(cfg *config.CircuitBreakerConfig) *circuitBreaker {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	window := intOr(cfg.Window, defaultBreakerWindow)
+
+	threshold := defaultBreakerThreshold
+	if cfg.ErrorThreshold != nil {
+		threshold = *cfg.ErrorThreshold
+	}
+
+	return &circuitBreaker{
+		threshold:   threshold,
+		minRequests: min(intOr(cfg.MinRequests, defaultBreakerMinRequests), window),
+		cooldown:    durationOr(cfg.Cooldown, defaultBreakerCooldown),
+		probes:      intOr(cfg.ProbeBatches, defaultBreakerProbes),
+		now:         time.Now,
+		state:       breakerClosed,
+		outcomes:    make([]bool, window),
+	}
+}
+
+// allow reports whether a batch may be exported. Every admitted batch must be followed by record.
+func (b *circuitBreaker) allow() bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerOpen && b.now().Sub(b.openedAt) >= b.cooldown {
+		b.transition(breakerHalfOpen)
+	}
+
+	switch b.state {
+	case breakerOpen:
+		return false
+	case breakerHalfOpen:
+		if b.inflight+b.passed >= b.probes {
+			return false
+		}
+
+		b.inflight++
+
+		return true
+	default:
+		return true
+	}
+}
+
+// record feeds the outcome of an admitted batch back into the breaker.
+func (b *circuitBreaker) record(err error) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerHalfOpen:
+		b.inflight = max(b.inflight-1, 0)
+
+		if err != nil {
+			b.trip()
+
+			return
+		}
+
+		b.passed++
+		if b.passed >= b.probes {
+			b.reset()
+			b.transition(breakerClosed)
+		}
+	case breakerClosed:
+		b.observe(err != nil)
+
+		if b.filled >= b.minRequests && float64(b.failures) >= b.threshold*float64(b.filled) {
+			b.trip()
+		}
+	default:
+		// A batch admitted before the circuit opened; its outcome no longer matters.
+	}
+}
+
+// reject counts items dropped without being exported while the circuit is open.
+func (b *circuitBreaker) reject(items int64) {
+	if b == nil || items <= 0 {
+		return
+	}
+
+	b.rejected.Add(items)
+}
+
+// shortCircuit handles a batch rejected by the open circuit. It is dropped without an error,
+// or handed back to the persistent queue in front of the exporter when queued is set.
+func (b *circuitBreaker) shortCircuit(items int, queued bool) error {
+	b.reject(int64(items))
+
+	if queued {
+		return errCircuitOpen
+	}
+
+	return nil
+}
+
+func (b *circuitBreaker) observe(failed bool) {
+	if b.filled == len(b.outcomes) && b.outcomes[b.next] {
+		b.failures--
+	}
+
+	b.outcomes[b.next] = failed
+	if failed {
+		b.failures++
+	}
+
+	b.next = (b.next + 1) % len(b.outcomes)
+	b.filled = min(b.filled+1, len(b.outcomes))
+}
+
+func (b *circuitBreaker) trip() {
+	b.openedAt = b.now()
+	b.inflight = 0
+	b.passed = 0
+	b.transition(breakerOpen)
+}
+
+func (b *circuitBreaker) reset() {
+	clear(b.outcomes)
+	b.next, b.filled, b.failures = 0, 0, 0
+	b.inflight, b.passed = 0, 0
+}
+
+func (b *circuitBreaker) transition(state string) {
+	if b.state == state {
+		return
+	}
+
+	b.state = state
+	b.changed = b.now().UTC()
+	b.transitions.Add(1)
+}
+
+func (b *circuitBreaker) snapshot() *diagnostics.CircuitStatus {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	state, changed := b.state, b.changed
+	b.mu.Unlock()
+
+	return &diagnostics.CircuitStatus{
+		State:          state,
+		Transitions:    b.transitions.Load(),
+		Rejected:       b.rejected.Load(),
+		LastTransition: changed,
+	}
+}
+
+// breakerFor resolves the circuit breaker settings for spec, mirroring queueFor.
+func breakerFor(spec config.ExporterSpec) *config.CircuitBreakerConfig {
+	if spec.CircuitBreaker != nil {
+		return spec.CircuitBreaker
+	}
+
+	if spec.OTLP != nil {
+		return spec.OTLP.CircuitBreaker
+	}
+
+	return nil
+}
+
+func intOr(value, fallback int) int {
+	if value > 0 {
+		return value
+	}
+
+	return fallback
+}
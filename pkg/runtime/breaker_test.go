@@ -0,0 +1,129 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hyp3rd/ewrap"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/hyp3rd/observe/pkg/config"
+	"github.com/hyp3rd/observe/pkg/exporters"
+)
+
+func TestCircuitBreakerTransitions(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	threshold := 0.5
+	breaker := newCircuitBreaker(&config.CircuitBreakerConfig{
+		Enabled:        true,
+		ErrorThreshold: &threshold,
+		Window:         4,
+		MinRequests:    4,
+		Cooldown:       time.Minute,
+		ProbeBatches:   2,
+	})
+	breaker.now = func() time.Time { return now }
+
+	failure := ewrap.New("collector unavailable")
+
+	for _, err := range []error{nil, failure, nil} {
+		if !breaker.allow() {
+			t.Fatal("expected a closed circuit to admit batches")
+		}
+
+		breaker.record(err)
+	}
+
+	// Two failures out of four reach the 50% threshold.
+	breaker.allow()
+	breaker.record(failure)
+
+	if state := breaker.snapshot().State; state != breakerOpen {
+		t.Fatalf("expected the circuit to open, got %s", state)
+	}
+
+	if breaker.allow() {
+		t.Fatal("expected an open circuit to reject batches before the cool-down")
+	}
+
+	now = now.Add(time.Minute)
+
+	if !breaker.allow() || !breaker.allow() {
+		t.Fatal("expected the half-open circuit to admit the probe batches")
+	}
+
+	if breaker.allow() {
+		t.Fatal("expected batches beyond the probes to be rejected")
+	}
+
+	breaker.record(nil)
+	breaker.record(failure)
+
+	if state := breaker.snapshot().State; state != breakerOpen {
+		t.Fatalf("expected a failed probe to reopen the circuit, got %s", state)
+	}
+
+	now = now.Add(time.Minute)
+
+	for range 2 {
+		breaker.allow()
+		breaker.record(nil)
+	}
+
+	status := breaker.snapshot()
+	if status.State != breakerClosed || status.Transitions != 5 || !status.LastTransition.Equal(now.UTC()) {
+		t.Fatalf("expected the circuit to close after successful probes, got %+v", status)
+	}
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	t.Parallel()
+
+	breaker := newCircuitBreaker(&config.CircuitBreakerConfig{})
+	if breaker != nil || !breaker.allow() || breaker.snapshot() != nil {
+		t.Fatal("expected a disabled breaker to admit everything and report nothing")
+	}
+}
+
+func TestSpanExporterWithStatsShortCircuitsWhileOpen(t *testing.T) {
+	t.Parallel()
+
+	spec := config.ExporterSpec{
+		Name:           "collector",
+		Type:           "failing",
+		CircuitBreaker: &config.CircuitBreakerConfig{Enabled: true, Window: 2, MinRequests: 2, Cooldown: time.Hour},
+	}
+
+	stats := newTraceExporterStats(spec, exporters.Target{Protocol: "failing"}, config.BatchConfig{})
+	stats.breaker = newCircuitBreaker(breakerFor(spec))
+
+	exporter, err := withSpanQueue(context.Background(), spec, failingSpanExporter{}, stats)
+	if err != nil {
+		t.Fatalf("withSpanQueue: %v", err)
+	}
+
+	spans := tracetest.SpanStubs{{Name: "a"}, {Name: "b"}}.Snapshots()
+
+	for range 2 {
+		if exporter.ExportSpans(context.Background(), spans) == nil {
+			t.Fatal("expected the failing exporter to return errors while closed")
+		}
+	}
+
+	err = exporter.ExportSpans(context.Background(), spans)
+	if err != nil {
+		t.Fatalf("expected the open circuit to drop the batch without an error, got %v", err)
+	}
+
+	status := stats.statusSnapshot()
+	if status.Circuit == nil || status.Circuit.State != breakerOpen || status.Circuit.Rejected != 2 {
+		t.Fatalf("expected an open circuit with rejected spans, got %+v", status.Circuit)
+	}
+
+	if status.ErrorCount != 2 || status.Dropped != 4 {
+		t.Fatalf("expected rejected spans to be counted apart from export failures, got %+v", status)
+	}
+}
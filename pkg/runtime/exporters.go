@@ -2,52 +2,79 @@ package runtime
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
 	"errors"
-	"os"
+	"slices"
 	"strings"
 	"sync/atomic"
 	"time"
 
 	"github.com/hyp3rd/ewrap"
-	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
-	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
-	"google.golang.org/grpc/credentials"
 
 	"github.com/hyp3rd/observe/pkg/config"
 	"github.com/hyp3rd/observe/pkg/diagnostics"
+	"github.com/hyp3rd/observe/pkg/exporters"
 )
 
 // ErrTLSNotEnabled is returned when TLS configuration is incomplete.
-var ErrTLSNotEnabled = ewrap.New("tls is not enabled").WithContext(
-	&ewrap.ErrorContext{
-		Severity: ewrap.SeverityError,
-		Type:     ewrap.ErrorTypeConfiguration,
-	},
+var ErrTLSNotEnabled = exporters.ErrTLSNotEnabled
+
+const (
+	defaultTraceQueueSize = 2048
+	defaultMetricInterval = time.Minute
 )
 
+// exporterBundle holds every configured exporter, fanned out per signal.
 type exporterBundle struct {
-	traceExporter  sdktrace.SpanExporter
-	metricExporter sdkmetric.Exporter
-	metricReader   *sdkmetric.PeriodicReader
-	traceStats     *traceExporterStats
-	metricStats    *metricExporterStats
+	traces  []traceExport
+	metrics []metricExport
+	logs    []logExport
+}
+
+// traceExport pairs a span exporter with its stats and batch settings.
+type traceExport struct {
+	name     string
+	exporter sdktrace.SpanExporter
+	stats    *traceExporterStats
+	batch    config.BatchConfig
+}
+
+// metricExport pairs a metric exporter with the reader that drives it.
+type metricExport struct {
+	name     string
+	exporter sdkmetric.Exporter
+	reader   *sdkmetric.PeriodicReader
+	stats    *metricExporterStats
+}
+
+// logExport pairs a log exporter with its stats and batch settings. Log records are queued
+// and batched like spans, so they share the trace stats bookkeeping.
+type logExport struct {
+	name     string
+	exporter sdklog.Exporter
+	stats    *traceExporterStats
+	batch    config.BatchConfig
 }
 
 type traceExporterStats struct {
-	queueLimit  int64
-	dropped     atomic.Int64
-	protocol    string
-	endpoint    string
-	lastError   atomic.Pointer[exporterError]
-	lastSuccess atomic.Pointer[time.Time]
-	errorCount  atomic.Int64
+	name         string
+	exporterType string
+	queueLimit   int64
+	dropped      atomic.Int64
+	protocol     string
+	endpoint     string
+	lastError    atomic.Pointer[exporterError]
+	lastSuccess  atomic.Pointer[time.Time]
+	errorCount   atomic.Int64
+	authErrors   atomic.Int64
+	queue        func() exporters.QueueStats
+	breaker      *circuitBreaker
+	tls          *config.TLSConfig
+	batch        *batchStats
+	failover     *endpointFailover
 }
 
 type exporterError struct {
@@ -56,28 +83,33 @@ type exporterError struct {
 }
 
 type metricExporterStats struct {
-	protocol    string
-	endpoint    string
-	lastError   atomic.Pointer[exporterError]
-	lastSuccess atomic.Pointer[time.Time]
-	errorCount  atomic.Int64
-}
-
-func newTraceExporterStats(cfg *config.OTLPConfig) *traceExporterStats {
-	limit := int64(cfg.Batch.MaxQueueSize)
+	name         string
+	exporterType string
+	protocol     string
+	endpoint     string
+	lastError    atomic.Pointer[exporterError]
+	lastSuccess  atomic.Pointer[time.Time]
+	errorCount   atomic.Int64
+	authErrors   atomic.Int64
+	queue        func() exporters.QueueStats
+	breaker      *circuitBreaker
+	tls          *config.TLSConfig
+	failover     *endpointFailover
+}
+
+func newTraceExporterStats(spec config.ExporterSpec, target exporters.Target, batch config.BatchConfig) *traceExporterStats {
+	limit := int64(batch.MaxQueueSize)
 	if limit <= 0 {
-		limit = 2048
-	}
-
-	protocol := cfg.Protocol
-	if protocol == "" {
-		protocol = "grpc"
+		limit = defaultTraceQueueSize
 	}
 
 	return &traceExporterStats{
-		queueLimit: limit,
-		protocol:   strings.ToLower(protocol),
-		endpoint:   cfg.Endpoint,
+		name:         spec.Name,
+		exporterType: strings.ToLower(spec.Type),
+		queueLimit:   limit,
+		protocol:     strings.ToLower(target.Protocol),
+		endpoint:     target.Endpoint,
+		tls:          tlsFor(spec),
 	}
 }
 
@@ -95,6 +127,11 @@ func (s *traceExporterStats) recordError(err error) {
 	}
 
 	s.errorCount.Add(1)
+
+	if exporters.IsAuthError(err) {
+		s.authErrors.Add(1)
+	}
+
 	s.lastError.Store(&exporterError{
 		message: err.Error(),
 		time:    time.Now().UTC(),
@@ -112,8 +149,11 @@ func (s *traceExporterStats) recordSuccess() {
 
 func (s *traceExporterStats) statusSnapshot() diagnostics.ExporterStatus {
 	status := diagnostics.ExporterStatus{
+		Name:     s.name,
+		Type:     s.exporterType,
 		Protocol: strings.ToLower(s.protocol),
 		Endpoint: s.endpoint,
+		Dropped:  s.dropped.Load(),
 	}
 	if last := s.lastError.Load(); last != nil {
 		status.LastError = last.message
@@ -125,19 +165,27 @@ func (s *traceExporterStats) statusSnapshot() diagnostics.ExporterStatus {
 	}
 
 	status.ErrorCount = s.errorCount.Load()
+	status.AuthErrors = s.authErrors.Load()
+	status.Queue = queueStatus(s.queue)
+	status.Circuit = s.breaker.snapshot()
+	status.TLS = tlsStatus(s.tls)
+	status.Batch = s.batch.snapshot()
+	status.Failover = s.failover.snapshot()
+
+	if s.failover != nil {
+		status.Endpoint = s.failover.activeEndpoint()
+	}
 
 	return status
 }
 
-func newMetricExporterStats(cfg *config.OTLPConfig) *metricExporterStats {
-	protocol := cfg.Protocol
-	if protocol == "" {
-		protocol = "grpc"
-	}
-
+func newMetricExporterStats(spec config.ExporterSpec, target exporters.Target) *metricExporterStats {
 	return &metricExporterStats{
-		protocol: strings.ToLower(protocol),
-		endpoint: cfg.Endpoint,
+		name:         spec.Name,
+		exporterType: strings.ToLower(spec.Type),
+		protocol:     strings.ToLower(target.Protocol),
+		endpoint:     target.Endpoint,
+		tls:          tlsFor(spec),
 	}
 }
 
@@ -147,6 +195,11 @@ func (s *metricExporterStats) recordError(err error) {
 	}
 
 	s.errorCount.Add(1)
+
+	if exporters.IsAuthError(err) {
+		s.authErrors.Add(1)
+	}
+
 	s.lastError.Store(&exporterError{
 		message: err.Error(),
 		time:    time.Now().UTC(),
@@ -168,6 +221,8 @@ func (s *metricExporterStats) statusSnapshot() diagnostics.ExporterStatus {
 	}
 
 	status := diagnostics.ExporterStatus{
+		Name:     s.name,
+		Type:     s.exporterType,
 		Protocol: s.protocol,
 		Endpoint: s.endpoint,
 	}
@@ -182,330 +237,427 @@ func (s *metricExporterStats) statusSnapshot() diagnostics.ExporterStatus {
 	}
 
 	status.ErrorCount = s.errorCount.Load()
+	status.AuthErrors = s.authErrors.Load()
+	status.Queue = queueStatus(s.queue)
+	status.Circuit = s.breaker.snapshot()
+	status.TLS = tlsStatus(s.tls)
+	status.Failover = s.failover.snapshot()
+
+	if s.failover != nil {
+		status.Endpoint = s.failover.activeEndpoint()
+	}
 
 	return status
 }
 
-func newExporterBundle(ctx context.Context, cfg config.ExporterConfig) (*exporterBundle, error) {
-	if cfg.OTLP == nil {
-		return nil, ewrap.New("otlp exporter config is required")
+func queueStatus(stats func() exporters.QueueStats) *diagnostics.QueueStatus {
+	if stats == nil {
+		return nil
 	}
 
-	if cfg.OTLP.Endpoint == "" {
-		return nil, ewrap.New("otlp exporter endpoint is required")
-	}
+	q := stats()
 
-	traceExp, err := newOTLPTraceExporter(ctx, cfg.OTLP)
-	if err != nil {
-		return nil, err
-	}
+	return &diagnostics.QueueStatus{Depth: q.Depth, Bytes: q.Bytes, Replayed: q.Replayed, Evicted: q.Evicted}
+}
+
+// tlsFor returns the TLS files an exporter loads, or nil when it does not use TLS files.
+func tlsFor(spec config.ExporterSpec) *config.TLSConfig {
+	var tlsCfg *config.TLSConfig
 
-	traceStats := newTraceExporterStats(cfg.OTLP)
-	traceExp = &spanExporterWithStats{
-		inner: traceExp,
-		stats: traceStats,
+	switch {
+	case spec.OTLP != nil && !spec.OTLP.Insecure:
+		tlsCfg = &spec.OTLP.TLS
+	case spec.Zipkin != nil:
+		tlsCfg = &spec.Zipkin.TLS
+	default:
+		return nil
 	}
 
-	metricExp, err := newOTLPMetricExporter(ctx, cfg.OTLP)
-	if err != nil {
-		return nil, err
+	if tlsCfg.CAFile == "" && tlsCfg.CertFile == "" {
+		return nil
 	}
 
-	metricStats := newMetricExporterStats(cfg.OTLP)
-	metricExp = &metricExporterWithStats{
-		inner: metricExp,
-		stats: metricStats,
+	return tlsCfg
+}
+
+func tlsStatus(tlsCfg *config.TLSConfig) *diagnostics.TLSStatus {
+	if tlsCfg == nil {
+		return nil
 	}
 
-	reader := sdkmetric.NewPeriodicReader(
-		metricExp,
-		sdkmetric.WithInterval(time.Minute),
-	)
+	certs, ok := exporters.TLSCertificateStatus(*tlsCfg)
+	if !ok {
+		return nil
+	}
 
-	return &exporterBundle{
-		traceExporter:  traceExp,
-		metricExporter: metricExp,
-		metricReader:   reader,
-		traceStats:     traceStats,
-		metricStats:    metricStats,
-	}, nil
+	return &diagnostics.TLSStatus{
+		CertNotAfter: certs.CertNotAfter,
+		CANotAfter:   certs.CANotAfter,
+		Reloads:      certs.Reloads,
+		LastReload:   certs.LastReload,
+		LastError:    certs.LastError,
+	}
 }
 
-func (b *exporterBundle) shutdown(ctx context.Context) error {
-	var errs []error
+func newExporterBundle(ctx context.Context, cfg config.ExporterConfig, registry *exporters.Registry) (*exporterBundle, error) {
+	bundle := &exporterBundle{}
 
-	if b.metricReader != nil {
-		err := b.metricReader.Shutdown(ctx)
-		if err != nil {
-			errs = append(errs, err)
-		}
+	if !cfg.Exporting() {
+		return bundle, nil
 	}
 
-	if b.metricExporter != nil {
-		err := b.metricExporter.Shutdown(ctx)
-		if err != nil {
-			errs = append(errs, err)
+	for _, spec := range exporterSpecs(cfg) {
+		factory, ok := registry.Lookup(spec.Type)
+		if !ok {
+			return nil, errors.Join(
+				ewrap.Newf("exporter %q: unknown type %q", spec.Name, spec.Type),
+				bundle.shutdown(ctx),
+			)
 		}
-	}
 
-	if b.traceExporter != nil {
-		err := b.traceExporter.Shutdown(ctx)
+		err := bundle.add(ctx, spec, factory, cfg)
 		if err != nil {
-			errs = append(errs, err)
+			return nil, errors.Join(err, bundle.shutdown(ctx))
 		}
 	}
 
-	return errors.Join(errs...)
+	if len(bundle.traces) == 0 && len(bundle.metrics) == 0 && len(bundle.logs) == 0 && !prometheusEnabled(cfg) {
+		return nil, ewrap.New("at least one exporter is required")
+	}
+
+	return bundle, nil
 }
 
-func newOTLPTraceExporter(ctx context.Context, cfg *config.OTLPConfig) (sdktrace.SpanExporter, error) {
-	switch strings.ToLower(cfg.Protocol) {
-	case "http", "https":
-		opts, err := otlpHTTPOptions(cfg)
-		if err != nil {
-			return nil, err
-		}
+// exporterSpecs lists the primary OTLP exporter followed by the named backends. The primary
+// exporter only ships the signals that resolve to an endpoint.
+func exporterSpecs(cfg config.ExporterConfig) []config.ExporterSpec {
+	specs := make([]config.ExporterSpec, 0, len(cfg.Backends)+1)
 
-		exp, err := otlptracehttp.New(ctx, opts...)
-		if err != nil {
-			return nil, ewrap.Wrap(err, "create otlp http trace exporter")
-		}
+	if cfg.OTLP != nil {
+		var signals []string
 
-		return exp, nil
-	default:
-		opts, err := otlpGRPCOptions(cfg)
-		if err != nil {
-			return nil, err
+		for _, signal := range []exporters.Signal{exporters.SignalTraces, exporters.SignalMetrics, exporters.SignalLogs} {
+			if cfg.OTLP.ForSignal(string(signal)).Endpoint != "" {
+				signals = append(signals, string(signal))
+			}
 		}
 
-		exp, err := otlptracegrpc.New(ctx, opts...)
-		if err != nil {
-			return nil, ewrap.Wrap(err, "create otlp grpc trace exporter")
+		if len(signals) > 0 {
+			specs = append(specs, config.ExporterSpec{
+				Name:    exporters.TypeOTLP,
+				Type:    exporters.TypeOTLP,
+				Signals: signals,
+				OTLP:    cfg.OTLP,
+			})
 		}
+	}
 
-		return exp, nil
+	return append(specs, cfg.Backends...)
+}
+
+// specForSignal resolves per-signal OTLP overrides so builders and diagnostics see effective values.
+func specForSignal(spec config.ExporterSpec, signal exporters.Signal) config.ExporterSpec {
+	if spec.OTLP != nil {
+		resolved := spec.OTLP.ForSignal(string(signal))
+		spec.OTLP = &resolved
 	}
+
+	return spec
 }
 
-func newOTLPMetricExporter(ctx context.Context, cfg *config.OTLPConfig) (sdkmetric.Exporter, error) {
-	switch strings.ToLower(cfg.Protocol) {
-	case "http", "https":
-		opts, err := otlpMetricHTTPOptions(cfg)
-		if err != nil {
-			return nil, err
-		}
+// add builds spec's exporters for every signal it serves that cfg has not switched off.
+func (b *exporterBundle) add(
+	ctx context.Context,
+	spec config.ExporterSpec,
+	factory exporters.Factory,
+	cfg config.ExporterConfig,
+) error {
+	signals, err := signalsFor(spec, factory)
+	if err != nil {
+		return err
+	}
 
-		exp, err := otlpmetrichttp.New(ctx, opts...)
-		if err != nil {
-			return nil, ewrap.Wrap(err, "create otlp http metric exporter")
+	signals = slices.DeleteFunc(signals, func(signal exporters.Signal) bool {
+		return !cfg.SignalEnabled(string(signal))
+	})
+
+	for _, signal := range signals {
+		resolved := specForSignal(spec, signal)
+		target := factory.Target(resolved)
+		failover := newEndpointFailover(resolved)
+
+		switch signal {
+		case exporters.SignalTraces:
+			exp, err := buildTraceExporter(ctx, factory, resolved, failover)
+			if err != nil {
+				return ewrap.Wrapf(err, "build %s trace exporter", resolved.Name)
+			}
+
+			batch := batchFor(resolved)
+			stats := newTraceExporterStats(resolved, target, batch)
+			stats.breaker = newCircuitBreaker(breakerFor(resolved))
+			stats.failover = failover
+
+			if batch.Enabled {
+				stats.batch = &batchStats{limit: stats.queueLimit}
+			}
+
+			exporter, err := withSpanQueue(ctx, resolved, exp, stats)
+			if err != nil {
+				return err
+			}
+
+			b.traces = append(b.traces, traceExport{
+				name:     resolved.Name,
+				exporter: exporter,
+				stats:    stats,
+				batch:    batch,
+			})
+		case exporters.SignalMetrics:
+			exp, err := buildMetricExporter(ctx, factory, resolved, failover)
+			if err != nil {
+				return ewrap.Wrapf(err, "build %s metric exporter", resolved.Name)
+			}
+
+			stats := newMetricExporterStats(resolved, target)
+			stats.breaker = newCircuitBreaker(breakerFor(resolved))
+			stats.failover = failover
+
+			exporter, err := withMetricQueue(ctx, resolved, exp, stats)
+			if err != nil {
+				return err
+			}
+
+			b.metrics = append(b.metrics, metricExport{
+				name:     resolved.Name,
+				exporter: exporter,
+				reader:   newPeriodicReader(exporter, resolved.MetricReaderSettings()),
+				stats:    stats,
+			})
+		case exporters.SignalLogs:
+			exp, err := buildLogExporter(ctx, factory, resolved, failover)
+			if err != nil {
+				return ewrap.Wrapf(err, "build %s log exporter", resolved.Name)
+			}
+
+			batch := batchFor(resolved)
+			stats := newTraceExporterStats(resolved, target, batch)
+			stats.breaker = newCircuitBreaker(breakerFor(resolved))
+			stats.failover = failover
+
+			b.logs = append(b.logs, logExport{
+				name:     resolved.Name,
+				exporter: &logExporterWithStats{inner: exp, stats: stats, breaker: stats.breaker},
+				stats:    stats,
+				batch:    batch,
+			})
 		}
+	}
 
-		return exp, nil
-	default:
-		opts, err := otlpMetricGRPCOptions(cfg)
-		if err != nil {
-			return nil, err
+	return nil
+}
+
+// signalsFor resolves the signals requested by spec, defaulting to everything the factory supports.
+func signalsFor(spec config.ExporterSpec, factory exporters.Factory) ([]exporters.Signal, error) {
+	if len(spec.Signals) == 0 {
+		return factory.Signals(), nil
+	}
+
+	signals := make([]exporters.Signal, 0, len(spec.Signals))
+	for _, raw := range spec.Signals {
+		signal := exporters.Signal(strings.ToLower(strings.TrimSpace(raw)))
+		if !factory.Supports(signal) {
+			return nil, ewrap.Newf("exporter %q (%s) does not support signal %q", spec.Name, spec.Type, raw)
 		}
 
-		exp, err := otlpmetricgrpc.New(ctx, opts...)
-		if err != nil {
-			return nil, ewrap.Wrap(err, "create otlp grpc metric exporter")
+		if slices.Contains(signals, signal) {
+			continue
 		}
 
-		return exp, nil
+		signals = append(signals, signal)
 	}
+
+	return signals, nil
 }
 
-func otlpGRPCOptions(cfg *config.OTLPConfig) ([]otlptracegrpc.Option, error) {
-	opts := []otlptracegrpc.Option{
-		otlptracegrpc.WithEndpoint(cfg.Endpoint),
+func batchFor(spec config.ExporterSpec) config.BatchConfig {
+	if spec.Batch != nil {
+		return *spec.Batch
 	}
-	if cfg.Insecure {
-		opts = append(opts, otlptracegrpc.WithInsecure())
-	} else {
-		tlsCfg, err := tlsConfigFrom(cfg.TLS)
-		if err != nil && !ErrTLSNotEnabled.Is(err) {
-			return nil, err
-		}
 
-		if tlsCfg != nil {
-			opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
-		}
+	if spec.OTLP != nil {
+		return spec.OTLP.Batch
+	}
+
+	return config.BatchConfig{Enabled: true}
+}
+
+// newPeriodicReader drives exporter on the configured interval, defaulting to one minute.
+func newPeriodicReader(exporter sdkmetric.Exporter, cfg config.MetricReaderConfig) *sdkmetric.PeriodicReader {
+	opts := []sdkmetric.PeriodicReaderOption{
+		sdkmetric.WithInterval(durationOr(cfg.Interval, defaultMetricInterval)),
 	}
 
 	if cfg.Timeout > 0 {
-		opts = append(opts, otlptracegrpc.WithTimeout(cfg.Timeout))
+		opts = append(opts, sdkmetric.WithTimeout(cfg.Timeout))
 	}
 
-	if cfg.Compression != "" {
-		opts = append(opts, otlptracegrpc.WithCompressor(cfg.Compression))
+	return sdkmetric.NewPeriodicReader(exporter, opts...)
+}
+
+func durationOr(value, fallback time.Duration) time.Duration {
+	if value > 0 {
+		return value
 	}
 
-	if len(cfg.Headers) > 0 {
-		opts = append(opts, otlptracegrpc.WithHeaders(cfg.Headers))
+	return fallback
+}
+
+// queueFor resolves the persistent queue settings for spec, mirroring batchFor.
+func queueFor(spec config.ExporterSpec) *config.QueueConfig {
+	queue := spec.Queue
+	if queue == nil && spec.OTLP != nil {
+		queue = spec.OTLP.Queue
 	}
 
-	if cfg.Retry.Enabled {
-		opts = append(opts, otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{
-			Enabled:         true,
-			InitialInterval: cfg.Retry.InitialInterval,
-			MaxInterval:     cfg.Retry.MaxInterval,
-			MaxElapsedTime:  cfg.Retry.MaxElapsedTime,
-		}))
+	if queue == nil || !queue.Enabled {
+		return nil
 	}
 
-	return opts, nil
+	return queue
 }
 
-func otlpHTTPOptions(cfg *config.OTLPConfig) ([]otlptracehttp.Option, error) {
-	return buildHTTPOptions(cfg, httpOptionFactory[otlptracehttp.Option]{
-		withEndpoint: otlptracehttp.WithEndpoint,
-		withInsecure: otlptracehttp.WithInsecure,
-		withTLS:      otlptracehttp.WithTLSClientConfig,
-		withTimeout:  otlptracehttp.WithTimeout,
-		withHeaders:  otlptracehttp.WithHeaders,
-		withCompression: func(value string) (otlptracehttp.Option, bool) {
-			return otlptracehttp.WithCompression(traceHTTPCompression(value)), true
-		},
-		withRetry: func(retryCfg config.RetryConfig) otlptracehttp.Option {
-			return otlptracehttp.WithRetry(otlptracehttp.RetryConfig{
-				Enabled:         true,
-				InitialInterval: retryCfg.InitialInterval,
-				MaxInterval:     retryCfg.MaxInterval,
-				MaxElapsedTime:  retryCfg.MaxElapsedTime,
-			})
-		},
-	})
-}
+// withSpanQueue wraps exp with stats and, when configured, a persistent disk queue in front.
+// Failed batches are then spooled rather than lost, so only queue evictions count as drops.
+func withSpanQueue(
+	ctx context.Context,
+	spec config.ExporterSpec,
+	exp sdktrace.SpanExporter,
+	stats *traceExporterStats,
+) (sdktrace.SpanExporter, error) {
+	queue := queueFor(spec)
+	wrapped := &spanExporterWithStats{inner: exp, stats: stats, breaker: stats.breaker, queued: queue != nil}
 
-func otlpMetricGRPCOptions(cfg *config.OTLPConfig) ([]otlpmetricgrpc.Option, error) {
-	opts := []otlpmetricgrpc.Option{
-		otlpmetricgrpc.WithEndpoint(cfg.Endpoint),
+	if queue == nil {
+		return wrapped, nil
 	}
-	if cfg.Insecure {
-		opts = append(opts, otlpmetricgrpc.WithInsecure())
-	} else {
-		tlsCfg, err := tlsConfigFrom(cfg.TLS)
-		if err != nil && !ErrTLSNotEnabled.Is(err) {
-			return nil, err
-		}
 
-		if tlsCfg != nil {
-			opts = append(opts, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
-		}
+	persistent, err := exporters.NewPersistentSpanExporter(wrapped, spec.Name, *queue,
+		exporters.WithDropHandler(func(items int) { stats.recordDrop(int64(items)) }),
+	)
+	if err != nil {
+		return nil, errors.Join(ewrap.Wrapf(err, "build %s trace queue", spec.Name), wrapped.Shutdown(ctx))
 	}
 
-	if cfg.Timeout > 0 {
-		opts = append(opts, otlpmetricgrpc.WithTimeout(cfg.Timeout))
+	stats.queue = persistent.Stats
+
+	return persistent, nil
+}
+
+// withMetricQueue wraps exp with stats and the configured temporality and, when configured,
+// a persistent disk queue in front.
+func withMetricQueue(
+	ctx context.Context,
+	spec config.ExporterSpec,
+	exp sdkmetric.Exporter,
+	stats *metricExporterStats,
+) (sdkmetric.Exporter, error) {
+	temporality, err := exporters.TemporalitySelector(spec.MetricReaderSettings().Temporality)
+	if err != nil {
+		return nil, errors.Join(ewrap.Wrapf(err, "exporter %q", spec.Name), exp.Shutdown(ctx))
 	}
 
-	if len(cfg.Headers) > 0 {
-		opts = append(opts, otlpmetricgrpc.WithHeaders(cfg.Headers))
+	wrapped := &metricExporterWithStats{
+		inner:       exp,
+		stats:       stats,
+		breaker:     stats.breaker,
+		temporality: temporality,
+		queued:      queueFor(spec) != nil,
 	}
 
-	if cmp := strings.ToLower(cfg.Compression); cmp != "" {
-		opts = append(opts, otlpmetricgrpc.WithCompressor(cmp))
+	queue := queueFor(spec)
+	if queue == nil {
+		return wrapped, nil
 	}
 
-	if cfg.Retry.Enabled {
-		opts = append(opts, otlpmetricgrpc.WithRetry(otlpmetricgrpc.RetryConfig{
-			Enabled:         true,
-			InitialInterval: cfg.Retry.InitialInterval,
-			MaxInterval:     cfg.Retry.MaxInterval,
-			MaxElapsedTime:  cfg.Retry.MaxElapsedTime,
-		}))
+	persistent, err := exporters.NewPersistentMetricExporter(wrapped, spec.Name, *queue)
+	if err != nil {
+		return nil, errors.Join(ewrap.Wrapf(err, "build %s metric queue", spec.Name), wrapped.Shutdown(ctx))
 	}
 
-	return opts, nil
-}
+	stats.queue = persistent.Stats
 
-func otlpMetricHTTPOptions(cfg *config.OTLPConfig) ([]otlpmetrichttp.Option, error) {
-	return buildHTTPOptions(cfg, httpOptionFactory[otlpmetrichttp.Option]{
-		withEndpoint: otlpmetrichttp.WithEndpoint,
-		withInsecure: otlpmetrichttp.WithInsecure,
-		withTLS:      otlpmetrichttp.WithTLSClientConfig,
-		withTimeout:  otlpmetrichttp.WithTimeout,
-		withHeaders:  otlpmetrichttp.WithHeaders,
-		withCompression: func(value string) (otlpmetrichttp.Option, bool) {
-			return otlpmetrichttp.WithCompression(metricHTTPCompression(value)), true
-		},
-		withRetry: func(retryCfg config.RetryConfig) otlpmetrichttp.Option {
-			return otlpmetrichttp.WithRetry(otlpmetrichttp.RetryConfig{
-				Enabled:         true,
-				InitialInterval: retryCfg.InitialInterval,
-				MaxInterval:     retryCfg.MaxInterval,
-				MaxElapsedTime:  retryCfg.MaxElapsedTime,
-			})
-		},
-	})
+	return persistent, nil
 }
 
-type httpOptionFactory[T any] struct {
-	withEndpoint    func(string) T
-	withInsecure    func() T
-	withTLS         func(*tls.Config) T
-	withTimeout     func(time.Duration) T
-	withHeaders     func(map[string]string) T
-	withCompression func(string) (T, bool)
-	withRetry       func(config.RetryConfig) T
-}
-
-func buildHTTPOptions[T any](cfg *config.OTLPConfig, factory httpOptionFactory[T]) ([]T, error) {
-	opts := []T{factory.withEndpoint(cfg.Endpoint)}
-	if cfg.Insecure {
-		opts = append(opts, factory.withInsecure())
-	} else {
-		tlsCfg, err := tlsConfigFrom(cfg.TLS)
-		if err != nil && !ErrTLSNotEnabled.Is(err) {
-			return nil, err
-		}
-
-		if tlsCfg != nil {
-			opts = append(opts, factory.withTLS(tlsCfg))
-		}
+func (b *exporterBundle) primaryTraceStats() *traceExporterStats {
+	if b == nil || len(b.traces) == 0 {
+		return nil
 	}
 
-	if cfg.Timeout > 0 {
-		opts = append(opts, factory.withTimeout(cfg.Timeout))
-	}
+	return b.traces[0].stats
+}
 
-	if len(cfg.Headers) > 0 {
-		opts = append(opts, factory.withHeaders(cfg.Headers))
+func (b *exporterBundle) primaryMetricStats() *metricExporterStats {
+	if b == nil || len(b.metrics) == 0 {
+		return nil
 	}
 
-	if factory.withCompression != nil && cfg.Compression != "" {
-		opt, ok := factory.withCompression(strings.ToLower(cfg.Compression))
-		if ok {
-			opts = append(opts, opt)
-		}
+	return b.metrics[0].stats
+}
+
+func (b *exporterBundle) metricReaders() []sdkmetric.Reader {
+	if b == nil {
+		return nil
 	}
 
-	if factory.withRetry != nil && cfg.Retry.Enabled {
-		opts = append(opts, factory.withRetry(cfg.Retry))
+	readers := make([]sdkmetric.Reader, 0, len(b.metrics))
+	for _, m := range b.metrics {
+		readers = append(readers, m.reader)
 	}
 
-	return opts, nil
+	return readers
 }
 
-func traceHTTPCompression(value string) otlptracehttp.Compression {
-	if value == "gzip" {
-		return otlptracehttp.GzipCompression
+func (b *exporterBundle) shutdown(ctx context.Context) error {
+	var errs []error
+
+	for _, m := range b.metrics {
+		// The meter provider usually shut the reader down already.
+		err := m.reader.Shutdown(ctx)
+		if err != nil && !errors.Is(err, sdkmetric.ErrReaderShutdown) {
+			errs = append(errs, err)
+		}
+
+		err = m.exporter.Shutdown(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		}
 	}
 
-	return otlptracehttp.NoCompression
-}
+	for _, t := range b.traces {
+		err := t.exporter.Shutdown(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
 
-func metricHTTPCompression(value string) otlpmetrichttp.Compression {
-	if value == "gzip" {
-		return otlpmetrichttp.GzipCompression
+	for _, l := range b.logs {
+		err := l.exporter.Shutdown(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		}
 	}
 
-	return otlpmetrichttp.NoCompression
+	return errors.Join(errs...)
 }
 
 type spanExporterWithStats struct {
-	inner sdktrace.SpanExporter
-	stats *traceExporterStats
+	inner   sdktrace.SpanExporter
+	stats   *traceExporterStats
+	breaker *circuitBreaker
+	// queued is set when a persistent queue spools failed batches, which are then not dropped.
+	queued bool
 }
 
 func (s *spanExporterWithStats) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
@@ -513,10 +665,19 @@ func (s *spanExporterWithStats) ExportSpans(ctx context.Context, spans []sdktrac
 		return nil
 	}
 
+	if !s.breaker.allow() {
+		return s.breaker.shortCircuit(len(spans), s.queued)
+	}
+
 	err := s.inner.ExportSpans(ctx, spans)
+	s.breaker.record(err)
+
 	if err != nil {
 		if s.stats != nil {
-			s.stats.recordDrop(int64(len(spans)))
+			if !s.queued {
+				s.stats.recordDrop(int64(len(spans)))
+			}
+
 			s.stats.recordError(err)
 		}
 
@@ -531,8 +692,13 @@ func (s *spanExporterWithStats) ExportSpans(ctx context.Context, spans []sdktrac
 }
 
 type metricExporterWithStats struct {
-	inner sdkmetric.Exporter
-	stats *metricExporterStats
+	inner   sdkmetric.Exporter
+	stats   *metricExporterStats
+	breaker *circuitBreaker
+	// temporality applies the configured preference to exporters that do not select it themselves.
+	temporality sdkmetric.TemporalitySelector
+	// queued is set when a persistent queue spools collections rejected by the breaker.
+	queued bool
 }
 
 func (m *metricExporterWithStats) Temporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
@@ -540,6 +706,10 @@ func (m *metricExporterWithStats) Temporality(kind sdkmetric.InstrumentKind) met
 		return metricdata.CumulativeTemporality
 	}
 
+	if m.temporality != nil {
+		return m.temporality(kind)
+	}
+
 	return m.inner.Temporality(kind)
 }
 
@@ -556,7 +726,18 @@ func (m *metricExporterWithStats) Export(ctx context.Context, rm *metricdata.Res
 		return nil
 	}
 
+	if !m.breaker.allow() {
+		items := 0
+		for _, sm := range rm.ScopeMetrics {
+			items += len(sm.Metrics)
+		}
+
+		return m.breaker.shortCircuit(items, m.queued)
+	}
+
 	err := m.inner.Export(ctx, rm)
+	m.breaker.record(err)
+
 	if err != nil {
 		if m.stats != nil {
 			m.stats.recordError(err)
@@ -611,43 +792,62 @@ func (s *spanExporterWithStats) Shutdown(ctx context.Context) error {
 	return nil
 }
 
-// tlsConfigFrom builds a tls.Config from the provided TLSConfig.
-func tlsConfigFrom(cfg config.TLSConfig) (*tls.Config, error) {
-	if cfg.CAFile == "" && cfg.CertFile == "" && cfg.KeyFile == "" && !cfg.Insecure {
-		return nil, ErrTLSNotEnabled
+type logExporterWithStats struct {
+	inner   sdklog.Exporter
+	stats   *traceExporterStats
+	breaker *circuitBreaker
+}
+
+func (l *logExporterWithStats) Export(ctx context.Context, records []sdklog.Record) error {
+	if l == nil || l.inner == nil {
+		return nil
 	}
 
-	tlsCfg := &tls.Config{
-		//nolint:gosec // allow insecure skip verify via config.
-		InsecureSkipVerify: cfg.Insecure,
+	if !l.breaker.allow() {
+		return l.breaker.shortCircuit(len(records), false)
 	}
 
-	if cfg.CAFile != "" {
-		data, err := os.ReadFile(cfg.CAFile)
-		if err != nil {
-			return nil, ewrap.Wrapf(err, "read ca file %s", cfg.CAFile)
-		}
+	err := l.inner.Export(ctx, records)
+	l.breaker.record(err)
 
-		pool := x509.NewCertPool()
-		if !pool.AppendCertsFromPEM(data) {
-			return nil, ewrap.Newf("failed to parse ca file %s", cfg.CAFile)
+	if err != nil {
+		if l.stats != nil {
+			l.stats.recordDrop(int64(len(records)))
+			l.stats.recordError(err)
 		}
 
-		tlsCfg.RootCAs = pool
+		return ewrap.Wrap(err, "export logs")
 	}
 
-	if cfg.CertFile != "" || cfg.KeyFile != "" {
-		if cfg.CertFile == "" || cfg.KeyFile == "" {
-			return nil, ewrap.New("tls cert_file and key_file must both be set")
-		}
+	if l.stats != nil {
+		l.stats.recordSuccess()
+	}
 
-		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
-		if err != nil {
-			return nil, ewrap.Wrap(err, "load tls client certificate")
-		}
+	return nil
+}
+
+func (l *logExporterWithStats) ForceFlush(ctx context.Context) error {
+	if l == nil || l.inner == nil {
+		return nil
+	}
 
-		tlsCfg.Certificates = []tls.Certificate{cert}
+	err := l.inner.ForceFlush(ctx)
+	if err != nil {
+		return ewrap.Wrap(err, "force flush log exporter")
 	}
 
-	return tlsCfg, nil
+	return nil
+}
+
+func (l *logExporterWithStats) Shutdown(ctx context.Context) error {
+	if l == nil || l.inner == nil {
+		return nil
+	}
+
+	err := l.inner.Shutdown(ctx)
+	if err != nil {
+		return ewrap.Wrap(err, "shutdown log exporter")
+	}
+
+	return nil
 }
@@ -4,10 +4,19 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hyp3rd/ewrap"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/hyp3rd/observe/pkg/config"
+	"github.com/hyp3rd/observe/pkg/exporters"
 )
 
 func TestMetricExporterWithStatsRecordsErrors(t *testing.T) {
@@ -106,3 +115,323 @@ func (s *stubMetricExporter) ForceFlush(context.Context) error {
 func (s *stubMetricExporter) Shutdown(context.Context) error {
 	return s.shutdownErr
 }
+
+func TestExporterBundleFansOutToBackends(t *testing.T) {
+	t.Parallel()
+
+	oldBackend := tracetest.NewInMemoryExporter()
+	newBackend := tracetest.NewInMemoryExporter()
+	backends := map[string]*tracetest.InMemoryExporter{"old": oldBackend, "new": newBackend}
+
+	registry := exporters.NewRegistry()
+
+	err := registry.Register("memory", exporters.Factory{
+		Traces: func(_ context.Context, spec config.ExporterSpec) (sdktrace.SpanExporter, error) {
+			return backends[spec.Name], nil
+		},
+		Metrics: func(context.Context, config.ExporterSpec) (metric.Exporter, error) {
+			return &stubMetricExporter{}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	cfg := config.ExporterConfig{
+		Backends: []config.ExporterSpec{
+			{Name: "old", Type: "memory", Batch: &config.BatchConfig{}},
+			{Name: "new", Type: "memory", Signals: []string{"traces"}, Batch: &config.BatchConfig{}},
+		},
+	}
+
+	bundle, err := newExporterBundle(context.Background(), cfg, registry)
+	if err != nil {
+		t.Fatalf("newExporterBundle: %v", err)
+	}
+
+	if len(bundle.traces) != 2 || len(bundle.metrics) != 1 {
+		t.Fatalf("expected 2 trace and 1 metric exporters, got %d and %d", len(bundle.traces), len(bundle.metrics))
+	}
+
+	tp, err := buildTracerProvider(config.Config{Sampling: config.SamplingConfig{Mode: "always_on"}}, resource.Empty(), bundle.traces, nil, nil)
+	if err != nil {
+		t.Fatalf("buildTracerProvider: %v", err)
+	}
+
+	_, span := tp.Tracer("test").Start(context.Background(), "op")
+	span.End()
+
+	for name, backend := range backends {
+		if got := len(backend.GetSpans()); got != 1 {
+			t.Fatalf("expected backend %s to receive 1 span, got %d", name, got)
+		}
+	}
+
+	statuses := traceExporterStatuses(bundle)
+	if len(statuses) != 2 || statuses[0].Name != "old" || statuses[1].Name != "new" {
+		t.Fatalf("unexpected trace exporter statuses %+v", statuses)
+	}
+
+	if statuses[1].Type != "memory" || statuses[1].LastSuccessTime.IsZero() {
+		t.Fatalf("expected per-exporter stats for new backend, got %+v", statuses[1])
+	}
+}
+
+func TestExporterBundleRejectsUnknownTypeAndSignal(t *testing.T) {
+	t.Parallel()
+
+	registry := exporters.NewRegistry()
+
+	err := registry.Register("traces-only", exporters.Factory{
+		Traces: func(context.Context, config.ExporterSpec) (sdktrace.SpanExporter, error) {
+			return tracetest.NewInMemoryExporter(), nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	_, err = newExporterBundle(context.Background(), config.ExporterConfig{
+		Backends: []config.ExporterSpec{{Name: "x", Type: "missing"}},
+	}, registry)
+	if err == nil || !strings.Contains(err.Error(), "unknown type") {
+		t.Fatalf("expected unknown type error, got %v", err)
+	}
+
+	_, err = newExporterBundle(context.Background(), config.ExporterConfig{
+		Backends: []config.ExporterSpec{{Name: "x", Type: "traces-only", Signals: []string{"metrics"}}},
+	}, registry)
+	if err == nil || !strings.Contains(err.Error(), "does not support signal") {
+		t.Fatalf("expected unsupported signal error, got %v", err)
+	}
+}
+
+type failingSpanExporter struct{}
+
+func (failingSpanExporter) ExportSpans(context.Context, []sdktrace.ReadOnlySpan) error {
+	return ewrap.New("collector unavailable")
+}
+
+func (failingSpanExporter) Shutdown(context.Context) error { return nil }
+
+func TestExporterBundleSpoolsToPersistentQueue(t *testing.T) {
+	t.Parallel()
+
+	registry := exporters.NewRegistry()
+
+	err := registry.Register("failing", exporters.Factory{
+		Traces: func(context.Context, config.ExporterSpec) (sdktrace.SpanExporter, error) {
+			return failingSpanExporter{}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	cfg := config.ExporterConfig{
+		Backends: []config.ExporterSpec{{
+			Name:  "collector",
+			Type:  "failing",
+			Batch: &config.BatchConfig{},
+			Queue: &config.QueueConfig{Enabled: true, Dir: t.TempDir()},
+		}},
+	}
+
+	bundle, err := newExporterBundle(context.Background(), cfg, registry)
+	if err != nil {
+		t.Fatalf("newExporterBundle: %v", err)
+	}
+
+	tp, err := buildTracerProvider(config.Config{Sampling: config.SamplingConfig{Mode: "always_on"}}, resource.Empty(), bundle.traces, nil, nil)
+	if err != nil {
+		t.Fatalf("buildTracerProvider: %v", err)
+	}
+
+	_, span := tp.Tracer("test").Start(context.Background(), "op")
+	span.End()
+
+	status := traceExporterStatuses(bundle)[0]
+	if status.Queue == nil || status.Queue.Depth != 1 || status.Queue.Bytes == 0 {
+		t.Fatalf("expected the failed batch to be queued on disk, got %+v", status.Queue)
+	}
+
+	if status.Dropped != 0 || status.ErrorCount != 1 {
+		t.Fatalf("expected the error to be recorded without a drop, got %+v", status)
+	}
+
+	err = bundle.shutdown(context.Background())
+	if err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+}
+
+func TestExporterBundleReportsPerSignalOTLPTargets(t *testing.T) {
+	t.Parallel()
+
+	insecure := true
+	cfg := config.ExporterConfig{
+		OTLP: &config.OTLPConfig{
+			Protocol: "grpc",
+			Insecure: true,
+			Batch:    config.BatchConfig{Enabled: true},
+			Traces:   &config.OTLPSignalConfig{Protocol: "http", Endpoint: "traces.vendor.example:4318", Insecure: &insecure},
+			Metrics:  &config.OTLPSignalConfig{Endpoint: "metrics.vendor.example:4317"},
+			Logs:     &config.OTLPSignalConfig{Endpoint: "logs.vendor.example:4317"},
+		},
+	}
+
+	bundle, err := newExporterBundle(context.Background(), cfg, exporters.Default())
+	if err != nil {
+		t.Fatalf("newExporterBundle: %v", err)
+	}
+
+	defer func() { _ = bundle.shutdown(context.Background()) }()
+
+	trace := exporterStatus(bundle)
+	if trace.Protocol != "http" || trace.Endpoint != "traces.vendor.example:4318" {
+		t.Fatalf("expected effective trace target, got %+v", trace)
+	}
+
+	metric := metricExporterStatus(bundle)
+	if metric.Protocol != "grpc" || metric.Endpoint != "metrics.vendor.example:4317" {
+		t.Fatalf("expected effective metric target, got %+v", metric)
+	}
+
+	logs := logExporterStatuses(bundle)
+	if len(logs) != 1 || logs[0].Endpoint != "logs.vendor.example:4317" {
+		t.Fatalf("expected effective log target, got %+v", logs)
+	}
+}
+
+func TestExporterBundleAppliesMetricReaderSettings(t *testing.T) {
+	t.Parallel()
+
+	registry := exporters.NewRegistry()
+
+	err := registry.Register("memory", exporters.Factory{
+		Metrics: func(context.Context, config.ExporterSpec) (metric.Exporter, error) {
+			return &stubMetricExporter{}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	cfg := config.ExporterConfig{
+		Backends: []config.ExporterSpec{{
+			Name:         "billing",
+			Type:         "memory",
+			MetricReader: &config.MetricReaderConfig{Interval: 10 * time.Second, Timeout: time.Second, Temporality: "delta"},
+		}},
+	}
+
+	bundle, err := newExporterBundle(context.Background(), cfg, registry)
+	if err != nil {
+		t.Fatalf("newExporterBundle: %v", err)
+	}
+
+	defer func() { _ = bundle.shutdown(context.Background()) }()
+
+	exporter := bundle.metrics[0].exporter
+	if got := exporter.Temporality(metric.InstrumentKindCounter); got != metricdata.DeltaTemporality {
+		t.Fatalf("expected delta temporality for counters, got %v", got)
+	}
+
+	if got := exporter.Temporality(metric.InstrumentKindUpDownCounter); got != metricdata.CumulativeTemporality {
+		t.Fatalf("expected cumulative temporality for up-down counters, got %v", got)
+	}
+
+	cfg.Backends[0].MetricReader.Temporality = "hourly"
+
+	_, err = newExporterBundle(context.Background(), cfg, registry)
+	if err == nil || !strings.Contains(err.Error(), "unsupported metric temporality") {
+		t.Fatalf("expected unsupported temporality error, got %v", err)
+	}
+}
+
+// recordingLogExporter keeps copies of the exported records.
+type recordingLogExporter struct {
+	records []sdklog.Record
+}
+
+func (r *recordingLogExporter) Export(_ context.Context, records []sdklog.Record) error {
+	for _, record := range records {
+		r.records = append(r.records, record.Clone())
+	}
+
+	return nil
+}
+
+func (*recordingLogExporter) ForceFlush(context.Context) error { return nil }
+
+func (*recordingLogExporter) Shutdown(context.Context) error { return nil }
+
+func TestExporterBundleShipsLogsThroughLoggerProvider(t *testing.T) {
+	t.Parallel()
+
+	backend := &recordingLogExporter{}
+	registry := exporters.NewRegistry()
+
+	err := registry.Register("memory", exporters.Factory{
+		Logs: func(context.Context, config.ExporterSpec) (sdklog.Exporter, error) {
+			return backend, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	cfg := config.ExporterConfig{
+		Backends: []config.ExporterSpec{{Name: "logs", Type: "memory", Batch: &config.BatchConfig{}}},
+	}
+
+	bundle, err := newExporterBundle(context.Background(), cfg, registry)
+	if err != nil {
+		t.Fatalf("newExporterBundle: %v", err)
+	}
+
+	if len(bundle.logs) != 1 || len(bundle.traces) != 0 {
+		t.Fatalf("expected a single log exporter, got %d logs and %d traces", len(bundle.logs), len(bundle.traces))
+	}
+
+	lp := buildLoggerProvider(resource.Empty(), bundle.logs)
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "op")
+
+	var record otellog.Record
+	record.SetSeverity(otellog.SeverityWarn)
+	record.SetBody(otellog.StringValue("disk almost full"))
+	lp.Logger("test").Emit(ctx, record)
+	span.End()
+
+	err = lp.Shutdown(context.Background())
+	if err != nil {
+		t.Fatalf("shutdown logger provider: %v", err)
+	}
+
+	if len(backend.records) != 1 || backend.records[0].TraceID() != span.SpanContext().TraceID() {
+		t.Fatalf("expected one record correlated with the span, got %+v", backend.records)
+	}
+
+	status := logExporterStatuses(bundle)[0]
+	if status.Name != "logs" || status.LastSuccessTime.IsZero() {
+		t.Fatalf("expected per-exporter log stats, got %+v", status)
+	}
+
+	if buildLoggerProvider(resource.Empty(), nil) != nil {
+		t.Fatal("expected no logger provider without log exporters")
+	}
+}
+
+func TestExporterStatsCountAuthErrors(t *testing.T) {
+	t.Parallel()
+
+	stats := newTraceExporterStats(config.ExporterSpec{Name: "otlp"}, exporters.Target{Protocol: "http"}, config.BatchConfig{})
+	stats.recordError(ewrap.Wrap(exporters.ErrAuthentication, "token file is empty"))
+	stats.recordError(ewrap.New("connection refused"))
+
+	status := stats.statusSnapshot()
+	if status.ErrorCount != 2 || status.AuthErrors != 1 {
+		t.Fatalf("expected 1 auth error out of 2, got %d of %d", status.AuthErrors, status.ErrorCount)
+	}
+}
@@ -0,0 +1,378 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyp3rd/ewrap"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/hyp3rd/observe/pkg/config"
+	"github.com/hyp3rd/observe/pkg/diagnostics"
+	"github.com/hyp3rd/observe/pkg/exporters"
+)
+
+const (
+	failoverReason = "failover"
+	failbackReason = "failback"
+
+	defaultFailoverThreshold = 3
+	defaultFailoverProbe     = 30 * time.Second
+	failoverHistoryLimit     = 16
+)
+
+// endpointFailover picks the endpoint an export goes to. The active endpoint moves to the
+// next one in the list after threshold consecutive failures, wrapping around after the last.
+// While away from the primary, one export per probe interval is sent to the primary instead;
+// its success fails back.
+type endpointFailover struct {
+	endpoints []string
+	threshold int
+	probe     time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	active    int
+	failures  int
+	lastProbe time.Time
+	history   []diagnostics.FailoverEvent
+
+	failovers atomic.Int64
+	failbacks atomic.Int64
+}
+
+// newEndpointFailover returns nil unless spec lists more than one OTLP endpoint.
+func newEndpointFailover(spec config.ExporterSpec) *endpointFailover {
+	if spec.OTLP == nil || len(spec.OTLP.Endpoints) < 2 {
+		return nil
+	}
+
+	cfg := spec.OTLP.Failover
+	if cfg == nil {
+		cfg = &config.FailoverConfig{}
+	}
+
+	return &endpointFailover{
+		endpoints: append([]string(nil), spec.OTLP.Endpoints...),
+		threshold: intOr(cfg.FailureThreshold, defaultFailoverThreshold),
+		probe:     durationOr(cfg.ProbeInterval, defaultFailoverProbe),
+		now:       time.Now,
+	}
+}
+
+// pick returns the endpoint index for the next export; probe is set when it probes the primary.
+func (f *endpointFailover) pick() (int, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.active != 0 && f.now().Sub(f.lastProbe) >= f.probe {
+		f.lastProbe = f.now()
+
+		return 0, true
+	}
+
+	return f.active, false
+}
+
+// record feeds the outcome of an export to endpoint idx back into the failover state.
+func (f *endpointFailover) record(idx int, probe bool, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case probe && err == nil:
+		f.switchTo(0, failbackReason)
+		f.failbacks.Add(1)
+	case probe, idx != f.active:
+		// A failed probe, or an export that started before the last switch.
+	case err == nil:
+		f.failures = 0
+	default:
+		f.failures++
+		if f.failures >= f.threshold {
+			f.switchTo((f.active+1)%len(f.endpoints), failoverReason)
+			f.failovers.Add(1)
+		}
+	}
+}
+
+func (f *endpointFailover) switchTo(idx int, reason string) {
+	now := f.now()
+
+	f.history = append(f.history, diagnostics.FailoverEvent{
+		Time:   now.UTC(),
+		From:   f.endpoints[f.active],
+		To:     f.endpoints[idx],
+		Reason: reason,
+	})
+	if len(f.history) > failoverHistoryLimit {
+		f.history = f.history[len(f.history)-failoverHistoryLimit:]
+	}
+
+	f.active = idx
+	f.failures = 0
+	f.lastProbe = now
+}
+
+// activeEndpoint returns the endpoint exports currently go to, or "" for a nil failover.
+func (f *endpointFailover) activeEndpoint() string {
+	if f == nil {
+		return ""
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.endpoints[f.active]
+}
+
+func (f *endpointFailover) snapshot() *diagnostics.FailoverStatus {
+	if f == nil {
+		return nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return &diagnostics.FailoverStatus{
+		Endpoints: append([]string(nil), f.endpoints...),
+		Active:    f.endpoints[f.active],
+		Failovers: f.failovers.Load(),
+		Failbacks: f.failbacks.Load(),
+		History:   append([]diagnostics.FailoverEvent(nil), f.history...),
+	}
+}
+
+// exportWithFailover sends one export through the failover. A probe of the primary gets half
+// of ctx's remaining time, and a failed probe is retried on the active endpoint with the rest,
+// so probing never costs a batch. Errors caused by ctx ending say nothing about the endpoint
+// and are not recorded.
+func exportWithFailover(ctx context.Context, f *endpointFailover, export func(ctx context.Context, idx int) error) error {
+	idx, probe := f.pick()
+	if probe {
+		probeCtx, cancel := probeContext(ctx)
+		err := export(probeCtx, idx)
+
+		cancel()
+
+		if ctx.Err() != nil {
+			return err
+		}
+
+		f.record(idx, true, err)
+
+		if err == nil {
+			return nil
+		}
+
+		idx, _ = f.pick()
+	}
+
+	err := export(ctx, idx)
+	if ctx.Err() == nil {
+		f.record(idx, false, err)
+	}
+
+	return err
+}
+
+// probeContext bounds a probe to half of ctx's remaining time, leaving the rest for the retry.
+func probeContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, time.Until(deadline)/2)
+}
+
+// endpointSpec narrows spec to a single OTLP endpoint.
+func endpointSpec(spec config.ExporterSpec, endpoint string) config.ExporterSpec {
+	otlp := *spec.OTLP
+	otlp.Endpoint = endpoint
+	otlp.Endpoints = nil
+	spec.OTLP = &otlp
+
+	return spec
+}
+
+type shutdowner interface {
+	Shutdown(ctx context.Context) error
+}
+
+// buildPerEndpoint builds one exporter per failover endpoint, shutting down those already
+// built when one fails.
+func buildPerEndpoint[T shutdowner](
+	ctx context.Context,
+	spec config.ExporterSpec,
+	f *endpointFailover,
+	build func(context.Context, config.ExporterSpec) (T, error),
+) ([]T, error) {
+	built := make([]T, 0, len(f.endpoints))
+
+	for _, endpoint := range f.endpoints {
+		exp, err := build(ctx, endpointSpec(spec, endpoint))
+		if err != nil {
+			return nil, errors.Join(ewrap.Wrapf(err, "endpoint %s", endpoint), shutdownAll(ctx, built))
+		}
+
+		built = append(built, exp)
+	}
+
+	return built, nil
+}
+
+func shutdownAll[T shutdowner](ctx context.Context, exporters []T) error {
+	var errs []error
+
+	for _, exp := range exporters {
+		err := exp.Shutdown(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// buildTraceExporter builds spec's span exporter, failing over across its endpoints when f is set.
+func buildTraceExporter(
+	ctx context.Context,
+	factory exporters.Factory,
+	spec config.ExporterSpec,
+	f *endpointFailover,
+) (sdktrace.SpanExporter, error) {
+	if f == nil {
+		return factory.Traces(ctx, spec)
+	}
+
+	built, err := buildPerEndpoint(ctx, spec, f, factory.Traces)
+	if err != nil {
+		return nil, err
+	}
+
+	return &failoverSpanExporter{failover: f, exporters: built}, nil
+}
+
+// buildMetricExporter mirrors buildTraceExporter for metrics.
+func buildMetricExporter(
+	ctx context.Context,
+	factory exporters.Factory,
+	spec config.ExporterSpec,
+	f *endpointFailover,
+) (sdkmetric.Exporter, error) {
+	if f == nil {
+		return factory.Metrics(ctx, spec)
+	}
+
+	built, err := buildPerEndpoint(ctx, spec, f, factory.Metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &failoverMetricExporter{failover: f, exporters: built}, nil
+}
+
+// buildLogExporter mirrors buildTraceExporter for logs.
+func buildLogExporter(
+	ctx context.Context,
+	factory exporters.Factory,
+	spec config.ExporterSpec,
+	f *endpointFailover,
+) (sdklog.Exporter, error) {
+	if f == nil {
+		return factory.Logs(ctx, spec)
+	}
+
+	built, err := buildPerEndpoint(ctx, spec, f, factory.Logs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &failoverLogExporter{failover: f, exporters: built}, nil
+}
+
+type failoverSpanExporter struct {
+	failover  *endpointFailover
+	exporters []sdktrace.SpanExporter
+}
+
+func (e *failoverSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	return exportWithFailover(ctx, e.failover, func(ctx context.Context, idx int) error {
+		return e.exporters[idx].ExportSpans(ctx, spans)
+	})
+}
+
+func (e *failoverSpanExporter) Shutdown(ctx context.Context) error {
+	return shutdownAll(ctx, e.exporters)
+}
+
+type failoverMetricExporter struct {
+	failover  *endpointFailover
+	exporters []sdkmetric.Exporter
+}
+
+func (e *failoverMetricExporter) Temporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	return e.exporters[0].Temporality(kind)
+}
+
+func (e *failoverMetricExporter) Aggregation(kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	return e.exporters[0].Aggregation(kind)
+}
+
+func (e *failoverMetricExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	return exportWithFailover(ctx, e.failover, func(ctx context.Context, idx int) error {
+		return e.exporters[idx].Export(ctx, rm)
+	})
+}
+
+func (e *failoverMetricExporter) ForceFlush(ctx context.Context) error {
+	var errs []error
+
+	for _, exp := range e.exporters {
+		err := exp.ForceFlush(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (e *failoverMetricExporter) Shutdown(ctx context.Context) error {
+	return shutdownAll(ctx, e.exporters)
+}
+
+type failoverLogExporter struct {
+	failover  *endpointFailover
+	exporters []sdklog.Exporter
+}
+
+func (e *failoverLogExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	return exportWithFailover(ctx, e.failover, func(ctx context.Context, idx int) error {
+		return e.exporters[idx].Export(ctx, records)
+	})
+}
+
+func (e *failoverLogExporter) ForceFlush(ctx context.Context) error {
+	var errs []error
+
+	for _, exp := range e.exporters {
+		err := exp.ForceFlush(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (e *failoverLogExporter) Shutdown(ctx context.Context) error {
+	return shutdownAll(ctx, e.exporters)
+}
@@ -0,0 +1,222 @@
+package runtime
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyp3rd/ewrap"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/hyp3rd/observe/pkg/config"
+	"github.com/hyp3rd/observe/pkg/exporters"
+)
+
+// fakeCollectors records which endpoint every export reached and fails those marked down.
+// Exports to a hanging endpoint block until their context ends.
+type fakeCollectors struct {
+	mu   sync.Mutex
+	down map[string]bool
+	hang map[string]bool
+	hits []string
+}
+
+func (c *fakeCollectors) setDown(endpoint string, down bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.down[endpoint] = down
+}
+
+func (c *fakeCollectors) setHanging(endpoint string, hang bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.hang[endpoint] = hang
+}
+
+func (c *fakeCollectors) takeHits() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	hits := c.hits
+	c.hits = nil
+
+	return hits
+}
+
+type fakeEndpointExporter struct {
+	endpoint   string
+	collectors *fakeCollectors
+}
+
+func (e fakeEndpointExporter) ExportSpans(ctx context.Context, _ []sdktrace.ReadOnlySpan) error {
+	e.collectors.mu.Lock()
+	e.collectors.hits = append(e.collectors.hits, e.endpoint)
+	down, hang := e.collectors.down[e.endpoint], e.collectors.hang[e.endpoint]
+	e.collectors.mu.Unlock()
+
+	if hang {
+		<-ctx.Done()
+	}
+
+	switch {
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case down:
+		return ewrap.Newf("%s unavailable", e.endpoint)
+	default:
+		return nil
+	}
+}
+
+func (fakeEndpointExporter) Shutdown(context.Context) error { return nil }
+
+func TestFailoverSpanExporterUsesTheFirstEndpointAsPrimary(t *testing.T) {
+	t.Parallel()
+
+	spec := specForSignal(config.ExporterSpec{
+		Type: "otlp",
+		OTLP: &config.OTLPConfig{Endpoint: "ignored:4317", Endpoints: []string{"regional:4317", "cross-region:4317"}},
+	}, exporters.SignalTraces)
+
+	if spec.OTLP.Endpoint != "regional:4317" {
+		t.Fatalf("expected the first listed endpoint to be the primary, got %q", spec.OTLP.Endpoint)
+	}
+}
+
+// Every case starts failed over to cross-region after two failed exports to regional.
+func TestFailoverSpanExporterAfterFailingOver(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		primary       func(*fakeCollectors)
+		fallback      func(*fakeCollectors)
+		probe         bool
+		exports       int
+		wantErr       bool
+		wantHits      []string
+		wantActive    string
+		wantFailbacks int64
+	}{
+		{
+			name:       "fallback accepts exports",
+			wantHits:   []string{"cross-region:4317"},
+			wantActive: "cross-region:4317",
+		},
+		{
+			name:       "failed probe is retried on the fallback",
+			probe:      true,
+			wantHits:   []string{"regional:4317", "cross-region:4317"},
+			wantActive: "cross-region:4317",
+		},
+		{
+			name:       "hanging probe is retried on the fallback",
+			primary:    func(c *fakeCollectors) { c.setHanging("regional:4317", true) },
+			probe:      true,
+			wantHits:   []string{"regional:4317", "cross-region:4317"},
+			wantActive: "cross-region:4317",
+		},
+		{
+			name:          "successful probe fails back",
+			primary:       func(c *fakeCollectors) { c.setDown("regional:4317", false) },
+			probe:         true,
+			wantHits:      []string{"regional:4317"},
+			wantActive:    "regional:4317",
+			wantFailbacks: 1,
+		},
+		{
+			name:       "exports outliving their deadline do not count",
+			fallback:   func(c *fakeCollectors) { c.setHanging("cross-region:4317", true) },
+			exports:    2,
+			wantErr:    true,
+			wantHits:   []string{"cross-region:4317", "cross-region:4317"},
+			wantActive: "cross-region:4317",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			collectors := &fakeCollectors{down: map[string]bool{"regional:4317": true}, hang: map[string]bool{}}
+			factory := exporters.Factory{
+				Traces: func(_ context.Context, spec config.ExporterSpec) (sdktrace.SpanExporter, error) {
+					return fakeEndpointExporter{endpoint: spec.OTLP.Endpoint, collectors: collectors}, nil
+				},
+			}
+
+			spec := specForSignal(config.ExporterSpec{
+				Name: "otlp",
+				Type: "otlp",
+				OTLP: &config.OTLPConfig{
+					Endpoints: []string{"regional:4317", "cross-region:4317"},
+					Failover:  &config.FailoverConfig{FailureThreshold: 2, ProbeInterval: time.Minute},
+				},
+			}, exporters.SignalTraces)
+
+			now := time.Unix(0, 0)
+			failover := newEndpointFailover(spec)
+			failover.now = func() time.Time { return now }
+
+			exp, err := buildTraceExporter(context.Background(), factory, spec, failover)
+			if err != nil {
+				t.Fatalf("buildTraceExporter: %v", err)
+			}
+
+			export := func() error {
+				ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+				defer cancel()
+
+				return exp.ExportSpans(ctx, tracetest.SpanStubs{{Name: "op"}}.Snapshots())
+			}
+
+			for range 2 {
+				if export() == nil {
+					t.Fatal("expected exports to the failed primary to fail")
+				}
+			}
+
+			if failover.activeEndpoint() != "cross-region:4317" {
+				t.Fatalf("expected a failover after two consecutive failures, got %q", failover.activeEndpoint())
+			}
+
+			collectors.takeHits()
+
+			for _, apply := range []func(*fakeCollectors){tc.primary, tc.fallback} {
+				if apply != nil {
+					apply(collectors)
+				}
+			}
+
+			if tc.probe {
+				now = now.Add(time.Minute)
+			}
+
+			for range max(tc.exports, 1) {
+				err = export()
+			}
+
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+
+			if hits := collectors.takeHits(); !slices.Equal(hits, tc.wantHits) {
+				t.Fatalf("expected exports to reach %v, got %v", tc.wantHits, hits)
+			}
+
+			stats := &traceExporterStats{endpoint: spec.OTLP.Endpoint, failover: failover}
+			if status := stats.statusSnapshot(); status.Endpoint != tc.wantActive || status.Failover == nil {
+				t.Fatalf("expected the status to report %s as active, got %+v", tc.wantActive, status)
+			}
+
+			if snapshot := failover.snapshot(); snapshot.Failovers != 1 || snapshot.Failbacks != tc.wantFailbacks {
+				t.Fatalf("expected 1 failover and %d failbacks, got %+v", tc.wantFailbacks, snapshot)
+			}
+		})
+	}
+}
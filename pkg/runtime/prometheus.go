@@ -0,0 +1,94 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/hyp3rd/ewrap"
+
+	"github.com/hyp3rd/observe/internal/constants"
+	"github.com/hyp3rd/observe/pkg/config"
+	"github.com/hyp3rd/observe/pkg/exporters"
+)
+
+// prometheusEndpoint owns the Prometheus reader and, when configured, its dedicated listener.
+type prometheusEndpoint struct {
+	reader     *exporters.PrometheusReader
+	listenAddr string
+	server     *http.Server
+	listener   net.Listener
+}
+
+func newPrometheusEndpoint(cfg config.PrometheusConfig) (*prometheusEndpoint, error) {
+	reader, err := exporters.NewPrometheusReader(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &prometheusEndpoint{reader: reader, listenAddr: cfg.ListenAddr}, nil
+}
+
+func prometheusEnabled(cfg config.ExporterConfig) bool {
+	return cfg.Prometheus != nil && cfg.Prometheus.Enabled && cfg.SignalEnabled("metrics")
+}
+
+// standalone reports whether the endpoint serves on its own listener rather than the diagnostics server.
+func (p *prometheusEndpoint) standalone() bool {
+	return p != nil && p.listenAddr != ""
+}
+
+func (p *prometheusEndpoint) start(ctx context.Context) error {
+	if !p.standalone() {
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(p.reader.Path, p.reader.Handler)
+
+	lc := net.ListenConfig{}
+
+	ln, err := lc.Listen(ctx, "tcp", p.listenAddr)
+	if err != nil {
+		return ewrap.Wrap(err, "listen prometheus")
+	}
+
+	p.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: constants.DefaultTimeout,
+	}
+	p.listener = ln
+
+	go func() {
+		err := p.server.Serve(ln)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			//nolint:errcheck // best-effort logging via stderr
+			_ = ewrap.Wrap(err, "prometheus server stopped")
+		}
+	}()
+
+	return nil
+}
+
+func (p *prometheusEndpoint) shutdown(ctx context.Context) error {
+	if p == nil || p.server == nil {
+		return nil
+	}
+
+	ctxShutdown, cancel := context.WithTimeout(ctx, constants.DefaultShutdownTimeout)
+	defer cancel()
+
+	err := p.server.Shutdown(ctxShutdown)
+	if err != nil {
+		return ewrap.Wrap(err, "shutdown prometheus server")
+	}
+
+	// Serve may not have taken the listener over yet, in which case Shutdown leaves it open.
+	err = p.listener.Close()
+	if err != nil && !errors.Is(err, net.ErrClosed) {
+		return ewrap.Wrap(err, "close prometheus listener")
+	}
+
+	return nil
+}
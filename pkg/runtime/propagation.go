@@ -0,0 +1,51 @@
+package runtime
+
+import (
+	"strings"
+
+	"github.com/hyp3rd/ewrap"
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/contrib/propagators/jaeger"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/hyp3rd/observe/pkg/config"
+)
+
+// propagatorFromConfig composes the configured propagators in order.
+// An empty list or "none" yields a propagator that neither injects nor extracts.
+func propagatorFromConfig(cfg config.PropagationConfig) (propagation.TextMapPropagator, error) {
+	propagators := make([]propagation.TextMapPropagator, 0, len(cfg.Propagators))
+	seen := make(map[string]struct{}, len(cfg.Propagators))
+
+	for _, raw := range cfg.Propagators {
+		name := strings.ToLower(strings.TrimSpace(raw))
+		if name == "" {
+			continue
+		}
+
+		if _, ok := seen[name]; ok {
+			continue
+		}
+
+		seen[name] = struct{}{}
+
+		switch name {
+		case "tracecontext":
+			propagators = append(propagators, propagation.TraceContext{})
+		case "baggage":
+			propagators = append(propagators, propagation.Baggage{})
+		case "b3":
+			propagators = append(propagators, b3.New(b3.WithInjectEncoding(b3.B3SingleHeader)))
+		case "b3multi":
+			propagators = append(propagators, b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)))
+		case "jaeger":
+			propagators = append(propagators, jaeger.Jaeger{})
+		case "none":
+			return propagation.NewCompositeTextMapPropagator(), nil
+		default:
+			return nil, ewrap.Newf("unsupported propagator %q", raw)
+		}
+	}
+
+	return propagation.NewCompositeTextMapPropagator(propagators...), nil
+}
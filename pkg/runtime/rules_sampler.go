@@ -0,0 +1,201 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hyp3rd/ewrap"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/embedded"
+
+	"github.com/hyp3rd/observe/pkg/config"
+)
+
+var spanKinds = map[string]trace.SpanKind{
+	"internal": trace.SpanKindInternal,
+	"server":   trace.SpanKindServer,
+	"client":   trace.SpanKindClient,
+	"producer": trace.SpanKindProducer,
+	"consumer": trace.SpanKindConsumer,
+}
+
+// rulesSampler samples with the first rule matching the span, falling back to fallback.
+// samplerFromConfig wraps it in ParentBased, so the rules decide where traces start.
+type rulesSampler struct {
+	rules    []samplingRule
+	fallback sdktrace.Sampler
+}
+
+type samplingRule struct {
+	name       *regexp.Regexp
+	kind       trace.SpanKind
+	scope      *regexp.Regexp
+	attributes map[attribute.Key]*regexp.Regexp
+	sampler    sdktrace.Sampler
+}
+
+func newRulesSampler(cfg config.SamplingConfig) (sdktrace.Sampler, error) {
+	if cfg.Argument <= 0 || cfg.Argument > 1 {
+		return nil, ewrap.Newf("sampling.argument must be within (0,1], got %f", cfg.Argument)
+	}
+
+	rules := make([]samplingRule, 0, len(cfg.Rules))
+
+	for idx, rc := range cfg.Rules {
+		rule, err := newSamplingRule(rc)
+		if err != nil {
+			return nil, ewrap.Wrapf(err, "sampling.rules[%d]", idx)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rulesSampler{rules: rules, fallback: sdktrace.TraceIDRatioBased(cfg.Argument)}, nil
+}
+
+func newSamplingRule(cfg config.SamplingRuleConfig) (samplingRule, error) {
+	rule := samplingRule{
+		name:       globPattern(cfg.Match.Name),
+		scope:      globPattern(cfg.Match.Scope),
+		attributes: make(map[attribute.Key]*regexp.Regexp, len(cfg.Match.Attributes)),
+	}
+
+	if cfg.Match.Kind != "" {
+		kind, ok := spanKinds[cfg.Match.Kind]
+		if !ok {
+			return samplingRule{}, ewrap.Newf("unsupported span kind %q", cfg.Match.Kind)
+		}
+
+		rule.kind = kind
+	}
+
+	for key, value := range cfg.Match.Attributes {
+		rule.attributes[attribute.Key(key)] = globPattern(value)
+	}
+
+	switch cfg.Sampler {
+	case "always_on":
+		rule.sampler = sdktrace.AlwaysSample()
+	case "always_off":
+		rule.sampler = sdktrace.NeverSample()
+	case "trace_id_ratio":
+		if cfg.Ratio <= 0 || cfg.Ratio > 1 {
+			return samplingRule{}, ewrap.Newf("ratio must be within (0,1], got %f", cfg.Ratio)
+		}
+
+		rule.sampler = sdktrace.TraceIDRatioBased(cfg.Ratio)
+	default:
+		return samplingRule{}, ewrap.Newf("unsupported sampler %q", cfg.Sampler)
+	}
+
+	return rule, nil
+}
+
+// globPattern compiles a glob where * matches any run of characters and ? a single one; an
+// empty glob yields nil, which matches anything.
+func globPattern(glob string) *regexp.Regexp {
+	if glob == "" {
+		return nil
+	}
+
+	pattern := strings.NewReplacer(`\*`, ".*", `\?`, ".").Replace(regexp.QuoteMeta(glob))
+
+	return regexp.MustCompile("^" + pattern + "$")
+}
+
+func (s rulesSampler) ShouldSample(params sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	scope, _ := params.ParentContext.Value(scopeContextKey{}).(string)
+
+	for i := range s.rules {
+		if s.rules[i].matches(params, scope) {
+			return s.rules[i].sampler.ShouldSample(params)
+		}
+	}
+
+	return s.fallback.ShouldSample(params)
+}
+
+func (s rulesSampler) Description() string {
+	return fmt.Sprintf("Rules{rules:%d,default:%s}", len(s.rules), s.fallback.Description())
+}
+
+func (r *samplingRule) matches(params sdktrace.SamplingParameters, scope string) bool {
+	if r.name != nil && !r.name.MatchString(params.Name) {
+		return false
+	}
+
+	if r.kind != trace.SpanKindUnspecified && r.kind != trace.ValidateSpanKind(params.Kind) {
+		return false
+	}
+
+	if r.scope != nil && !r.scope.MatchString(scope) {
+		return false
+	}
+
+	for key, pattern := range r.attributes {
+		if !attributeMatches(params.Attributes, key, pattern) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func attributeMatches(attrs []attribute.KeyValue, key attribute.Key, pattern *regexp.Regexp) bool {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			return pattern == nil || pattern.MatchString(attr.Value.Emit())
+		}
+	}
+
+	return false
+}
+
+// scopeContextKey carries the instrumentation scope name to the sampler, which the SDK does
+// not include in the sampling parameters.
+type scopeContextKey struct{}
+
+// scopedTracerProvider wraps provider when a sampling rule matches on the instrumentation
+// scope, so its tracers pass their scope name to the sampler; otherwise it returns provider.
+func scopedTracerProvider(cfg config.SamplingConfig, provider trace.TracerProvider) trace.TracerProvider {
+	if cfg.Mode != "rules" {
+		return provider
+	}
+
+	for _, rule := range cfg.Rules {
+		if rule.Match.Scope != "" {
+			return scopeTracerProvider{provider: provider}
+		}
+	}
+
+	return provider
+}
+
+type scopeTracerProvider struct {
+	embedded.TracerProvider
+
+	provider trace.TracerProvider
+}
+
+func (p scopeTracerProvider) Tracer(name string, opts ...trace.TracerOption) trace.Tracer {
+	return scopeTracer{tracer: p.provider.Tracer(name, opts...), scope: name}
+}
+
+type scopeTracer struct {
+	embedded.Tracer
+
+	tracer trace.Tracer
+	scope  string
+}
+
+// Start hands the scope to the sampler through the context passed to the SDK, but returns a
+// context derived from ctx, so the scope does not leak into spans started by other tracers.
+func (t scopeTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	_, span := t.tracer.Start(context.WithValue(ctx, scopeContextKey{}, t.scope), name, opts...)
+
+	return trace.ContextWithSpan(ctx, span), span
+}
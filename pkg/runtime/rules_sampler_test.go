@@ -0,0 +1,216 @@
+package runtime
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+
+	"github.com/hyp3rd/observe/pkg/config"
+	observegrpc "github.com/hyp3rd/observe/pkg/instrumentation/grpc"
+	observehttp "github.com/hyp3rd/observe/pkg/instrumentation/http"
+)
+
+func rulesTestConfig() config.SamplingConfig {
+	return config.SamplingConfig{
+		Mode:     "rules",
+		Argument: 0.1,
+		Rules: []config.SamplingRuleConfig{
+			{Match: config.SamplingRuleMatch{Name: "GET /health*"}, Sampler: "always_off"},
+			{Match: config.SamplingRuleMatch{Kind: "server", Attributes: map[string]string{"http.route": "/checkout/*"}}, Sampler: "always_on"},
+			{Match: config.SamplingRuleMatch{Attributes: map[string]string{"rpc.method": "Get?"}}, Sampler: "trace_id_ratio", Ratio: 0.5},
+			{Match: config.SamplingRuleMatch{Scope: "github.com/acme/batch*"}, Sampler: "always_off"},
+		},
+	}
+}
+
+func TestRulesSamplerAppliesFirstMatchingRule(t *testing.T) {
+	t.Parallel()
+
+	sampler, err := samplerFromConfig(rulesTestConfig())
+	if err != nil {
+		t.Fatalf("samplerFromConfig: %v", err)
+	}
+
+	// The low 63 bits of these trace IDs fall below and above every ratio used here.
+	low := trace.TraceID{8: 0x10}
+	high := trace.TraceID{8: 0xF0}
+	checkout := attribute.String("http.route", "/checkout/{cart}")
+
+	tests := []struct {
+		name    string
+		traceID trace.TraceID
+		span    string
+		kind    trace.SpanKind
+		attrs   []attribute.KeyValue
+		want    sdktrace.SamplingDecision
+	}{
+		{name: "name glob", traceID: low, span: "GET /healthz", kind: trace.SpanKindServer, want: sdktrace.Drop},
+		{
+			name: "kind and attribute", traceID: high, span: "POST", kind: trace.SpanKindServer,
+			attrs: []attribute.KeyValue{checkout}, want: sdktrace.RecordAndSample,
+		},
+		{
+			name: "kind mismatch", traceID: high, span: "POST", kind: trace.SpanKindClient,
+			attrs: []attribute.KeyValue{checkout}, want: sdktrace.Drop,
+		},
+		{
+			name: "attribute glob applies its ratio", traceID: low, span: "rpc", kind: trace.SpanKindClient,
+			attrs: []attribute.KeyValue{attribute.String("rpc.method", "GetCart")}, want: sdktrace.RecordAndSample,
+		},
+		{name: "default ratio below", traceID: low, span: "other", kind: trace.SpanKindInternal, want: sdktrace.RecordAndSample},
+		{name: "default ratio above", traceID: high, span: "other", kind: trace.SpanKindInternal, want: sdktrace.Drop},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := sampler.ShouldSample(sdktrace.SamplingParameters{
+				ParentContext: context.Background(),
+				TraceID:       tc.traceID,
+				Name:          tc.span,
+				Kind:          tc.kind,
+				Attributes:    tc.attrs,
+			}).Decision
+			if got != tc.want {
+				t.Fatalf("expected decision %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+// The SDK does not pass the scope to samplers, so the scoped tracers put it on the context.
+func TestRulesSamplerMatchesInstrumentationScope(t *testing.T) {
+	t.Parallel()
+
+	cfg := rulesTestConfig()
+
+	sampler, err := samplerFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("samplerFromConfig: %v", err)
+	}
+
+	tracers := scopedTracerProvider(cfg, sdktrace.NewTracerProvider(sdktrace.WithSampler(sampler)))
+
+	t.Run("matching scope", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, batch := tracers.Tracer("github.com/acme/batch/jobs").Start(context.Background(), "GET /cart/{id}")
+		if batch.SpanContext().IsSampled() {
+			t.Fatal("expected spans from the matching scope to be dropped")
+		}
+
+		if ctx.Value(scopeContextKey{}) != nil {
+			t.Fatal("expected the scope not to leak into the returned context")
+		}
+	})
+
+	t.Run("other scope", func(t *testing.T) {
+		t.Parallel()
+
+		_, api := tracers.Tracer("github.com/acme/api").Start(context.Background(), "GET /cart/{id}", trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attribute.String("http.route", "/checkout/{cart}")))
+		if !api.SpanContext().IsSampled() {
+			t.Fatal("expected spans from other scopes to reach the later rules")
+		}
+	})
+}
+
+// The instrumentation sets the attributes rules match on when it starts a span, so the
+// sampler sees them.
+func TestRulesSamplerMatchesInstrumentationAttributes(t *testing.T) {
+	t.Parallel()
+
+	sampler, err := samplerFromConfig(config.SamplingConfig{
+		Mode:     "rules",
+		Argument: 1,
+		Rules: []config.SamplingRuleConfig{
+			{Match: config.SamplingRuleMatch{Kind: "server", Attributes: map[string]string{"http.route": "/checkout/*"}}, Sampler: "always_on"},
+			{Match: config.SamplingRuleMatch{Attributes: map[string]string{"rpc.method": "Charge"}}, Sampler: "always_on"},
+			{Sampler: "always_off"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("samplerFromConfig: %v", err)
+	}
+
+	httpRequest := func(path string) func(*testing.T, trace.TracerProvider) {
+		return func(t *testing.T, tp trace.TracerProvider) {
+			t.Helper()
+
+			mw, err := observehttp.NewMiddleware(tp, metricnoop.NewMeterProvider(), config.HTTPInstrumentationConfig{Enabled: true})
+			if err != nil {
+				t.Fatalf("NewMiddleware: %v", err)
+			}
+
+			handler := mw.Handler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) }))
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, path, nil))
+		}
+	}
+
+	grpcServer := func(method string) func(*testing.T, trace.TracerProvider) {
+		return func(t *testing.T, tp trace.TracerProvider) {
+			t.Helper()
+
+			interceptors := observegrpc.NewInterceptors(tp, config.GRPCInstrumentationConfig{Enabled: true})
+			info := &grpc.UnaryServerInfo{FullMethod: "/payments.v1.Payments/" + method}
+
+			_, err := interceptors.UnaryServer()(context.Background(), nil, info, func(context.Context, any) (any, error) {
+				return struct{}{}, nil
+			})
+			if err != nil {
+				t.Fatalf("server interceptor: %v", err)
+			}
+		}
+	}
+
+	grpcClient := func(method string) func(*testing.T, trace.TracerProvider) {
+		return func(t *testing.T, tp trace.TracerProvider) {
+			t.Helper()
+
+			interceptors := observegrpc.NewInterceptors(tp, config.GRPCInstrumentationConfig{Enabled: true})
+			invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error { return nil }
+
+			err := interceptors.UnaryClient()(context.Background(), "/payments.v1.Payments/"+method, nil, nil, nil, invoker)
+			if err != nil {
+				t.Fatalf("client interceptor: %v", err)
+			}
+		}
+	}
+
+	tests := []struct {
+		name    string
+		send    func(*testing.T, trace.TracerProvider)
+		sampled bool
+	}{
+		{name: "http route matching a rule", send: httpRequest("/checkout/42"), sampled: true},
+		{name: "http route matching no rule", send: httpRequest("/browse"), sampled: false},
+		{name: "grpc server method matching a rule", send: grpcServer("Charge"), sampled: true},
+		{name: "grpc server method matching no rule", send: grpcServer("Refund"), sampled: false},
+		{name: "grpc client method matching a rule", send: grpcClient("Charge"), sampled: true},
+		{name: "grpc client method matching no rule", send: grpcClient("Refund"), sampled: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sampler), sdktrace.WithSpanProcessor(recorder))
+
+			tc.send(t, tp)
+
+			if sampled := len(recorder.Ended()) == 1; sampled != tc.sampled {
+				t.Fatalf("expected sampled=%v, got %d recorded spans", tc.sampled, len(recorder.Ended()))
+			}
+		})
+	}
+}
@@ -4,22 +4,32 @@ package runtime
 import (
 	"context"
 	"errors"
+	"net/http"
 	"sync"
 	"time"
 
 	"github.com/hyp3rd/ewrap"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+	lognoop "go.opentelemetry.io/otel/log/noop"
 	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/propagation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
-	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
 	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
 	"google.golang.org/grpc"
 
+	"github.com/hyp3rd/observe/pkg/baggage"
 	"github.com/hyp3rd/observe/pkg/config"
 	"github.com/hyp3rd/observe/pkg/diagnostics"
+	"github.com/hyp3rd/observe/pkg/exporters"
 	observegrpc "github.com/hyp3rd/observe/pkg/instrumentation/grpc"
 	observehttp "github.com/hyp3rd/observe/pkg/instrumentation/http"
 	observemsg "github.com/hyp3rd/observe/pkg/instrumentation/messaging"
@@ -32,8 +42,14 @@ type Runtime struct {
 	cfg config.Config
 
 	tracerProvider  *sdktrace.TracerProvider
+	tracers         trace.TracerProvider
 	meterProvider   *sdkmetric.MeterProvider
+	loggerProvider  *sdklog.LoggerProvider
+	propagator      propagation.TextMapPropagator
 	exporters       *exporterBundle
+	prometheus      *prometheusEndpoint
+	tenantLimiter   *tenantLimiter
+	tailSampler     *tailSampler
 	httpMiddleware  *observehttp.Middleware
 	grpcServerInt   grpc.UnaryServerInterceptor
 	grpcClientInt   grpc.UnaryClientInterceptor
@@ -56,84 +72,208 @@ type runtimeState struct {
 	shutdown bool
 }
 
-// New creates a Runtime from the supplied Config.
+// New creates a Runtime from the supplied Config. When a step fails, whatever was already
+// built is shut down before the error is returned.
 //
 //nolint:revive // cognitive-complexity: acceptable for a constructor function.
-func New(ctx context.Context, cfg config.Config) (*Runtime, error) {
-	exporters, err := newExporterBundle(ctx, cfg.Exporters)
+func New(ctx context.Context, cfg config.Config) (_ *Runtime, err error) {
+	propagator, err := propagatorFromConfig(cfg.Propagation)
+	if err != nil {
+		return nil, ewrap.Wrap(err, "build propagator")
+	}
+
+	if cfg.SDKDisabled {
+		return newDisabledRuntime(ctx, cfg, propagator)
+	}
+
+	views, err := buildViews(cfg.Metrics)
+	if err != nil {
+		return nil, ewrap.Wrap(err, "build metric views")
+	}
+
+	bundle, err := newExporterBundle(ctx, cfg.Exporters, exporters.Default())
 	if err != nil {
 		return nil, ewrap.Wrap(err, "build exporters")
 	}
 
+	cleanup := bundle.shutdown
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, cleanup(context.WithoutCancel(ctx)))
+		}
+	}()
+
+	var prom *prometheusEndpoint
+	if prometheusEnabled(cfg.Exporters) {
+		prom, err = newPrometheusEndpoint(*cfg.Exporters.Prometheus)
+		if err != nil {
+			return nil, ewrap.Wrap(err, "build prometheus reader")
+		}
+	}
+
 	res, err := buildResource(ctx, cfg.Service)
 	if err != nil {
 		return nil, ewrap.Wrap(err, "build resource")
 	}
 
-	tp, err := buildTracerProvider(cfg, res, exporters.traceExporter)
+	limiter := newTenantLimiter(cfg.Sampling.TenantLimiter)
+	tail := newTailSampler(cfg.Sampling.Tail)
+
+	tp, err := buildTracerProvider(cfg, res, bundle.traces, limiter, tail)
 	if err != nil {
 		return nil, ewrap.Wrap(err, "build tracer provider")
 	}
 
-	mp := buildMeterProvider(res, exporters.metricReader)
+	readers := bundle.metricReaders()
+	if prom != nil {
+		readers = append(readers, prom.reader)
+	}
 
-	otel.SetTracerProvider(tp)
-	otel.SetMeterProvider(mp)
+	mp := buildMeterProvider(res, readers, views)
+	lp := buildLoggerProvider(res, bundle.logs)
+
+	tracers := scopedTracerProvider(cfg.Sampling, tp)
 
 	rt := &Runtime{
 		cfg:            cfg,
 		tracerProvider: tp,
+		tracers:        tracers,
 		meterProvider:  mp,
-		exporters:      exporters,
+		loggerProvider: lp,
+		propagator:     propagator,
+		exporters:      bundle,
+		prometheus:     prom,
+		tenantLimiter:  limiter,
+		tailSampler:    tail,
 		startTime:      time.Now().UTC(),
 	}
 	rt.lastReload = rt.startTime
+	cleanup = rt.Shutdown
+
+	err = rt.initInstrumentation(tracers, mp)
+	if err != nil {
+		return nil, err
+	}
 
-	if cfg.Instrumentation.HTTP.Enabled {
-		mw, err := observehttp.NewMiddleware(tp, mp, cfg.Instrumentation.HTTP)
+	err = prom.start(ctx)
+	if err != nil {
+		return nil, ewrap.Wrap(err, "start prometheus endpoint")
+	}
+
+	if cfg.Diagnostics.Enabled {
+		err = rt.startDiagnosticsServer(ctx, cfg.Diagnostics)
 		if err != nil {
-			return nil, ewrap.Wrap(err, "init http instrumentation")
+			return nil, ewrap.Wrap(err, "start diagnostics server")
 		}
+	}
+
+	// The providers only become global once nothing can fail, so a failed New leaves the
+	// previous globals in place rather than providers that were just shut down.
+	otel.SetTracerProvider(tracers)
+	otel.SetMeterProvider(mp)
+	otel.SetTextMapPropagator(propagator)
+
+	if lp != nil {
+		global.SetLoggerProvider(lp)
+	}
+
+	return rt, nil
+}
 
-		rt.httpMiddleware = mw
+// newDisabledRuntime serves the sdk_disabled kill switch: no-op providers are installed
+// globally and handed to the instrumentation, no exporter is built and no telemetry is
+// recorded. Context propagation and the diagnostics server keep working.
+func newDisabledRuntime(ctx context.Context, cfg config.Config, propagator propagation.TextMapPropagator) (*Runtime, error) {
+	tp := tracenoop.NewTracerProvider()
+	mp := metricnoop.NewMeterProvider()
+
+	rt := &Runtime{
+		cfg:        cfg,
+		propagator: propagator,
+		exporters:  &exporterBundle{},
+		startTime:  time.Now().UTC(),
 	}
+	rt.lastReload = rt.startTime
 
-	if cfg.Instrumentation.GRPC.Enabled {
-		interceptors := observegrpc.NewInterceptors(tp, cfg.Instrumentation.GRPC)
-		rt.grpcServerInt = interceptors.UnaryServer()
-		rt.grpcClientInt = interceptors.UnaryClient()
+	err := rt.initInstrumentation(tp, mp)
+	if err != nil {
+		return nil, err
 	}
 
-	if cfg.Instrumentation.SQL.Enabled {
-		rt.sqlHelper = observesql.NewHelper(cfg.Instrumentation.SQL)
+	if cfg.Diagnostics.Enabled {
+		err := rt.startDiagnosticsServer(ctx, cfg.Diagnostics)
+		if err != nil {
+			return nil, ewrap.Wrap(err, "start diagnostics server")
+		}
 	}
 
-	if cfg.Instrumentation.Messaging.Enabled {
-		mHelper, err := observemsg.NewHelper(tp, mp)
+	// As in New, the no-op providers only become global once nothing can fail.
+	otel.SetTracerProvider(tp)
+	otel.SetMeterProvider(mp)
+	otel.SetTextMapPropagator(propagator)
+	global.SetLoggerProvider(lognoop.NewLoggerProvider())
+
+	return rt, nil
+}
+
+// initInstrumentation builds the instrumentation helpers enabled in the config against tp and mp.
+func (r *Runtime) initInstrumentation(tp trace.TracerProvider, mp metric.MeterProvider) error {
+	if r.cfg.Instrumentation.HTTP.Enabled {
+		mw, err := observehttp.NewMiddleware(
+			tp,
+			mp,
+			r.cfg.Instrumentation.HTTP,
+			observehttp.WithPropagator(r.propagator),
+			observehttp.WithMetricBaggage(r.cfg.Propagation.Baggage.MetricAttributes),
+		)
 		if err != nil {
-			return nil, ewrap.Wrap(err, "init messaging instrumentation")
+			return ewrap.Wrap(err, "init http instrumentation")
 		}
 
-		rt.messagingHelper = mHelper
+		r.httpMiddleware = mw
+	}
+
+	if r.cfg.Instrumentation.GRPC.Enabled {
+		interceptors := observegrpc.NewInterceptors(
+			tp,
+			r.cfg.Instrumentation.GRPC,
+			observegrpc.WithPropagator(r.propagator),
+		)
+		r.grpcServerInt = interceptors.UnaryServer()
+		r.grpcClientInt = interceptors.UnaryClient()
 	}
 
-	if cfg.Instrumentation.Worker.Enabled {
-		wHelper, err := observeworker.NewHelper(tp, mp)
+	if r.cfg.Instrumentation.SQL.Enabled {
+		r.sqlHelper = observesql.NewHelper(r.cfg.Instrumentation.SQL)
+	}
+
+	if r.cfg.Instrumentation.Messaging.Enabled {
+		mHelper, err := observemsg.NewHelper(
+			tp,
+			mp,
+			observemsg.WithMetricBaggage(r.cfg.Propagation.Baggage.MetricAttributes),
+		)
 		if err != nil {
-			return nil, ewrap.Wrap(err, "init worker instrumentation")
+			return ewrap.Wrap(err, "init messaging instrumentation")
 		}
 
-		rt.workerHelper = wHelper
+		r.messagingHelper = mHelper
 	}
 
-	if cfg.Diagnostics.Enabled {
-		err := rt.startDiagnosticsServer(ctx, cfg.Diagnostics)
+	if r.cfg.Instrumentation.Worker.Enabled {
+		wHelper, err := observeworker.NewHelper(
+			tp,
+			mp,
+			observeworker.WithMetricBaggage(r.cfg.Propagation.Baggage.MetricAttributes),
+		)
 		if err != nil {
-			return nil, ewrap.Wrap(err, "start diagnostics server")
+			return ewrap.Wrap(err, "init worker instrumentation")
 		}
+
+		r.workerHelper = wHelper
 	}
 
-	return rt, nil
+	return nil
 }
 
 // Config returns a copy of the currently active configuration.
@@ -145,15 +285,54 @@ func (r *Runtime) Config() config.Config {
 }
 
 // Tracer returns an instrumented tracer for callers to use directly.
+// It is a no-op tracer when the SDK is disabled.
 func (r *Runtime) Tracer(name string, opts ...trace.TracerOption) trace.Tracer {
-	return r.tracerProvider.Tracer(name, opts...)
+	if r.tracers == nil {
+		return tracenoop.NewTracerProvider().Tracer(name, opts...)
+	}
+
+	return r.tracers.Tracer(name, opts...)
 }
 
 // Meter returns a configured meter for instrumentation libraries.
+// It is a no-op meter when the SDK is disabled.
 func (r *Runtime) Meter(name string, opts ...metric.MeterOption) metric.Meter {
+	if r.meterProvider == nil {
+		return metricnoop.NewMeterProvider().Meter(name, opts...)
+	}
+
 	return r.meterProvider.Meter(name, opts...)
 }
 
+// LoggerProvider returns the provider that ships log records through the configured log
+// exporters, or nil when no exporter handles the logs signal.
+func (r *Runtime) LoggerProvider() otellog.LoggerProvider {
+	if r.loggerProvider == nil {
+		return nil
+	}
+
+	return r.loggerProvider
+}
+
+// Propagator returns the text map propagator installed globally by the runtime.
+func (r *Runtime) Propagator() propagation.TextMapPropagator {
+	if r.propagator == nil {
+		return propagation.NewCompositeTextMapPropagator()
+	}
+
+	return r.propagator
+}
+
+// PrometheusHandler exposes the Prometheus scrape handler when exporters.prometheus is enabled,
+// for applications that prefer to mount it on their own mux.
+func (r *Runtime) PrometheusHandler() http.Handler {
+	if r.prometheus == nil {
+		return nil
+	}
+
+	return r.prometheus.reader.Handler
+}
+
 // HTTPMiddleware exposes the HTTP middleware if enabled.
 func (r *Runtime) HTTPMiddleware() *observehttp.Middleware {
 	return r.httpMiddleware
@@ -186,7 +365,7 @@ func (r *Runtime) WorkerHelper() *observeworker.Helper {
 
 // InitMetrics wires runtime-level metrics if enabled in configuration.
 func (r *Runtime) InitMetrics(state *MetricsState) error {
-	if !r.cfg.Instrumentation.RuntimeMetrics.Enabled {
+	if !r.cfg.Instrumentation.RuntimeMetrics.Enabled || r.meterProvider == nil {
 		return nil
 	}
 
@@ -229,6 +408,13 @@ func (r *Runtime) Shutdown(ctx context.Context) error {
 			}
 		}
 
+		if r.loggerProvider != nil {
+			err := r.loggerProvider.Shutdown(ctx)
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+
 		if r.exporters != nil {
 			err := r.exporters.shutdown(ctx)
 			if err != nil {
@@ -236,6 +422,11 @@ func (r *Runtime) Shutdown(ctx context.Context) error {
 			}
 		}
 
+		err := r.prometheus.shutdown(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		}
+
 		if r.metrics != nil {
 			err := r.metrics.shutdown()
 			if err != nil {
@@ -274,23 +465,36 @@ func (r *Runtime) IsShutdown() bool {
 	return r.state.shutdown
 }
 
-func buildTracerProvider(cfg config.Config, res *resource.Resource, traceExp sdktrace.SpanExporter) (*sdktrace.TracerProvider, error) {
+// buildTracerProvider wraps the configured sampler with limiter and places tail in front of
+// the exporter processors; either may be nil.
+func buildTracerProvider(
+	cfg config.Config,
+	res *resource.Resource,
+	traces []traceExport,
+	limiter *tenantLimiter,
+	tail *tailSampler,
+) (*sdktrace.TracerProvider, error) {
 	sampler, err := samplerFromConfig(cfg.Sampling)
 	if err != nil {
 		return nil, err
 	}
 
 	opts := []sdktrace.TracerProviderOption{
-		sdktrace.WithSampler(sampler),
+		sdktrace.WithSampler(limiter.sampler(sampler)),
 		sdktrace.WithResource(res),
 	}
 
-	if traceExp != nil {
-		if cfg.Exporters.OTLP != nil {
-			opts = append(opts, exporterSpanProcessor(cfg.Exporters.OTLP.Batch, traceExp))
-		} else {
-			opts = append(opts, exporterSpanProcessor(config.BatchConfig{Enabled: true}, traceExp))
-		}
+	if promoter := baggage.NewPromoter(cfg.Propagation.Baggage.Attributes); promoter != nil {
+		opts = append(opts, sdktrace.WithSpanProcessor(baggageSpanProcessor{promoter: promoter}))
+	}
+
+	processors := make([]sdktrace.SpanProcessor, 0, len(traces))
+	for _, t := range traces {
+		processors = append(processors, exporterSpanProcessor(t.batch, t.exporter, t.stats))
+	}
+
+	for _, processor := range tail.wrap(processors) {
+		opts = append(opts, sdktrace.WithSpanProcessor(processor))
 	}
 
 	tp := sdktrace.NewTracerProvider(opts...)
@@ -298,36 +502,74 @@ func buildTracerProvider(cfg config.Config, res *resource.Resource, traceExp sdk
 	return tp, nil
 }
 
-func buildMeterProvider(res *resource.Resource, reader *sdkmetric.PeriodicReader) *sdkmetric.MeterProvider {
+func buildMeterProvider(res *resource.Resource, readers []sdkmetric.Reader, views []sdkmetric.View) *sdkmetric.MeterProvider {
 	options := []sdkmetric.Option{
 		sdkmetric.WithResource(res),
+		sdkmetric.WithView(views...),
 	}
-	if reader != nil {
+	for _, reader := range readers {
 		options = append(options, sdkmetric.WithReader(reader))
 	}
 
 	return sdkmetric.NewMeterProvider(options...)
 }
 
-func exporterSpanProcessor(cfg config.BatchConfig, exporter sdktrace.SpanExporter) sdktrace.TracerProviderOption {
+// buildLoggerProvider returns nil when no exporter handles logs, so callers can skip the bridge.
+func buildLoggerProvider(res *resource.Resource, logs []logExport) *sdklog.LoggerProvider {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	options := []sdklog.LoggerProviderOption{sdklog.WithResource(res)}
+	for _, l := range logs {
+		options = append(options, sdklog.WithProcessor(exporterLogProcessor(l.batch, l.exporter)))
+	}
+
+	return sdklog.NewLoggerProvider(options...)
+}
+
+func exporterLogProcessor(cfg config.BatchConfig, exporter sdklog.Exporter) sdklog.Processor {
 	if !cfg.Enabled {
-		return sdktrace.WithSyncer(exporter)
+		return sdklog.NewSimpleProcessor(exporter)
 	}
 
-	var opts []sdktrace.BatchSpanProcessorOption
+	var opts []sdklog.BatchProcessorOption
 	if cfg.Timeout > 0 {
-		opts = append(opts, sdktrace.WithBatchTimeout(cfg.Timeout))
+		opts = append(opts, sdklog.WithExportInterval(cfg.Timeout))
 	}
 
 	if cfg.MaxExportBatch > 0 {
-		opts = append(opts, sdktrace.WithMaxExportBatchSize(cfg.MaxExportBatch))
+		opts = append(opts, sdklog.WithExportMaxBatchSize(cfg.MaxExportBatch))
+	}
+
+	if cfg.ExportTimeout > 0 {
+		opts = append(opts, sdklog.WithExportTimeout(cfg.ExportTimeout))
 	}
 
 	if cfg.MaxQueueSize > 0 {
-		opts = append(opts, sdktrace.WithMaxQueueSize(cfg.MaxQueueSize))
+		opts = append(opts, sdklog.WithMaxQueueSize(cfg.MaxQueueSize))
 	}
 
-	return sdktrace.WithBatcher(exporter, opts...)
+	return sdklog.NewBatchProcessor(exporter, opts...)
+}
+
+// exporterSpanProcessor batches through instrumentedBatchProcessor rather than the SDK
+// BatchSpanProcessor, so queue depth and queue-full drops show up in the diagnostics.
+func exporterSpanProcessor(
+	cfg config.BatchConfig,
+	exporter sdktrace.SpanExporter,
+	stats *traceExporterStats,
+) sdktrace.SpanProcessor {
+	if !cfg.Enabled {
+		return sdktrace.NewSimpleSpanProcessor(exporter)
+	}
+
+	var batch *batchStats
+	if stats != nil {
+		batch = stats.batch
+	}
+
+	return newInstrumentedBatchProcessor(exporter, cfg, batch)
 }
 
 func buildResource(ctx context.Context, svc config.ServiceConfig) (*resource.Resource, error) {
@@ -387,6 +629,13 @@ func samplerFromConfig(cfg config.SamplingConfig) (sdktrace.Sampler, error) {
 		}
 
 		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.Argument)), nil
+	case "rules":
+		sampler, err := newRulesSampler(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		return sdktrace.ParentBased(sampler), nil
 	default:
 		return nil, ewrap.Newf("unsupported sampling mode %q", cfg.Mode)
 	}
@@ -397,12 +646,18 @@ func (r *Runtime) Snapshot() diagnostics.Snapshot {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	queueLimit := int64(0)
-	droppedSpans := int64(0)
+	var queueLimit, droppedSpans, queueDepth, queueDropped int64
+
+	if r.exporters != nil {
+		for _, t := range r.exporters.traces {
+			queueLimit += t.stats.queueLimit
+			droppedSpans += t.stats.dropped.Load()
 
-	if r.exporters != nil && r.exporters.traceStats != nil {
-		queueLimit = r.exporters.traceStats.queueLimit
-		droppedSpans = r.exporters.traceStats.dropped.Load()
+			if batch := t.stats.batch.snapshot(); batch != nil {
+				queueDepth += batch.QueueDepth
+				queueDropped += batch.QueueDropped
+			}
+		}
 	}
 
 	return diagnostics.Snapshot{
@@ -411,6 +666,8 @@ func (r *Runtime) Snapshot() diagnostics.Snapshot {
 		Environment:      r.cfg.Service.Environment,
 		SamplingMode:     r.cfg.Sampling.Mode,
 		ExporterEndpoint: endpointForSnapshot(r.cfg),
+		ExportMode:       exportMode(r.cfg),
+		ExportedSignals:  r.exportedSignals(),
 		StartTime:        r.startTime,
 		LastReloadTime:   r.lastReload,
 		Instrumentation: map[string]bool{
@@ -424,17 +681,50 @@ func (r *Runtime) Snapshot() diagnostics.Snapshot {
 		ConfigReloadCount: reloadCount(r.metricsState),
 		TraceQueueLimit:   queueLimit,
 		TraceDroppedSpans: droppedSpans,
+		TraceQueueDepth:   queueDepth,
+		TraceQueueDropped: queueDropped,
 		TraceExporter:     exporterStatus(r.exporters),
 		MetricExporter:    metricExporterStatus(r.exporters),
+		TraceExporters:    traceExporterStatuses(r.exporters),
+		MetricExporters:   metricExporterStatuses(r.exporters),
+		LogExporters:      logExporterStatuses(r.exporters),
+		TenantLimiter:     r.tenantLimiter.snapshot(),
+		TailSampling:      r.tailSampler.snapshot(),
 	}
 }
 
 func endpointForSnapshot(cfg config.Config) string {
-	if cfg.Exporters.OTLP == nil {
+	if cfg.Exporters.OTLP == nil || cfg.SDKDisabled || !cfg.Exporters.SignalEnabled("traces") {
 		return ""
 	}
 
-	return cfg.Exporters.OTLP.Endpoint
+	return cfg.Exporters.OTLP.ForSignal("traces").Endpoint
+}
+
+// exportMode reports "disabled" under the SDK kill switch, "none" when no signal is exported
+// and "export" otherwise.
+func exportMode(cfg config.Config) string {
+	switch {
+	case cfg.SDKDisabled:
+		return "disabled"
+	case !cfg.Exporters.Exporting():
+		return config.ExportModeNone
+	default:
+		return "export"
+	}
+}
+
+// exportedSignals reports which signals have at least one exporter or a Prometheus reader.
+func (r *Runtime) exportedSignals() map[string]bool {
+	signals := map[string]bool{"traces": false, "metrics": r.prometheus != nil, "logs": false}
+
+	if r.exporters != nil {
+		signals["traces"] = len(r.exporters.traces) > 0
+		signals["metrics"] = signals["metrics"] || len(r.exporters.metrics) > 0
+		signals["logs"] = len(r.exporters.logs) > 0
+	}
+
+	return signals
 }
 
 func reloadCount(state *MetricsState) int64 {
@@ -446,23 +736,69 @@ func reloadCount(state *MetricsState) int64 {
 }
 
 func exporterStatus(bundle *exporterBundle) diagnostics.ExporterStatus {
-	if bundle == nil || bundle.traceStats == nil {
+	stats := bundle.primaryTraceStats()
+	if stats == nil {
 		return diagnostics.ExporterStatus{}
 	}
 
-	return bundle.traceStats.statusSnapshot()
+	return stats.statusSnapshot()
 }
 
 func metricExporterStatus(bundle *exporterBundle) diagnostics.ExporterStatus {
-	if bundle == nil || bundle.metricStats == nil {
+	stats := bundle.primaryMetricStats()
+	if stats == nil {
 		return diagnostics.ExporterStatus{}
 	}
 
-	return bundle.metricStats.statusSnapshot()
+	return stats.statusSnapshot()
+}
+
+func traceExporterStatuses(bundle *exporterBundle) []diagnostics.ExporterStatus {
+	if bundle == nil || len(bundle.traces) == 0 {
+		return nil
+	}
+
+	statuses := make([]diagnostics.ExporterStatus, 0, len(bundle.traces))
+	for _, t := range bundle.traces {
+		statuses = append(statuses, t.stats.statusSnapshot())
+	}
+
+	return statuses
+}
+
+func metricExporterStatuses(bundle *exporterBundle) []diagnostics.ExporterStatus {
+	if bundle == nil || len(bundle.metrics) == 0 {
+		return nil
+	}
+
+	statuses := make([]diagnostics.ExporterStatus, 0, len(bundle.metrics))
+	for _, m := range bundle.metrics {
+		statuses = append(statuses, m.stats.statusSnapshot())
+	}
+
+	return statuses
+}
+
+func logExporterStatuses(bundle *exporterBundle) []diagnostics.ExporterStatus {
+	if bundle == nil || len(bundle.logs) == 0 {
+		return nil
+	}
+
+	statuses := make([]diagnostics.ExporterStatus, 0, len(bundle.logs))
+	for _, l := range bundle.logs {
+		statuses = append(statuses, l.stats.statusSnapshot())
+	}
+
+	return statuses
 }
 
 func (r *Runtime) startDiagnosticsServer(ctx context.Context, cfg config.DiagnosticsConfig) error {
-	server := diagnostics.NewServer(cfg, r)
+	var opts []diagnostics.Option
+	if r.prometheus != nil && !r.prometheus.standalone() {
+		opts = append(opts, diagnostics.WithHandler(r.prometheus.reader.Path, r.prometheus.reader.Handler))
+	}
+
+	server := diagnostics.NewServer(cfg, r, opts...)
 
 	err := server.Start(ctx)
 	if err != nil {
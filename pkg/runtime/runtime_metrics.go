@@ -2,17 +2,23 @@ package runtime
 
 import (
 	"context"
+	"time"
 
 	"github.com/hyp3rd/ewrap"
 	runtimemetrics "go.opentelemetry.io/contrib/instrumentation/runtime"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	"github.com/hyp3rd/observe/pkg/config"
+	"github.com/hyp3rd/observe/pkg/exporters"
 )
 
 type runtimeMetricsController struct {
 	state        *MetricsState
 	registration metric.Registration
+	batches      []*batchStats
+	limiter      *tenantLimiter
 }
 
 func (c *runtimeMetricsController) start(rt *Runtime, provider *sdkmetric.MeterProvider) error {
@@ -34,6 +40,8 @@ func (c *runtimeMetricsController) start(rt *Runtime, provider *sdkmetric.MeterP
 	}
 
 	c.registration = reg
+	c.batches = instruments.exporterBatch.install(rt.exporters)
+	c.limiter = instruments.tenant.install(rt.tenantLimiter)
 
 	return nil
 }
@@ -43,6 +51,14 @@ func (c *runtimeMetricsController) shutdown() error {
 		return nil
 	}
 
+	for _, batch := range c.batches {
+		batch.recorder.Store(nil)
+	}
+
+	if c.limiter != nil {
+		c.limiter.recorder.Store(nil)
+	}
+
 	if c.registration != nil {
 		err := c.registration.Unregister()
 		if err != nil {
@@ -67,6 +83,42 @@ type runtimeInstruments struct {
 	instrumentationGauge metric.Int64ObservableGauge
 	queueGauge           metric.Int64ObservableGauge
 	droppedCounter       metric.Int64ObservableCounter
+	exporterQueue        *queueInstruments
+	exporterCircuit      *circuitInstruments
+	exporterBatch        *batchInstruments
+	tenant               *tenantInstruments
+	tlsExpiry            metric.Float64ObservableGauge
+}
+
+// queueInstruments report the persistent exporter queues, one series per exporter and signal.
+type queueInstruments struct {
+	depth    metric.Int64ObservableGauge
+	bytes    metric.Int64ObservableGauge
+	replayed metric.Int64ObservableCounter
+	evicted  metric.Int64ObservableCounter
+}
+
+// circuitInstruments report the exporter circuit breakers, one series per exporter and signal.
+type circuitInstruments struct {
+	state       metric.Int64ObservableGauge
+	transitions metric.Int64ObservableCounter
+	rejected    metric.Int64ObservableCounter
+}
+
+// batchInstruments report the in-memory span batch queues. Depth and queue-full drops are
+// observed in the callback; batch sizes and export latencies are recorded on every export.
+type batchInstruments struct {
+	depth     metric.Int64ObservableGauge
+	dropped   metric.Int64ObservableCounter
+	batchSize metric.Int64Histogram
+	duration  metric.Float64Histogram
+}
+
+// tenantInstruments report the per-tenant sampling limiter. Throttled traces are counted per
+// tenant as they are dropped; the tracked tenants are observed in the callback.
+type tenantInstruments struct {
+	throttled metric.Int64Counter
+	tracked   metric.Int64ObservableGauge
 }
 
 func newRuntimeInstruments(provider *sdkmetric.MeterProvider) (*runtimeInstruments, error) {
@@ -104,15 +156,297 @@ func newRuntimeInstruments(provider *sdkmetric.MeterProvider) (*runtimeInstrumen
 		return nil, ewrap.Wrap(err, "create dropped spans counter")
 	}
 
+	exporterQueue, err := newQueueInstruments(meter)
+	if err != nil {
+		return nil, err
+	}
+
+	exporterCircuit, err := newCircuitInstruments(meter)
+	if err != nil {
+		return nil, err
+	}
+
+	exporterBatch, err := newBatchInstruments(meter)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant, err := newTenantInstruments(meter)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsExpiry, err := meter.Float64ObservableGauge(
+		"observe.runtime.exporter.tls.expiry",
+		metric.WithDescription("Time left before an exporter's client certificate or CA bundle expires"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, ewrap.Wrap(err, "create exporter tls expiry gauge")
+	}
+
 	return &runtimeInstruments{
 		meter:                meter,
 		configReloads:        configReloads,
 		instrumentationGauge: instrumentationGauge,
 		queueGauge:           queueGauge,
 		droppedCounter:       droppedCounter,
+		exporterQueue:        exporterQueue,
+		exporterCircuit:      exporterCircuit,
+		exporterBatch:        exporterBatch,
+		tenant:               tenant,
+		tlsExpiry:            tlsExpiry,
 	}, nil
 }
 
+func newQueueInstruments(meter metric.Meter) (*queueInstruments, error) {
+	depth, err := meter.Int64ObservableGauge(
+		"observe.runtime.exporter.queue.depth",
+		metric.WithDescription("Number of batches waiting in the persistent exporter queue"),
+	)
+	if err != nil {
+		return nil, ewrap.Wrap(err, "create exporter queue depth gauge")
+	}
+
+	bytes, err := meter.Int64ObservableGauge(
+		"observe.runtime.exporter.queue.size",
+		metric.WithDescription("Bytes on disk used by the persistent exporter queue"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, ewrap.Wrap(err, "create exporter queue size gauge")
+	}
+
+	replayed, err := meter.Int64ObservableCounter(
+		"observe.runtime.exporter.queue.replayed",
+		metric.WithDescription("Cumulative number of queued batches replayed to the exporter"),
+	)
+	if err != nil {
+		return nil, ewrap.Wrap(err, "create exporter queue replayed counter")
+	}
+
+	evicted, err := meter.Int64ObservableCounter(
+		"observe.runtime.exporter.queue.evicted",
+		metric.WithDescription("Cumulative number of queued batches evicted by the queue policy"),
+	)
+	if err != nil {
+		return nil, ewrap.Wrap(err, "create exporter queue evicted counter")
+	}
+
+	return &queueInstruments{depth: depth, bytes: bytes, replayed: replayed, evicted: evicted}, nil
+}
+
+func (qi *queueInstruments) instruments() []metric.Observable {
+	return []metric.Observable{qi.depth, qi.bytes, qi.replayed, qi.evicted}
+}
+
+func (qi *queueInstruments) observe(observer metric.Observer, stats func() exporters.QueueStats, signal, name string) {
+	if stats == nil {
+		return
+	}
+
+	q := stats()
+	attrs := metric.WithAttributes(
+		attribute.String("signal", signal),
+		attribute.String("exporter", name),
+	)
+
+	observer.ObserveInt64(qi.depth, q.Depth, attrs)
+	observer.ObserveInt64(qi.bytes, q.Bytes, attrs)
+	observer.ObserveInt64(qi.replayed, q.Replayed, attrs)
+	observer.ObserveInt64(qi.evicted, q.Evicted, attrs)
+}
+
+func newCircuitInstruments(meter metric.Meter) (*circuitInstruments, error) {
+	state, err := meter.Int64ObservableGauge(
+		"observe.runtime.exporter.circuit.state",
+		metric.WithDescription("Exporter circuit breaker state (0=closed,1=half_open,2=open)"),
+	)
+	if err != nil {
+		return nil, ewrap.Wrap(err, "create exporter circuit state gauge")
+	}
+
+	transitions, err := meter.Int64ObservableCounter(
+		"observe.runtime.exporter.circuit.transitions",
+		metric.WithDescription("Cumulative number of exporter circuit breaker state changes"),
+	)
+	if err != nil {
+		return nil, ewrap.Wrap(err, "create exporter circuit transitions counter")
+	}
+
+	rejected, err := meter.Int64ObservableCounter(
+		"observe.runtime.exporter.circuit.rejected",
+		metric.WithDescription("Cumulative number of items not exported while the circuit was open"),
+	)
+	if err != nil {
+		return nil, ewrap.Wrap(err, "create exporter circuit rejected counter")
+	}
+
+	return &circuitInstruments{state: state, transitions: transitions, rejected: rejected}, nil
+}
+
+func (ci *circuitInstruments) instruments() []metric.Observable {
+	return []metric.Observable{ci.state, ci.transitions, ci.rejected}
+}
+
+func (ci *circuitInstruments) observe(observer metric.Observer, breaker *circuitBreaker, signal, name string) {
+	status := breaker.snapshot()
+	if status == nil {
+		return
+	}
+
+	attrs := metric.WithAttributes(
+		attribute.String("signal", signal),
+		attribute.String("exporter", name),
+	)
+
+	observer.ObserveInt64(ci.state, circuitStateValue(status.State), attrs)
+	observer.ObserveInt64(ci.transitions, status.Transitions, attrs)
+	observer.ObserveInt64(ci.rejected, status.Rejected, attrs)
+}
+
+func circuitStateValue(state string) int64 {
+	switch state {
+	case breakerHalfOpen:
+		return 1
+	case breakerOpen:
+		return 2
+	default:
+		return 0
+	}
+}
+
+func newBatchInstruments(meter metric.Meter) (*batchInstruments, error) {
+	depth, err := meter.Int64ObservableGauge(
+		"observe.runtime.trace.queue.depth",
+		metric.WithDescription("Number of spans waiting in the trace batch processor queue"),
+	)
+	if err != nil {
+		return nil, ewrap.Wrap(err, "create trace queue depth gauge")
+	}
+
+	dropped, err := meter.Int64ObservableCounter(
+		"observe.runtime.trace.queue.dropped_spans",
+		metric.WithDescription("Cumulative number of spans dropped because the trace batch processor queue was full"),
+	)
+	if err != nil {
+		return nil, ewrap.Wrap(err, "create trace queue dropped spans counter")
+	}
+
+	batchSize, err := meter.Int64Histogram(
+		"observe.runtime.trace.export.batch_size",
+		metric.WithDescription("Number of spans in each batch handed to the trace exporter"),
+	)
+	if err != nil {
+		return nil, ewrap.Wrap(err, "create trace export batch size histogram")
+	}
+
+	duration, err := meter.Float64Histogram(
+		"observe.runtime.trace.export.duration",
+		metric.WithDescription("Time taken by the trace exporter to export a batch"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, ewrap.Wrap(err, "create trace export duration histogram")
+	}
+
+	return &batchInstruments{depth: depth, dropped: dropped, batchSize: batchSize, duration: duration}, nil
+}
+
+func (bi *batchInstruments) instruments() []metric.Observable {
+	return []metric.Observable{bi.depth, bi.dropped}
+}
+
+// install points the batch processors of bundle at the export histograms and returns the
+// stats it touched, so shutdown can detach them again.
+func (bi *batchInstruments) install(bundle *exporterBundle) []*batchStats {
+	if bundle == nil {
+		return nil
+	}
+
+	var installed []*batchStats
+
+	for _, t := range bundle.traces {
+		if t.stats == nil || t.stats.batch == nil {
+			continue
+		}
+
+		attrs := metric.WithAttributes(
+			attribute.String("signal", "traces"),
+			attribute.String("exporter", t.name),
+		)
+
+		var recorder exportRecorder = func(ctx context.Context, spans int, latency time.Duration) {
+			bi.batchSize.Record(ctx, int64(spans), attrs)
+			bi.duration.Record(ctx, latency.Seconds(), attrs)
+		}
+
+		t.stats.batch.recorder.Store(&recorder)
+		installed = append(installed, t.stats.batch)
+	}
+
+	return installed
+}
+
+func (bi *batchInstruments) observe(observer metric.Observer, stats *batchStats, name string) {
+	status := stats.snapshot()
+	if status == nil {
+		return
+	}
+
+	attrs := metric.WithAttributes(
+		attribute.String("signal", "traces"),
+		attribute.String("exporter", name),
+	)
+
+	observer.ObserveInt64(bi.depth, status.QueueDepth, attrs)
+	observer.ObserveInt64(bi.dropped, status.QueueDropped, attrs)
+}
+
+func newTenantInstruments(meter metric.Meter) (*tenantInstruments, error) {
+	throttled, err := meter.Int64Counter(
+		"observe.runtime.sampler.tenant.throttled",
+		metric.WithDescription("Number of traces dropped because the tenant exhausted its sampling budget"),
+	)
+	if err != nil {
+		return nil, ewrap.Wrap(err, "create tenant throttled counter")
+	}
+
+	tracked, err := meter.Int64ObservableGauge(
+		"observe.runtime.sampler.tenant.tracked",
+		metric.WithDescription("Number of tenants currently tracked by the sampling limiter"),
+	)
+	if err != nil {
+		return nil, ewrap.Wrap(err, "create tenant tracked gauge")
+	}
+
+	return &tenantInstruments{throttled: throttled, tracked: tracked}, nil
+}
+
+// install points limiter at the throttled counter and returns it, so shutdown can detach it again.
+func (ti *tenantInstruments) install(limiter *tenantLimiter) *tenantLimiter {
+	if limiter == nil {
+		return nil
+	}
+
+	var recorder throttleRecorder = func(ctx context.Context, tenant string) {
+		ti.throttled.Add(ctx, 1, metric.WithAttributes(attribute.String("tenant", tenant)))
+	}
+
+	limiter.recorder.Store(&recorder)
+
+	return limiter
+}
+
+func (ti *tenantInstruments) observe(observer metric.Observer, limiter *tenantLimiter) {
+	status := limiter.snapshot()
+	if status == nil {
+		return
+	}
+
+	observer.ObserveInt64(ti.tracked, status.TrackedTenants)
+}
+
 func (ri *runtimeInstruments) registerCallback(rt *Runtime, state *MetricsState) (metric.Registration, error) {
 	reg, err := ri.meter.RegisterCallback(
 		func(_ context.Context, observer metric.Observer) error {
@@ -127,13 +461,14 @@ func (ri *runtimeInstruments) registerCallback(rt *Runtime, state *MetricsState)
 			ri.observeModule(observer, rt.workerHelper != nil, "worker")
 
 			ri.observeTracerStats(observer, rt.exporters)
+			ri.observeQueues(observer, rt.exporters)
+			ri.observeCircuits(observer, rt.exporters)
+			ri.observeTLS(observer, rt.exporters)
+			ri.tenant.observe(observer, rt.tenantLimiter)
 
 			return nil
 		},
-		ri.configReloads,
-		ri.instrumentationGauge,
-		ri.queueGauge,
-		ri.droppedCounter,
+		ri.observables()...,
 	)
 	if err != nil {
 		return nil, ewrap.Wrap(err, "register runtime metrics callback")
@@ -142,6 +477,21 @@ func (ri *runtimeInstruments) registerCallback(rt *Runtime, state *MetricsState)
 	return reg, nil
 }
 
+func (ri *runtimeInstruments) observables() []metric.Observable {
+	observables := []metric.Observable{
+		ri.configReloads,
+		ri.instrumentationGauge,
+		ri.queueGauge,
+		ri.droppedCounter,
+		ri.tlsExpiry,
+		ri.tenant.tracked,
+	}
+	observables = append(observables, ri.exporterQueue.instruments()...)
+	observables = append(observables, ri.exporterCircuit.instruments()...)
+
+	return append(observables, ri.exporterBatch.instruments()...)
+}
+
 func (ri *runtimeInstruments) observeModule(observer metric.Observer, enabled bool, name string) {
 	observer.ObserveInt64(
 		ri.instrumentationGauge,
@@ -151,19 +501,88 @@ func (ri *runtimeInstruments) observeModule(observer metric.Observer, enabled bo
 }
 
 func (ri *runtimeInstruments) observeTracerStats(observer metric.Observer, bundle *exporterBundle) {
-	if bundle == nil || bundle.traceStats == nil {
+	if bundle == nil {
 		return
 	}
 
-	stats := bundle.traceStats
-	observer.ObserveInt64(
-		ri.queueGauge,
-		stats.queueLimit,
-		metric.WithAttributes(attribute.String("signal", "traces")),
-	)
-	observer.ObserveInt64(
-		ri.droppedCounter,
-		stats.dropped.Load(),
-		metric.WithAttributes(attribute.String("signal", "traces")),
-	)
+	for _, t := range bundle.traces {
+		attrs := metric.WithAttributes(
+			attribute.String("signal", "traces"),
+			attribute.String("exporter", t.name),
+		)
+		observer.ObserveInt64(ri.queueGauge, t.stats.queueLimit, attrs)
+		observer.ObserveInt64(ri.droppedCounter, t.stats.dropped.Load(), attrs)
+		ri.exporterBatch.observe(observer, t.stats.batch, t.name)
+	}
+}
+
+func (ri *runtimeInstruments) observeQueues(observer metric.Observer, bundle *exporterBundle) {
+	if bundle == nil {
+		return
+	}
+
+	for _, t := range bundle.traces {
+		ri.exporterQueue.observe(observer, t.stats.queue, "traces", t.name)
+	}
+
+	for _, m := range bundle.metrics {
+		ri.exporterQueue.observe(observer, m.stats.queue, "metrics", m.name)
+	}
+}
+
+func (ri *runtimeInstruments) observeCircuits(observer metric.Observer, bundle *exporterBundle) {
+	if bundle == nil {
+		return
+	}
+
+	for _, t := range bundle.traces {
+		ri.exporterCircuit.observe(observer, t.stats.breaker, "traces", t.name)
+	}
+
+	for _, m := range bundle.metrics {
+		ri.exporterCircuit.observe(observer, m.stats.breaker, "metrics", m.name)
+	}
+
+	for _, l := range bundle.logs {
+		ri.exporterCircuit.observe(observer, l.stats.breaker, "logs", l.name)
+	}
+}
+
+func (ri *runtimeInstruments) observeTLS(observer metric.Observer, bundle *exporterBundle) {
+	if bundle == nil {
+		return
+	}
+
+	for _, t := range bundle.traces {
+		ri.observeCertificates(observer, t.stats.tls, "traces", t.name)
+	}
+
+	for _, m := range bundle.metrics {
+		ri.observeCertificates(observer, m.stats.tls, "metrics", m.name)
+	}
+
+	for _, l := range bundle.logs {
+		ri.observeCertificates(observer, l.stats.tls, "logs", l.name)
+	}
+}
+
+// observeCertificates reports the seconds left on the client certificate and the CA bundle;
+// the value turns negative once a certificate has expired.
+func (ri *runtimeInstruments) observeCertificates(observer metric.Observer, tlsCfg *config.TLSConfig, signal, name string) {
+	status := tlsStatus(tlsCfg)
+	if status == nil {
+		return
+	}
+
+	for certificate, notAfter := range map[string]time.Time{"client": status.CertNotAfter, "ca": status.CANotAfter} {
+		if notAfter.IsZero() {
+			continue
+		}
+
+		observer.ObserveFloat64(ri.tlsExpiry, time.Until(notAfter).Seconds(), metric.WithAttributes(
+			attribute.String("signal", signal),
+			attribute.String("exporter", name),
+			attribute.String("certificate", certificate),
+		))
+	}
 }
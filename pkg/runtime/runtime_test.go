@@ -2,14 +2,18 @@ package runtime
 
 import (
 	"context"
+	"net"
 	"testing"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/hyp3rd/observe/pkg/config"
 	"github.com/hyp3rd/observe/pkg/diagnostics"
+	"github.com/hyp3rd/observe/pkg/exporters"
 )
 
 const (
@@ -103,6 +107,56 @@ func TestSamplerFromConfigUnsupportedMode(t *testing.T) {
 	}
 }
 
+func TestPropagatorFromConfigCombinesFormats(t *testing.T) {
+	t.Parallel()
+
+	prop, err := propagatorFromConfig(config.PropagationConfig{
+		Propagators: []string{"tracecontext", "b3multi", "jaeger", "baggage"},
+	})
+	if err != nil {
+		t.Fatalf("propagatorFromConfig returned error: %v", err)
+	}
+
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanCtx)
+
+	carrier := propagation.MapCarrier{}
+	prop.Inject(ctx, carrier)
+
+	for _, key := range []string{"traceparent", "x-b3-traceid", "uber-trace-id"} {
+		if carrier.Get(key) == "" {
+			t.Fatalf("expected %s header to be injected, got %v", key, carrier)
+		}
+	}
+
+	extracted := trace.SpanContextFromContext(prop.Extract(context.Background(), carrier))
+	if extracted.TraceID() != spanCtx.TraceID() {
+		t.Fatalf("expected trace id %s, got %s", spanCtx.TraceID(), extracted.TraceID())
+	}
+}
+
+func TestPropagatorFromConfigNone(t *testing.T) {
+	t.Parallel()
+
+	prop, err := propagatorFromConfig(config.PropagationConfig{Propagators: []string{"none"}})
+	if err != nil {
+		t.Fatalf("propagatorFromConfig returned error: %v", err)
+	}
+
+	if fields := prop.Fields(); len(fields) != 0 {
+		t.Fatalf("expected no propagation fields, got %v", fields)
+	}
+
+	_, err = propagatorFromConfig(config.PropagationConfig{Propagators: []string{"xray"}})
+	if err == nil {
+		t.Fatal("expected error for unsupported propagator, got nil")
+	}
+}
+
 func TestEndpointForSnapshot(t *testing.T) {
 	t.Parallel()
 
@@ -219,8 +273,8 @@ func TestSnapshotExporterStatus(t *testing.T) {
 			},
 		},
 		exporters: &exporterBundle{
-			traceStats:  traceStats,
-			metricStats: metricStats,
+			traces:  []traceExport{{name: "otlp", stats: traceStats}},
+			metrics: []metricExport{{name: "otlp", stats: metricStats}},
 		},
 	}
 
@@ -334,3 +388,174 @@ func assertSnapshotDefaults(t *testing.T, snap diagnostics.Snapshot) {
 		t.Fatalf("expected empty metric exporter endpoint, got %s", snap.MetricExporter.Endpoint)
 	}
 }
+
+func TestNewWithoutExportersKeepsRecording(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Diagnostics.Enabled = false
+	cfg.Exporters.Mode = config.ExportModeNone
+	cfg.Exporters.OTLP.Endpoint = ""
+
+	err := config.Validate(cfg)
+	if err != nil {
+		t.Fatalf("expected mode none to need no endpoint, got %v", err)
+	}
+
+	rt, err := New(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	defer func() { _ = rt.Shutdown(context.Background()) }()
+
+	_, span := rt.Tracer("test").Start(context.Background(), "op")
+	if !span.IsRecording() || !span.SpanContext().IsSampled() {
+		t.Fatal("expected spans to be recorded and sampled without exporters")
+	}
+
+	span.End()
+
+	snap := rt.Snapshot()
+	if snap.ExportMode != config.ExportModeNone || snap.ExporterEndpoint != "" {
+		t.Fatalf("expected the snapshot to report mode none, got %q (%q)", snap.ExportMode, snap.ExporterEndpoint)
+	}
+
+	for signal, exported := range snap.ExportedSignals {
+		if exported {
+			t.Fatalf("expected %s not to be exported", signal)
+		}
+	}
+}
+
+func TestNewShutsDownWhatItBuiltWhenStartupFails(t *testing.T) {
+	t.Parallel()
+
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	defer busy.Close()
+
+	promAddr := freeAddr(t)
+
+	cfg := config.DefaultConfig()
+	cfg.Exporters.OTLP = nil
+	cfg.Exporters.Prometheus = &config.PrometheusConfig{Enabled: true, Path: "/metrics", ListenAddr: promAddr}
+	cfg.Diagnostics = config.DiagnosticsConfig{Enabled: true, HTTPAddr: busy.Addr().String()}
+
+	_, err = New(context.Background(), cfg)
+	if err == nil {
+		t.Fatal("expected New to fail on the busy diagnostics address")
+	}
+
+	// The Prometheus listener started before the failure must have been released.
+	ln, err := net.Listen("tcp", promAddr)
+	if err != nil {
+		t.Fatalf("expected the prometheus address to be free again, got %v", err)
+	}
+
+	_ = ln.Close()
+}
+
+func freeAddr(t *testing.T) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	addr := ln.Addr().String()
+
+	_ = ln.Close()
+
+	return addr
+}
+
+func TestNewExporterBundleSkipsDisabledSignals(t *testing.T) {
+	t.Parallel()
+
+	disabled := false
+	cfg := config.DefaultConfig().Exporters
+	cfg.Signals.Traces.Enabled = &disabled
+
+	bundle, err := newExporterBundle(context.Background(), cfg, exporters.Default())
+	if err != nil {
+		t.Fatalf("newExporterBundle returned error: %v", err)
+	}
+
+	defer func() { _ = bundle.shutdown(context.Background()) }()
+
+	if len(bundle.traces) != 0 || len(bundle.metrics) != 1 || len(bundle.logs) != 1 {
+		t.Fatalf("expected only the traces exporter to be skipped, got %d/%d/%d",
+			len(bundle.traces), len(bundle.metrics), len(bundle.logs))
+	}
+}
+
+func TestNewSDKDisabledInstallsNoopProviders(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.SDKDisabled = true
+	cfg.Diagnostics.Enabled = false
+	cfg.Exporters.OTLP = nil
+
+	err := config.Validate(cfg)
+	if err != nil {
+		t.Fatalf("expected the kill switch to skip exporter validation, got %v", err)
+	}
+
+	rt, err := New(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	_, span := otel.Tracer("test").Start(context.Background(), "op")
+	if span.IsRecording() {
+		t.Fatal("expected the global tracer provider to be a no-op")
+	}
+
+	_, span = rt.Tracer("test").Start(context.Background(), "op")
+	if span.IsRecording() {
+		t.Fatal("expected the runtime tracer to be a no-op")
+	}
+
+	if rt.HTTPMiddleware() == nil || rt.LoggerProvider() != nil {
+		t.Fatal("expected instrumentation to stay available without a logger provider")
+	}
+
+	if snap := rt.Snapshot(); snap.ExportMode != "disabled" {
+		t.Fatalf("expected the snapshot to report the kill switch, got %q", snap.ExportMode)
+	}
+
+	err = rt.Shutdown(context.Background())
+	if err != nil {
+		t.Fatalf("Shutdown returned error: %v", err)
+	}
+}
+
+func TestNewSDKDisabledKeepsGlobalsWhenStartupFails(t *testing.T) {
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	defer busy.Close()
+
+	previous := sdktrace.NewTracerProvider()
+	defer func() { _ = previous.Shutdown(context.Background()) }()
+
+	otel.SetTracerProvider(previous)
+
+	cfg := config.DefaultConfig()
+	cfg.SDKDisabled = true
+	cfg.Exporters.OTLP = nil
+	cfg.Diagnostics = config.DiagnosticsConfig{Enabled: true, HTTPAddr: busy.Addr().String()}
+
+	_, err = New(context.Background(), cfg)
+	if err == nil {
+		t.Fatal("expected New to fail on the busy diagnostics address")
+	}
+
+	if otel.GetTracerProvider() != previous {
+		t.Fatal("expected a failed New to leave the previous global tracer provider in place")
+	}
+}
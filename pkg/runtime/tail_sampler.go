@@ -0,0 +1,468 @@
+package runtime
+
+import (
+	"container/list"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyp3rd/ewrap"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hyp3rd/observe/pkg/config"
+	"github.com/hyp3rd/observe/pkg/diagnostics"
+)
+
+const (
+	defaultTailDecisionWait = 10 * time.Second
+	defaultTailMaxTraces    = 10000
+	defaultTailMaxSpans     = 100000
+	tailTicksPerWait        = 10
+)
+
+// tailSampler is a span processor that holds the spans of each trace back from the exporter
+// processors until the trace is decided: when its local root span ends, when decision wait
+// has passed since its first span ended, or early when the buffer exceeds its caps. A kept
+// trace is forwarded span by span to next; a dropped one is discarded. Decisions are
+// remembered for as many traces as the buffer holds, so spans ending after their trace was
+// decided follow it.
+type tailSampler struct {
+	policies  []tailNamedPolicy
+	wait      time.Duration
+	maxTraces int
+	maxSpans  int
+	now       func() time.Time
+	next      []sdktrace.SpanProcessor
+
+	mu      sync.Mutex
+	traces  map[trace.TraceID]*list.Element
+	order   *list.List
+	spans   int
+	decided map[trace.TraceID]bool
+	recent  []trace.TraceID
+	cursor  int
+
+	sampled atomic.Int64
+	dropped atomic.Int64
+	evicted atomic.Int64
+	late    atomic.Int64
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopped  atomic.Bool
+	stopOnce sync.Once
+}
+
+type tailTrace struct {
+	id    trace.TraceID
+	first time.Time
+	spans []sdktrace.ReadOnlySpan
+}
+
+// tailNamedPolicy is a top-level policy, with the number of traces it kept.
+type tailNamedPolicy struct {
+	name    string
+	policy  tailPolicy
+	matched atomic.Int64
+}
+
+// newTailSampler returns nil when tail sampling is not enabled.
+func newTailSampler(cfg config.TailSamplingConfig) *tailSampler {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	s := &tailSampler{
+		policies:  make([]tailNamedPolicy, len(cfg.Policies)),
+		wait:      durationOr(cfg.DecisionWait, defaultTailDecisionWait),
+		maxTraces: intOr(cfg.MaxTraces, defaultTailMaxTraces),
+		maxSpans:  intOr(cfg.MaxSpans, defaultTailMaxSpans),
+		now:       time.Now,
+		traces:    map[trace.TraceID]*list.Element{},
+		order:     list.New(),
+		decided:   map[trace.TraceID]bool{},
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	s.recent = make([]trace.TraceID, s.maxTraces)
+
+	for i, policy := range cfg.Policies {
+		name := policy.Name
+		if name == "" {
+			name = fmt.Sprintf("%s_%d", policy.Type, i)
+		}
+
+		s.policies[i].name = name
+		s.policies[i].policy = newTailPolicy(policy, s.clock)
+	}
+
+	return s
+}
+
+// wrap places the sampler in front of processors and starts its decision timer; a nil
+// sampler returns processors unchanged.
+func (s *tailSampler) wrap(processors []sdktrace.SpanProcessor) []sdktrace.SpanProcessor {
+	if s == nil {
+		return processors
+	}
+
+	s.next = processors
+
+	go s.run()
+
+	return []sdktrace.SpanProcessor{s}
+}
+
+// clock reads the time through now, so policies follow a clock replaced after construction.
+func (s *tailSampler) clock() time.Time {
+	return s.now()
+}
+
+// OnStart implements sdktrace.SpanProcessor.
+func (s *tailSampler) OnStart(parent context.Context, span sdktrace.ReadWriteSpan) {
+	for _, p := range s.next {
+		p.OnStart(parent, span)
+	}
+}
+
+// OnEnd buffers sampled spans until their trace is decided.
+func (s *tailSampler) OnEnd(span sdktrace.ReadOnlySpan) {
+	if s.stopped.Load() || !span.SpanContext().IsSampled() {
+		return
+	}
+
+	id := span.SpanContext().TraceID()
+
+	s.mu.Lock()
+
+	if keep, ok := s.decided[id]; ok {
+		s.mu.Unlock()
+		s.late.Add(1)
+
+		if keep {
+			s.forward([]sdktrace.ReadOnlySpan{span})
+		}
+
+		return
+	}
+
+	elem := s.buffer(id, span)
+
+	var kept []sdktrace.ReadOnlySpan
+	if parent := span.Parent(); !parent.IsValid() || parent.IsRemote() {
+		kept = s.decide(elem)
+	}
+
+	for s.order.Len() > s.maxTraces || s.spans > s.maxSpans {
+		s.evicted.Add(1)
+
+		kept = append(kept, s.decide(s.order.Front())...)
+	}
+
+	s.mu.Unlock()
+
+	s.forward(kept)
+}
+
+// ForceFlush flushes the exporter processors. Traces still awaiting a decision stay buffered.
+func (s *tailSampler) ForceFlush(ctx context.Context) error {
+	var errs []error
+
+	for _, p := range s.next {
+		err := p.ForceFlush(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Shutdown decides every buffered trace, then shuts the exporter processors down. When ctx
+// ends before the background loop stops, the buffered traces are dropped but the exporter
+// processors are still shut down.
+func (s *tailSampler) Shutdown(ctx context.Context) error {
+	var err error
+
+	s.stopOnce.Do(func() {
+		close(s.stop)
+
+		errs := make([]error, 0, len(s.next)+1)
+
+		select {
+		case <-s.done:
+			s.flush()
+		case <-ctx.Done():
+			s.stopped.Store(true)
+
+			errs = append(errs, ewrap.Wrap(ctx.Err(), "shutdown tail sampler"))
+		}
+
+		for _, p := range s.next {
+			errs = append(errs, p.Shutdown(ctx))
+		}
+
+		err = errors.Join(errs...)
+	})
+
+	return err
+}
+
+// flush decides and forwards every buffered trace, once no more spans are accepted.
+func (s *tailSampler) flush() {
+	s.stopped.Store(true)
+	s.mu.Lock()
+
+	var kept []sdktrace.ReadOnlySpan
+	for s.order.Len() > 0 {
+		kept = append(kept, s.decide(s.order.Front())...)
+	}
+
+	s.mu.Unlock()
+
+	s.forward(kept)
+}
+
+func (s *tailSampler) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(max(s.wait/tailTicksPerWait, time.Millisecond))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.expire()
+		}
+	}
+}
+
+// expire decides the traces whose decision wait has passed.
+func (s *tailSampler) expire() {
+	s.mu.Lock()
+
+	var kept []sdktrace.ReadOnlySpan
+
+	now := s.now()
+	for s.order.Len() > 0 {
+		front := s.order.Front()
+		if t, _ := front.Value.(*tailTrace); now.Sub(t.first) < s.wait {
+			break
+		}
+
+		kept = append(kept, s.decide(front)...)
+	}
+
+	s.mu.Unlock()
+
+	s.forward(kept)
+}
+
+// buffer appends span to its trace, starting a new one at the back of the order when needed.
+func (s *tailSampler) buffer(id trace.TraceID, span sdktrace.ReadOnlySpan) *list.Element {
+	elem, ok := s.traces[id]
+	if !ok {
+		elem = s.order.PushBack(&tailTrace{id: id, first: s.now()})
+		s.traces[id] = elem
+	}
+
+	t, _ := elem.Value.(*tailTrace)
+	t.spans = append(t.spans, span)
+	s.spans++
+
+	return elem
+}
+
+// decide removes the trace in elem from the buffer, evaluates the policies against it and
+// remembers the outcome. It returns the spans to forward, which is nil for a dropped trace.
+func (s *tailSampler) decide(elem *list.Element) []sdktrace.ReadOnlySpan {
+	t, _ := elem.Value.(*tailTrace)
+
+	s.order.Remove(elem)
+	delete(s.traces, t.id)
+	s.spans -= len(t.spans)
+
+	keep := false
+
+	for i := range s.policies {
+		if s.policies[i].policy.sample(t.id, t.spans) {
+			s.policies[i].matched.Add(1)
+
+			keep = true
+
+			break
+		}
+	}
+
+	delete(s.decided, s.recent[s.cursor])
+	s.recent[s.cursor] = t.id
+	s.cursor = (s.cursor + 1) % len(s.recent)
+	s.decided[t.id] = keep
+
+	if !keep {
+		s.dropped.Add(1)
+
+		return nil
+	}
+
+	s.sampled.Add(1)
+
+	return t.spans
+}
+
+func (s *tailSampler) forward(spans []sdktrace.ReadOnlySpan) {
+	for _, span := range spans {
+		for _, p := range s.next {
+			p.OnEnd(span)
+		}
+	}
+}
+
+func (s *tailSampler) snapshot() *diagnostics.TailSamplingStatus {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	traces, spans := s.order.Len(), s.spans
+	s.mu.Unlock()
+
+	status := &diagnostics.TailSamplingStatus{
+		BufferedTraces: int64(traces),
+		BufferedSpans:  int64(spans),
+		SampledTraces:  s.sampled.Load(),
+		DroppedTraces:  s.dropped.Load(),
+		EvictedTraces:  s.evicted.Load(),
+		LateSpans:      s.late.Load(),
+		Policies:       make(map[string]int64, len(s.policies)),
+	}
+
+	for i := range s.policies {
+		status.Policies[s.policies[i].name] = s.policies[i].matched.Load()
+	}
+
+	return status
+}
+
+// tailPolicy decides whether to keep a buffered trace. Policies are only evaluated with the
+// sampler's lock held, so stateful ones need no locking of their own.
+type tailPolicy interface {
+	sample(id trace.TraceID, spans []sdktrace.ReadOnlySpan) bool
+}
+
+// newTailPolicy compiles a validated policy; now is the sampler's clock.
+func newTailPolicy(cfg config.TailPolicyConfig, now func() time.Time) tailPolicy {
+	switch cfg.Type {
+	case "latency":
+		return latencyPolicy{threshold: cfg.Threshold}
+	case "attribute":
+		return attributePolicy{key: attribute.Key(cfg.Key), values: cfg.Values}
+	case "probabilistic":
+		return probabilisticPolicy{bound: uint64(cfg.Ratio * (1 << 63))}
+	case "rate_limit":
+		return &rateLimitPolicy{
+			bucket: tokenBucket{rate: cfg.Rate, burst: max(cfg.Rate, 1), tokens: max(cfg.Rate, 1), last: now()},
+			now:    now,
+		}
+	case "and", "or":
+		children := make([]tailPolicy, 0, len(cfg.Policies))
+		for _, child := range cfg.Policies {
+			children = append(children, newTailPolicy(child, now))
+		}
+
+		return compositePolicy{all: cfg.Type == "and", children: children}
+	default:
+		return errorPolicy{}
+	}
+}
+
+type errorPolicy struct{}
+
+func (errorPolicy) sample(_ trace.TraceID, spans []sdktrace.ReadOnlySpan) bool {
+	return slices.ContainsFunc(spans, func(span sdktrace.ReadOnlySpan) bool {
+		return span.Status().Code == codes.Error
+	})
+}
+
+type latencyPolicy struct {
+	threshold time.Duration
+}
+
+func (p latencyPolicy) sample(_ trace.TraceID, spans []sdktrace.ReadOnlySpan) bool {
+	var start, end time.Time
+
+	for _, span := range spans {
+		if start.IsZero() || span.StartTime().Before(start) {
+			start = span.StartTime()
+		}
+
+		if span.EndTime().After(end) {
+			end = span.EndTime()
+		}
+	}
+
+	return end.Sub(start) >= p.threshold
+}
+
+type attributePolicy struct {
+	key    attribute.Key
+	values []string
+}
+
+func (p attributePolicy) sample(_ trace.TraceID, spans []sdktrace.ReadOnlySpan) bool {
+	for _, span := range spans {
+		for _, attr := range span.Attributes() {
+			if attr.Key == p.key && (len(p.values) == 0 || slices.Contains(p.values, attr.Value.Emit())) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// probabilisticPolicy keeps a trace when the low 63 bits of its trace ID fall below bound,
+// the same test the SDK's TraceIDRatioBased sampler applies.
+type probabilisticPolicy struct {
+	bound uint64
+}
+
+func (p probabilisticPolicy) sample(id trace.TraceID, _ []sdktrace.ReadOnlySpan) bool {
+	return binary.BigEndian.Uint64(id[8:16])>>1 < p.bound
+}
+
+type rateLimitPolicy struct {
+	bucket tokenBucket
+	now    func() time.Time
+}
+
+func (p *rateLimitPolicy) sample(trace.TraceID, []sdktrace.ReadOnlySpan) bool {
+	return p.bucket.take(p.now())
+}
+
+// compositePolicy combines its children with AND when all is set and OR otherwise,
+// short-circuiting in order.
+type compositePolicy struct {
+	all      bool
+	children []tailPolicy
+}
+
+func (p compositePolicy) sample(id trace.TraceID, spans []sdktrace.ReadOnlySpan) bool {
+	for _, child := range p.children {
+		if child.sample(id, spans) != p.all {
+			return !p.all
+		}
+	}
+
+	return p.all
+}
@@ -0,0 +1,267 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hyp3rd/observe/pkg/config"
+)
+
+func TestTailSamplerDropsTracesMatchingNoPolicy(t *testing.T) {
+	t.Parallel()
+
+	tail := newTailSampler(config.TailSamplingConfig{
+		Enabled:      true,
+		DecisionWait: time.Hour,
+		Policies:     []config.TailPolicyConfig{{Name: "errors", Type: "error"}},
+	})
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(tail.wrap([]sdktrace.SpanProcessor{recorder})[0]))
+
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	tracer := provider.Tracer("tail")
+
+	ctx, root := tracer.Start(context.Background(), "fast")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+	root.End()
+
+	if len(recorder.Ended()) != 0 {
+		t.Fatal("expected a fast, successful trace to be dropped")
+	}
+
+	if status := tail.snapshot(); status.DroppedTraces != 1 || status.BufferedTraces != 0 {
+		t.Fatalf("expected the trace to be decided once its root ended, got %+v", status)
+	}
+}
+
+func TestTailSamplerKeepsWholeErroredTraces(t *testing.T) {
+	t.Parallel()
+
+	tail := newTailSampler(config.TailSamplingConfig{
+		Enabled:      true,
+		DecisionWait: time.Hour,
+		Policies:     []config.TailPolicyConfig{{Name: "errors", Type: "error"}},
+	})
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(tail.wrap([]sdktrace.SpanProcessor{recorder})[0]))
+
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	tracer := provider.Tracer("tail")
+
+	ctx, root := tracer.Start(context.Background(), "failing")
+	_, straggler := tracer.Start(ctx, "straggler")
+
+	_, child := tracer.Start(ctx, "child")
+	child.SetStatus(codes.Error, "boom")
+	child.End()
+
+	if len(recorder.Ended()) != 0 {
+		t.Fatal("expected spans to be held until the root ends")
+	}
+
+	root.End()
+
+	if len(recorder.Ended()) != 2 {
+		t.Fatalf("expected the whole errored trace to be exported, got %d spans", len(recorder.Ended()))
+	}
+
+	straggler.End()
+
+	if len(recorder.Ended()) != 3 {
+		t.Fatal("expected a span ending after the decision to follow it")
+	}
+
+	status := tail.snapshot()
+	if status.SampledTraces != 1 || status.LateSpans != 1 || status.Policies["errors"] != 1 {
+		t.Fatalf("unexpected decisions: %+v", status)
+	}
+}
+
+func TestTailSamplerEvaluatesCompositePolicies(t *testing.T) {
+	t.Parallel()
+
+	tail := newTailSampler(config.TailSamplingConfig{
+		Enabled:      true,
+		DecisionWait: time.Hour,
+		Policies: []config.TailPolicyConfig{
+			{Name: "slow-checkout", Type: "and", Policies: []config.TailPolicyConfig{
+				{Type: "attribute", Key: "http.route", Values: []string{"/checkout"}},
+				{Type: "latency", Threshold: time.Second},
+			}},
+		},
+	})
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(tail.wrap([]sdktrace.SpanProcessor{recorder})[0]))
+
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	tracer := provider.Tracer("tail")
+	start := time.Unix(100, 0)
+	checkout := trace.WithAttributes(attribute.String("http.route", "/checkout"))
+
+	_, slow := tracer.Start(context.Background(), "checkout", checkout, trace.WithTimestamp(start))
+	slow.End(trace.WithTimestamp(start.Add(2 * time.Second)))
+
+	_, quick := tracer.Start(context.Background(), "checkout", checkout, trace.WithTimestamp(start))
+	quick.End(trace.WithTimestamp(start.Add(100 * time.Millisecond)))
+
+	if len(recorder.Ended()) != 1 {
+		t.Fatal("expected only the slow checkout to match the composite policy")
+	}
+
+	if status := tail.snapshot(); status.Policies["slow-checkout"] != 1 || status.DroppedTraces != 1 {
+		t.Fatalf("unexpected decisions: %+v", status)
+	}
+}
+
+func TestTailSamplerDecidesAfterTheDecisionWait(t *testing.T) {
+	t.Parallel()
+
+	tail := newTailSampler(config.TailSamplingConfig{
+		Enabled:      true,
+		DecisionWait: time.Hour,
+		Policies:     []config.TailPolicyConfig{{Name: "errors", Type: "error"}},
+	})
+	now := time.Unix(0, 0)
+	tail.now = func() time.Time { return now }
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(tail.wrap([]sdktrace.SpanProcessor{recorder})[0]))
+
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	tracer := provider.Tracer("tail")
+
+	// The root of this trace does not end here.
+	ctx, open := tracer.Start(context.Background(), "open")
+	_, child := tracer.Start(ctx, "child")
+	child.SetStatus(codes.Error, "boom")
+	child.End()
+
+	now = now.Add(time.Hour)
+	tail.expire()
+
+	if len(recorder.Ended()) != 1 {
+		t.Fatal("expected the decision wait to release the buffered trace")
+	}
+
+	open.End()
+
+	if len(recorder.Ended()) != 2 || tail.snapshot().LateSpans != 1 {
+		t.Fatal("expected the root ending after the decision to follow it")
+	}
+}
+
+func TestTailSamplerCapsBufferedTraces(t *testing.T) {
+	t.Parallel()
+
+	tail := newTailSampler(config.TailSamplingConfig{
+		Enabled:      true,
+		DecisionWait: time.Hour,
+		MaxTraces:    2,
+		Policies:     []config.TailPolicyConfig{{Name: "errors", Type: "error"}},
+	})
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(tail.wrap([]sdktrace.SpanProcessor{recorder})[0]))
+
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	tracer := provider.Tracer("tail")
+
+	// With room for two traces, a third decides the oldest one early.
+	for range 3 {
+		ctx, _ := tracer.Start(context.Background(), "burst")
+		_, child := tracer.Start(ctx, "child")
+		child.SetStatus(codes.Error, "boom")
+		child.End()
+	}
+
+	status := tail.snapshot()
+	if status.BufferedTraces != 2 || status.BufferedSpans != 2 || status.EvictedTraces != 1 {
+		t.Fatalf("expected the buffer to stay within its cap, got %+v", status)
+	}
+
+	if len(recorder.Ended()) != 1 {
+		t.Fatalf("expected the evicted trace to be decided, got %d spans", len(recorder.Ended()))
+	}
+}
+
+func TestTailSamplerDecidesBufferedTracesOnShutdown(t *testing.T) {
+	t.Parallel()
+
+	tail := newTailSampler(config.TailSamplingConfig{
+		Enabled:      true,
+		DecisionWait: time.Hour,
+		Policies:     []config.TailPolicyConfig{{Name: "errors", Type: "error"}},
+	})
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(tail.wrap([]sdktrace.SpanProcessor{recorder})[0]))
+	tracer := provider.Tracer("tail")
+
+	for range 2 {
+		ctx, _ := tracer.Start(context.Background(), "pending")
+		_, child := tracer.Start(ctx, "child")
+		child.SetStatus(codes.Error, "boom")
+		child.End()
+	}
+
+	err := provider.Shutdown(context.Background())
+	if err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	if len(recorder.Ended()) != 2 {
+		t.Fatalf("expected shutdown to decide the buffered traces, got %d spans", len(recorder.Ended()))
+	}
+
+	if status := tail.snapshot(); status.SampledTraces != 2 || status.BufferedTraces != 0 {
+		t.Fatalf("unexpected decisions: %+v", status)
+	}
+}
+
+// shutdownCounter counts the Shutdown calls reaching the processor it wraps.
+type shutdownCounter struct {
+	sdktrace.SpanProcessor
+
+	calls atomic.Int32
+}
+
+func (p *shutdownCounter) Shutdown(ctx context.Context) error {
+	p.calls.Add(1)
+
+	return p.SpanProcessor.Shutdown(ctx)
+}
+
+func TestTailSamplerShutsDownExportersWhenShutdownTimesOut(t *testing.T) {
+	t.Parallel()
+
+	tail := newTailSampler(config.TailSamplingConfig{Enabled: true})
+	next := &shutdownCounter{SpanProcessor: tracetest.NewSpanRecorder()}
+
+	// Without wrap the background loop never runs, so Shutdown gives up waiting for it.
+	tail.next = []sdktrace.SpanProcessor{next}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := tail.Shutdown(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the expired context to be reported, got %v", err)
+	}
+
+	if next.calls.Load() != 1 {
+		t.Fatal("expected the exporter processors to be shut down anyway")
+	}
+}
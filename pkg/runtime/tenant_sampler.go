@@ -0,0 +1,256 @@
+package runtime
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hyp3rd/observe/pkg/config"
+	"github.com/hyp3rd/observe/pkg/diagnostics"
+)
+
+const (
+	defaultTenantKey        = "tenant.id"
+	defaultTenantMaxTracked = 10000
+
+	// otherTenant labels throttled traces of tenants without an override, so the tenant
+	// metric's cardinality is bounded by the configuration rather than by callers.
+	otherTenant = "other"
+)
+
+// throttleRecorder is called for every trace the tenant limiter drops, with the tenant's
+// metric label: the tenant itself when it has an override and otherTenant otherwise.
+type throttleRecorder func(ctx context.Context, tenant string)
+
+// tenantLimiter keeps one token bucket per tenant. A bucket holds up to burst tokens and
+// refills at the tenant's rate; every sampled trace takes one token. Buckets live in an LRU
+// bounded by capacity, so an evicted tenant simply starts over with a full bucket.
+type tenantLimiter struct {
+	key       string
+	rate      float64
+	burst     float64
+	overrides map[string]float64
+	capacity  int
+	now       func() time.Time
+
+	mu      sync.Mutex
+	tenants map[string]*list.Element
+	lru     *list.List
+
+	throttled atomic.Int64
+	recorder  atomic.Pointer[throttleRecorder]
+}
+
+type tenantBucket struct {
+	tenant    string
+	throttled int64
+	tokenBucket
+}
+
+// tokenBucket holds up to burst tokens and refills at rate tokens per second.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// take refills the bucket up to now and takes a token, reporting whether one was available.
+func (b *tokenBucket) take(now time.Time) bool {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = min(b.burst, b.tokens+elapsed*b.rate)
+		b.last = now
+	}
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+
+	return true
+}
+
+// newTenantLimiter returns nil when the limiter is not enabled.
+func newTenantLimiter(cfg config.TenantLimiterConfig) *tenantLimiter {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	key := cfg.Key
+	if key == "" {
+		key = defaultTenantKey
+	}
+
+	return &tenantLimiter{
+		key:       key,
+		rate:      cfg.Rate,
+		burst:     cfg.Burst,
+		overrides: cfg.Overrides,
+		capacity:  intOr(cfg.MaxTenants, defaultTenantMaxTracked),
+		now:       time.Now,
+		tenants:   map[string]*list.Element{},
+		lru:       list.New(),
+	}
+}
+
+// sampler wraps base with the limiter; a nil limiter returns base unchanged.
+func (l *tenantLimiter) sampler(base sdktrace.Sampler) sdktrace.Sampler {
+	if l == nil {
+		return base
+	}
+
+	return tenantSampler{base: base, limiter: l}
+}
+
+// allow takes a token from tenant's bucket, reporting whether one was available and
+// counting the refusal against the tenant otherwise.
+func (l *tenantLimiter) allow(tenant string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	bucket := l.bucket(tenant, now)
+
+	if bucket.take(now) {
+		return true
+	}
+
+	bucket.throttled++
+
+	return false
+}
+
+// bucket returns tenant's bucket, marking it most recently used and evicting the least
+// recently used one when the LRU is full.
+func (l *tenantLimiter) bucket(tenant string, now time.Time) *tenantBucket {
+	if elem, ok := l.tenants[tenant]; ok {
+		l.lru.MoveToFront(elem)
+
+		bucket, _ := elem.Value.(*tenantBucket)
+
+		return bucket
+	}
+
+	if l.lru.Len() >= l.capacity {
+		oldest := l.lru.Back()
+		if evicted, ok := oldest.Value.(*tenantBucket); ok {
+			delete(l.tenants, evicted.tenant)
+		}
+
+		l.lru.Remove(oldest)
+	}
+
+	rate := l.rate
+	if override, ok := l.overrides[tenant]; ok {
+		rate = override
+	}
+
+	burst := l.burst
+	if burst <= 0 {
+		burst = max(rate, 1)
+	}
+
+	if rate <= 0 {
+		burst = 0
+	}
+
+	bucket := &tenantBucket{tenant: tenant, tokenBucket: tokenBucket{rate: rate, burst: burst, tokens: burst, last: now}}
+	l.tenants[tenant] = l.lru.PushFront(bucket)
+
+	return bucket
+}
+
+func (l *tenantLimiter) throttle(ctx context.Context, tenant string) {
+	l.throttled.Add(1)
+
+	if recorder := l.recorder.Load(); recorder != nil {
+		label := otherTenant
+		if _, ok := l.overrides[tenant]; ok {
+			label = tenant
+		}
+
+		(*recorder)(ctx, label)
+	}
+}
+
+func (l *tenantLimiter) snapshot() *diagnostics.TenantLimiterStatus {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	status := &diagnostics.TenantLimiterStatus{
+		TrackedTenants:  int64(l.lru.Len()),
+		ThrottledTraces: l.throttled.Load(),
+	}
+
+	for elem := l.lru.Front(); elem != nil; elem = elem.Next() {
+		if bucket, _ := elem.Value.(*tenantBucket); bucket.throttled > 0 {
+			if status.ThrottledByTenant == nil {
+				status.ThrottledByTenant = map[string]int64{}
+			}
+
+			status.ThrottledByTenant[bucket.tenant] = bucket.throttled
+		}
+	}
+
+	return status
+}
+
+// tenantSampler applies the tenant budget to traces the base sampler keeps. Only spans that
+// start a trace in this process, roots or children of a remote parent, take a token; local
+// children follow their parent, so a throttled trace is dropped as a whole.
+type tenantSampler struct {
+	base    sdktrace.Sampler
+	limiter *tenantLimiter
+}
+
+func (s tenantSampler) ShouldSample(params sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	parent := trace.SpanContextFromContext(params.ParentContext)
+	dropped := sdktrace.SamplingResult{Decision: sdktrace.Drop, Tracestate: parent.TraceState()}
+
+	if parent.IsValid() && !parent.IsRemote() && !parent.IsSampled() {
+		return dropped
+	}
+
+	result := s.base.ShouldSample(params)
+	if result.Decision != sdktrace.RecordAndSample || (parent.IsValid() && !parent.IsRemote()) {
+		return result
+	}
+
+	tenant := s.tenant(params)
+	if tenant == "" || s.limiter.allow(tenant) {
+		return result
+	}
+
+	s.limiter.throttle(params.ParentContext, tenant)
+
+	return dropped
+}
+
+func (s tenantSampler) Description() string {
+	return fmt.Sprintf("TenantLimiter{key:%s,base:%s}", s.limiter.key, s.base.Description())
+}
+
+// tenant reads the tenant from the span attributes, falling back to the propagated baggage.
+func (s tenantSampler) tenant(params sdktrace.SamplingParameters) string {
+	key := attribute.Key(s.limiter.key)
+	for _, attr := range params.Attributes {
+		if attr.Key == key {
+			return attr.Value.Emit()
+		}
+	}
+
+	return baggage.FromContext(params.ParentContext).Member(s.limiter.key).Value()
+}
@@ -0,0 +1,149 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hyp3rd/observe/pkg/config"
+)
+
+func tenantRoot(tenant string) sdktrace.SamplingParameters {
+	return sdktrace.SamplingParameters{
+		ParentContext: context.Background(),
+		Name:          "request",
+		Attributes:    []attribute.KeyValue{attribute.String("tenant.id", tenant)},
+	}
+}
+
+func TestTenantSamplerEnforcesPerTenantBudget(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	limiter := newTenantLimiter(config.TenantLimiterConfig{Enabled: true, Rate: 2})
+	limiter.now = func() time.Time { return now }
+
+	var throttled []string
+
+	var recorder throttleRecorder = func(_ context.Context, tenant string) { throttled = append(throttled, tenant) }
+	limiter.recorder.Store(&recorder)
+
+	sampler := limiter.sampler(sdktrace.AlwaysSample())
+
+	for range 2 {
+		if sampler.ShouldSample(tenantRoot("noisy")).Decision != sdktrace.RecordAndSample {
+			t.Fatal("expected traces within the burst to be sampled")
+		}
+	}
+
+	if sampler.ShouldSample(tenantRoot("noisy")).Decision != sdktrace.Drop {
+		t.Fatal("expected the tenant to be throttled once its bucket is empty")
+	}
+
+	if sampler.ShouldSample(tenantRoot("quiet")).Decision != sdktrace.RecordAndSample {
+		t.Fatal("expected another tenant to keep its own budget")
+	}
+
+	now = now.Add(500 * time.Millisecond)
+
+	if sampler.ShouldSample(tenantRoot("noisy")).Decision != sdktrace.RecordAndSample {
+		t.Fatal("expected the bucket to refill at the configured rate")
+	}
+
+	if status := limiter.snapshot(); status.ThrottledTraces != 1 || status.ThrottledByTenant["noisy"] != 1 {
+		t.Fatalf("expected the throttled trace to be reported per tenant, got %+v", status)
+	}
+
+	// Tenants without an override share one metric label, whatever callers send.
+	if len(throttled) != 1 || throttled[0] != otherTenant {
+		t.Fatalf("expected the metric label %q, got %v", otherTenant, throttled)
+	}
+}
+
+func TestTenantSamplerDropsTenantsOverriddenToZero(t *testing.T) {
+	t.Parallel()
+
+	limiter := newTenantLimiter(config.TenantLimiterConfig{Enabled: true, Rate: 2, Overrides: map[string]float64{"blocked": 0}})
+
+	var throttled []string
+
+	var recorder throttleRecorder = func(_ context.Context, tenant string) { throttled = append(throttled, tenant) }
+	limiter.recorder.Store(&recorder)
+
+	sampler := limiter.sampler(sdktrace.AlwaysSample())
+
+	if sampler.ShouldSample(tenantRoot("blocked")).Decision != sdktrace.Drop {
+		t.Fatal("expected a zero override to drop every trace")
+	}
+
+	// The tenant can also arrive as baggage.
+	member, _ := baggage.NewMember("tenant.id", "blocked")
+	bag, _ := baggage.New(member)
+
+	if sampler.ShouldSample(sdktrace.SamplingParameters{ParentContext: baggage.ContextWithBaggage(context.Background(), bag)}).Decision !=
+		sdktrace.Drop {
+		t.Fatal("expected the tenant to be read from baggage")
+	}
+
+	if len(throttled) != 2 || throttled[0] != "blocked" || throttled[1] != "blocked" {
+		t.Fatalf("expected overridden tenants to keep their own metric label, got %v", throttled)
+	}
+}
+
+func TestTenantSamplerSkipsSpansWithoutABudget(t *testing.T) {
+	t.Parallel()
+
+	// A local child of a throttled root follows it rather than taking a token.
+	unsampledParent := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{1},
+	}))
+
+	tests := []struct {
+		name   string
+		params sdktrace.SamplingParameters
+		want   sdktrace.SamplingDecision
+	}{
+		{name: "no tenant", params: sdktrace.SamplingParameters{ParentContext: context.Background()}, want: sdktrace.RecordAndSample},
+		{name: "unsampled local parent", params: sdktrace.SamplingParameters{ParentContext: unsampledParent}, want: sdktrace.Drop},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			limiter := newTenantLimiter(config.TenantLimiterConfig{Enabled: true, Rate: 1})
+			sampler := limiter.sampler(sdktrace.AlwaysSample())
+
+			for range 3 {
+				if got := sampler.ShouldSample(tc.params).Decision; got != tc.want {
+					t.Fatalf("expected decision %v, got %v", tc.want, got)
+				}
+			}
+
+			if status := limiter.snapshot(); status.TrackedTenants != 0 || status.ThrottledTraces != 0 {
+				t.Fatalf("expected the limiter not to be consulted, got %+v", status)
+			}
+		})
+	}
+}
+
+func TestTenantSamplerBoundsTrackedTenants(t *testing.T) {
+	t.Parallel()
+
+	limiter := newTenantLimiter(config.TenantLimiterConfig{Enabled: true, Rate: 2, MaxTenants: 2})
+	sampler := limiter.sampler(sdktrace.AlwaysSample())
+
+	for _, tenant := range []string{"a", "b", "c"} {
+		sampler.ShouldSample(tenantRoot(tenant))
+	}
+
+	if status := limiter.snapshot(); status.TrackedTenants != 2 {
+		t.Fatalf("expected the least recently seen tenant to be forgotten, got %+v", status)
+	}
+}
@@ -0,0 +1,121 @@
+package runtime
+
+import (
+	"strings"
+
+	"github.com/hyp3rd/ewrap"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	"github.com/hyp3rd/observe/pkg/config"
+)
+
+const (
+	defaultExponentialMaxSize  = 160
+	defaultExponentialMaxScale = 20
+)
+
+var instrumentKinds = map[string]sdkmetric.InstrumentKind{
+	"counter":                    sdkmetric.InstrumentKindCounter,
+	"up_down_counter":            sdkmetric.InstrumentKindUpDownCounter,
+	"histogram":                  sdkmetric.InstrumentKindHistogram,
+	"gauge":                      sdkmetric.InstrumentKindGauge,
+	"observable_counter":         sdkmetric.InstrumentKindObservableCounter,
+	"observable_up_down_counter": sdkmetric.InstrumentKindObservableUpDownCounter,
+	"observable_gauge":           sdkmetric.InstrumentKindObservableGauge,
+}
+
+// buildViews compiles metrics.views into SDK views, in configuration order.
+func buildViews(cfg config.MetricsConfig) ([]sdkmetric.View, error) {
+	views := make([]sdkmetric.View, 0, len(cfg.Views))
+
+	for idx, viewCfg := range cfg.Views {
+		view, err := buildView(viewCfg)
+		if err != nil {
+			return nil, ewrap.Wrapf(err, "metrics.views[%d]", idx)
+		}
+
+		views = append(views, view)
+	}
+
+	return views, nil
+}
+
+func buildView(cfg config.MetricViewConfig) (sdkmetric.View, error) {
+	criteria := sdkmetric.Instrument{
+		Name:  cfg.Match.Instrument,
+		Scope: instrumentation.Scope{Name: cfg.Match.Meter},
+	}
+
+	if cfg.Match.Kind != "" {
+		kind, ok := instrumentKinds[cfg.Match.Kind]
+		if !ok {
+			return nil, ewrap.Newf("unsupported instrument kind %q", cfg.Match.Kind)
+		}
+
+		criteria.Kind = kind
+	}
+
+	if criteria.Name == "" && criteria.Scope.Name == "" && criteria.Kind == 0 {
+		return nil, ewrap.New("match requires instrument, meter or kind")
+	}
+
+	mask := sdkmetric.Stream{
+		Name:            cfg.Name,
+		Description:     cfg.Description,
+		Aggregation:     viewAggregation(cfg),
+		AttributeFilter: attributeFilter(cfg),
+	}
+
+	// NewView only logs invalid combinations; surface them as configuration errors instead.
+	if mask.Name != "" && (criteria.Name == "" || strings.ContainsAny(criteria.Name, "*?")) {
+		return nil, ewrap.New("name can only be set when match.instrument is an exact name")
+	}
+
+	return sdkmetric.NewView(criteria, mask), nil
+}
+
+func viewAggregation(cfg config.MetricViewConfig) sdkmetric.Aggregation {
+	switch {
+	case cfg.Drop:
+		return sdkmetric.AggregationDrop{}
+	case len(cfg.Buckets) > 0:
+		return sdkmetric.AggregationExplicitBucketHistogram{Boundaries: cfg.Buckets}
+	case cfg.Exponential != nil:
+		return sdkmetric.AggregationBase2ExponentialHistogram{
+			MaxSize:  orDefault(cfg.Exponential.MaxSize, defaultExponentialMaxSize),
+			MaxScale: orDefault(cfg.Exponential.MaxScale, defaultExponentialMaxScale),
+		}
+	default:
+		return nil
+	}
+}
+
+func orDefault(value, fallback int32) int32 {
+	if value > 0 {
+		return value
+	}
+
+	return fallback
+}
+
+func attributeFilter(cfg config.MetricViewConfig) attribute.Filter {
+	switch {
+	case len(cfg.KeepAttributes) > 0:
+		return attribute.NewAllowKeysFilter(keys(cfg.KeepAttributes)...)
+	case len(cfg.DropAttributes) > 0:
+		return attribute.NewDenyKeysFilter(keys(cfg.DropAttributes)...)
+	default:
+		return nil
+	}
+}
+
+func keys(names []string) []attribute.Key {
+	out := make([]attribute.Key, 0, len(names))
+	for _, name := range names {
+		out = append(out, attribute.Key(name))
+	}
+
+	return out
+}
@@ -0,0 +1,108 @@
+package runtime
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"github.com/hyp3rd/observe/pkg/config"
+)
+
+func TestBuildViewsRewritesStreams(t *testing.T) {
+	t.Parallel()
+
+	views, err := buildViews(config.MetricsConfig{Views: []config.MetricViewConfig{
+		{
+			Match:          config.MetricViewMatch{Instrument: "http.server.duration.ms"},
+			Name:           "http.server.latency",
+			Description:    "Server latency",
+			Buckets:        []float64{5, 25, 100, 500},
+			KeepAttributes: []string{"http.route"},
+		},
+		{Match: config.MetricViewMatch{Instrument: "worker.*", Kind: "histogram"}, Exponential: &config.ExponentialBucketConfig{}},
+		{Match: config.MetricViewMatch{Meter: "noisy"}, Drop: true},
+	}})
+	if err != nil {
+		t.Fatalf("buildViews: %v", err)
+	}
+
+	reader := sdkmetric.NewManualReader()
+	mp := buildMeterProvider(resource.Empty(), []sdkmetric.Reader{reader}, views)
+	ctx := context.Background()
+
+	latency, _ := mp.Meter("http").Float64Histogram("http.server.duration.ms")
+	latency.Record(ctx, 42, metric.WithAttributes(attribute.String("http.route", "/orders"), attribute.String("user.id", "u1")))
+
+	jobs, _ := mp.Meter("worker").Float64Histogram("worker.job.duration_ms")
+	jobs.Record(ctx, 7)
+
+	noisy, _ := mp.Meter("noisy").Int64Counter("chatter")
+	noisy.Add(ctx, 1)
+
+	var rm metricdata.ResourceMetrics
+
+	err = reader.Collect(ctx, &rm)
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+
+	metrics := map[string]metricdata.Metrics{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m
+		}
+	}
+
+	if _, ok := metrics["chatter"]; ok {
+		t.Fatal("expected the noisy meter to be dropped")
+	}
+
+	renamed, ok := metrics["http.server.latency"]
+	if !ok || renamed.Description != "Server latency" {
+		t.Fatalf("expected renamed http histogram, got %v", metrics)
+	}
+
+	point := renamed.Data.(metricdata.Histogram[float64]).DataPoints[0]
+	if !slices.Equal(point.Bounds, []float64{5, 25, 100, 500}) {
+		t.Fatalf("expected custom buckets, got %v", point.Bounds)
+	}
+
+	if point.Attributes.Len() != 1 || !point.Attributes.HasValue("http.route") {
+		t.Fatalf("expected only http.route to be kept, got %v", point.Attributes.ToSlice())
+	}
+
+	if _, ok := metrics["worker.job.duration_ms"].Data.(metricdata.ExponentialHistogram[float64]); !ok {
+		t.Fatalf("expected an exponential histogram for worker jobs, got %T", metrics["worker.job.duration_ms"].Data)
+	}
+}
+
+func TestBuildViewsRejectsInvalidViews(t *testing.T) {
+	t.Parallel()
+
+	for _, view := range []config.MetricViewConfig{
+		{},
+		{Match: config.MetricViewMatch{Kind: "sundial"}},
+		{Match: config.MetricViewMatch{Instrument: "http.*"}, Name: "renamed"},
+	} {
+		_, err := buildViews(config.MetricsConfig{Views: []config.MetricViewConfig{view}})
+		if err == nil {
+			t.Fatalf("expected %+v to be rejected", view)
+		}
+
+		err = config.Validate(config.Config{
+			Service:   config.ServiceConfig{Name: "svc"},
+			Exporters: config.ExporterConfig{OTLP: &config.OTLPConfig{Endpoint: "collector:4317"}},
+			Metrics:   config.MetricsConfig{Views: []config.MetricViewConfig{view}},
+			Sampling:  config.SamplingConfig{Mode: "always_on"},
+		})
+		if err == nil {
+			t.Fatalf("expected validation to reject %+v", view)
+		}
+	}
+}